@@ -1,13 +1,19 @@
 package main
 
 import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
 	"TaskSync/internal/service"
 	"TaskSync/internal/storage"
 	"TaskSync/internal/storage/postgres"
+	"TaskSync/internal/storage/sqlite"
 	"TaskSync/internal/transport/http-server/handler"
 	"TaskSync/internal/transport/http-server/server"
 	"TaskSync/pkg/logger"
 	migrations "TaskSync/pkg/migration"
+	"context"
+	"database/sql"
+	"errors"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -25,6 +31,16 @@ import (
 // @host localhost:8080
 // @basePath /
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Access token from /auth/login in the form "Bearer <token>".
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description API key from /apikeys, access is limited by the key scopes.
+
 func main() {
 	// Загрузка переменных окружения из файла .env
 	if err := godotenv.Load(); err != nil {
@@ -34,6 +50,160 @@ func main() {
 	// Настройка логгера
 	log := logger.SetupLogger(os.Getenv("ENV"))
 
+	// Выбор хранилища
+	var repositories *storage.Storage
+
+	switch driver := os.Getenv("DB_DRIVER"); driver {
+	case "", "postgres":
+		repositories = storage.NewStorage(newPostgresDB(log))
+	case "sqlite":
+		repositories = storage.NewSQLiteStorage(newSQLiteDB(log))
+	case "memory":
+		repositories = storage.NewMemoryStorage()
+		log.Warn("Using in-memory storage, data will be lost on restart")
+	default:
+		log.Error("invalid DB_DRIVER", slog.String("driver", driver))
+		panic("invalid DB_DRIVER: " + driver)
+	}
+
+	// Политика обработки пересечений записей времени
+	overlapPolicy, err := service.ParseOverlapPolicy(os.Getenv("TIME_OVERLAP_POLICY"))
+	if err != nil {
+		log.Error("invalid TIME_OVERLAP_POLICY", slog.Any("error", err))
+		panic(err)
+	}
+
+	// Таблица переходов между статусами задач
+	workflow, err := service.ParseWorkflow(os.Getenv("TASK_WORKFLOW"))
+	if err != nil {
+		log.Error("invalid TASK_WORKFLOW", slog.Any("error", err))
+		panic(err)
+	}
+
+	// Настройки авторизации
+	jwtSecret := os.Getenv("AUTH_JWT_SECRET")
+	if jwtSecret == "" {
+		log.Error("AUTH_JWT_SECRET is not set")
+		panic("AUTH_JWT_SECRET is not set")
+	}
+
+	accessTTL, err := time.ParseDuration(os.Getenv("AUTH_ACCESS_TTL"))
+	if err != nil {
+		log.Error("failed to parse AUTH_ACCESS_TTL", slog.Any("error", err))
+		panic(err)
+	}
+
+	refreshTTL, err := time.ParseDuration(os.Getenv("AUTH_REFRESH_TTL"))
+	if err != nil {
+		log.Error("failed to parse AUTH_REFRESH_TTL", slog.Any("error", err))
+		panic(err)
+	}
+
+	// Период проверки повторяющихся задач, 0 отключает планировщик
+	recurrenceInterval := time.Minute
+	if value := os.Getenv("RECURRENCE_INTERVAL"); value != "" {
+		recurrenceInterval, err = time.ParseDuration(value)
+		if err != nil {
+			log.Error("failed to parse RECURRENCE_INTERVAL", slog.Any("error", err))
+			panic(err)
+		}
+	}
+
+	// Инициализация сервисов и обработчиков
+	services := service.NewService(repositories, service.Config{
+		OverlapPolicy: overlapPolicy,
+		Workflow:      workflow,
+		Auth: service.AuthConfig{
+			Secret:     []byte(jwtSecret),
+			AccessTTL:  accessTTL,
+			RefreshTTL: refreshTTL,
+		},
+	})
+
+	// Первоначальный пароль администратора, задаётся только если у пользователя ещё нет пароля
+	if bootstrapID := os.Getenv("AUTH_BOOTSTRAP_PEOPLE_ID"); bootstrapID != "" {
+		peopleID, err := strconv.Atoi(bootstrapID)
+		if err != nil {
+			log.Error("failed conv str to int", slog.Any("error", err))
+			panic(err)
+		}
+
+		peopleID, err = bootstrapPeopleID(context.Background(), repositories.PeopleManage, peopleID, isLocalDriver(os.Getenv("DB_DRIVER")))
+		if err != nil {
+			log.Error("failed to create bootstrap people", slog.Any("error", err))
+			panic(err)
+		}
+
+		set, err := services.Auth.InitPassword(context.Background(), peopleID, os.Getenv("AUTH_BOOTSTRAP_PASSWORD"))
+		if err != nil {
+			log.Error("failed to set bootstrap password", slog.Any("error", err))
+		} else if set {
+			log.Info("Bootstrap password set", slog.Int("people_id", peopleID))
+
+			// Первый пользователь получает роль администратора, чтобы управлять остальными
+			err = services.People.Update(context.Background(), entities.People{ID: peopleID, Role: entities.RoleAdmin})
+			if err != nil {
+				log.Error("failed to grant bootstrap admin role", slog.Any("error", err))
+			}
+		}
+	}
+	handlers := handler.NewHandler(services)
+
+	// Инициализация логгера
+	handlers.InitLogger(log)
+
+	// Настройка и запуск сервера
+	srv := &server.Server{}
+	log.Info("Starting server...")
+
+	go func() {
+		if err = srv.Run(os.Getenv("SERVER_HOST")+":"+os.Getenv("SERVER_PORT"), handlers.InitRouter()); err != nil {
+			log.Error("error starting server", slog.Any("error", err))
+			panic(err)
+		}
+	}()
+
+	// Планировщик повторяющихся задач
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if recurrenceInterval > 0 {
+		go runRecurrences(ctx, log, services.Recurrence, recurrenceInterval)
+	}
+
+	// graceful shutdown
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	sig := <-sigChan
+	log.Info("Stopped by Admin", "Signal", sig)
+}
+
+// runRecurrences создаёт задачи наступивших повторений сразу при запуске и затем каждые interval, пока ctx не отменён.
+// Повторения, созданные до перезапуска, повторно не создаются.
+func runRecurrences(ctx context.Context, log *slog.Logger, recurrences service.Recurrence, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		created, err := recurrences.Materialize(ctx, time.Now().UTC())
+		if err != nil {
+			log.Error("failed to create recurring tasks", slog.Any("error", err))
+		}
+		if created > 0 {
+			log.Info("Recurring tasks created", slog.Int("count", created))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// newPostgresDB подключается к PostgreSQL и применяет миграции.
+func newPostgresDB(log *slog.Logger) *sql.DB {
 	// Конвертация в int
 	attempts, err := strconv.Atoi(os.Getenv("DB_ATTEMPTS"))
 	if err != nil {
@@ -71,29 +241,71 @@ func main() {
 
 	log.Info("Migrations applied successfully!")
 
-	// Инициализация хранилища, сервисов и обработчиков
-	repositories := storage.NewStorage(db)
-	services := service.NewService(repositories)
-	handlers := handler.NewHandler(services)
+	return db
+}
 
-	// Инициализация логгера
-	handlers.InitLogger(log)
+// newSQLiteDB открывает файл SQLite из DB_PATH и применяет миграции SQLite.
+func newSQLiteDB(log *slog.Logger) *sql.DB {
+	path := os.Getenv("DB_PATH")
+	if path == "" {
+		path = "task-sync.db"
+	}
 
-	// Настройка и запуск сервера
-	srv := &server.Server{}
-	log.Info("Starting server...")
+	db, err := sqlite.NewSQLiteDB(path)
+	if err != nil {
+		log.Error("failed to init SQLite", slog.Any("error", err))
+		panic(err)
+	}
 
-	go func() {
-		if err = srv.Run(os.Getenv("SERVER_HOST")+":"+os.Getenv("SERVER_PORT"), handlers.InitRouter()); err != nil {
-			log.Error("error starting server", slog.Any("error", err))
-			panic(err)
-		}
-	}()
+	if err = migrations.RunSQLiteMigrations(db); err != nil {
+		log.Error("Failed to create create migrations", slog.Any("error", err))
+		panic(err)
+	}
 
-	// graceful shutdown
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	log.Info("Migrations applied successfully!", slog.String("path", path))
 
-	sig := <-sigChan
-	log.Info("Stopped by Admin", "Signal", sig)
+	return db
+}
+
+// Служебный паспорт пользователя, создаваемого для первого входа во встроенное хранилище.
+const (
+	bootstrapPassportSeries = 1000
+	bootstrapPassportNumber = 100000
+)
+
+// bootstrapPeopleID возвращает пользователя, которому задаётся первоначальный пароль.
+// Новая база SQLite и хранилище в памяти пустые: если пользователя peopleID в них нет, используется
+// администратор, созданный при прошлом запуске (он находится по служебному паспорту), или создаётся новый.
+func bootstrapPeopleID(ctx context.Context, people storage.PeopleManage, peopleID int, local bool) (int, error) {
+	_, err := people.GetByID(ctx, peopleID)
+	if err == nil || (!local && errors.Is(err, domain.ErrNoRecordsFound)) {
+		return peopleID, nil
+	}
+	if !errors.Is(err, domain.ErrNoRecordsFound) {
+		return 0, err
+	}
+
+	filter := entities.PeopleFilter{People: entities.People{PassportSeries: bootstrapPassportSeries, PassportNumber: bootstrapPassportNumber}}
+	found, _, err := people.GetByFilter(ctx, filter, entities.PageRequest{Limit: 1})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(found) > 0 {
+		return found[0].ID, nil
+	}
+
+	return people.Create(ctx, entities.People{
+		PassportSeries: bootstrapPassportSeries,
+		PassportNumber: bootstrapPassportNumber,
+		Surname:        "Admin",
+		Name:           "Admin",
+		Address:        "-",
+		Role:           entities.RoleAdmin,
+	})
+}
+
+// isLocalDriver сообщает, используется ли встроенное хранилище, которое может быть пустым при запуске.
+func isLocalDriver(driver string) bool {
+	return driver == "sqlite" || driver == "memory"
 }
@@ -0,0 +1,65 @@
+// Package domain содержит категории ошибок, общие для хранилищ, сервиса и транспорта.
+// Хранилища и сервис объявляют свои ошибки через New, транспорт выбирает код ответа по категории.
+// Ошибки хранилищ объявлены здесь же, чтобы реализации хранилищ не зависели друг от друга.
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// Категории ошибок
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrValidation   = errors.New("validation failed")
+	ErrForbidden    = errors.New("forbidden")
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// Error ошибка с категорией Kind, errors.Is(err, Kind) возвращает true.
+type Error struct {
+	Kind error
+	Msg  string
+}
+
+// New создает ошибку категории kind.
+func New(kind error, msg string) error {
+	return &Error{Kind: kind, Msg: msg}
+}
+
+func (e *Error) Error() string {
+	return e.Msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// FieldError ошибка значения поля запроса, Field - имя поля в JSON.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError ошибки значений полей запроса, относится к категории ErrValidation.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// NewFieldError создает ошибку значения одного поля.
+func NewFieldError(field, message string) error {
+	return &ValidationError{Fields: []FieldError{{Field: field, Message: message}}}
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Field+" "+f.Message)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
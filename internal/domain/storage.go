@@ -0,0 +1,12 @@
+package domain
+
+// Ошибки хранилищ, общие для всех реализаций
+var (
+	ErrInputData        = New(ErrValidation, "incorrect input data")
+	ErrNoRecordsFound   = New(ErrNotFound, "no records found")
+	ErrAlreadyExists    = New(ErrConflict, "record already exists")
+	ErrTimeEntryStarted = New(ErrConflict, "time entry already started")
+	ErrTimeEntryOverlap = New(ErrConflict, "time entry overlaps another entry")
+	ErrTaskCycle        = New(ErrConflict, "task cannot be moved under its own subtask")
+	ErrDependencyCycle  = New(ErrConflict, "task dependency would create a cycle")
+)
@@ -0,0 +1,47 @@
+package entities
+
+import "time"
+
+// Область доступа API ключа.
+type Scope string
+
+const (
+	ScopePeopleRead    Scope = "people:read"
+	ScopePeopleWrite   Scope = "people:write"
+	ScopeTasksRead     Scope = "tasks:read"
+	ScopeTasksWrite    Scope = "tasks:write"
+	ScopeProjectsRead  Scope = "projects:read"
+	ScopeProjectsWrite Scope = "projects:write"
+	ScopeTimeRead      Scope = "time:read"
+	ScopeTimeWrite     Scope = "time:write"
+	ScopeReportsRead   Scope = "reports:read"
+)
+
+// Scopes все известные области доступа.
+var Scopes = []Scope{
+	ScopePeopleRead, ScopePeopleWrite,
+	ScopeTasksRead, ScopeTasksWrite,
+	ScopeProjectsRead, ScopeProjectsWrite,
+	ScopeTimeRead, ScopeTimeWrite,
+	ScopeReportsRead,
+}
+
+// APIKey долгоживущий ключ доступа для интеграций, действует от имени пользователя PeopleID.
+// Ключ хранится в виде SHA-256 хеша, Prefix - открытая часть ключа для поиска.
+type APIKey struct {
+	ID         int       `json:"id"`
+	PeopleID   int       `json:"people_id"`
+	Name       string    `json:"name"`
+	Prefix     string    `json:"prefix"`
+	KeyHash    string    `json:"-"`
+	Scopes     []Scope   `json:"scopes"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	RevokedAt  time.Time `json:"revoked_at"`
+	Created    time.Time `json:"created"`
+}
+
+// APIKeyCreated созданный ключ, значение Key выдаётся только один раз.
+type APIKeyCreated struct {
+	APIKey
+	Key string `json:"key"`
+}
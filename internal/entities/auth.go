@@ -0,0 +1,31 @@
+package entities
+
+import "time"
+
+// Пользователь, от имени которого выполняется запрос.
+// При входе по API ключу заполняются APIKeyID и Scopes, при входе по паролю - SessionID.
+type Caller struct {
+	PeopleID  int     `json:"people_id"`
+	SessionID string  `json:"session_id"`
+	Role      Role    `json:"role"`
+	APIKeyID  int     `json:"api_key_id"`
+	Scopes    []Scope `json:"scopes"`
+}
+
+// Сессия входа пользователя, к ней привязаны refresh token и выданные access token.
+type Session struct {
+	ID          string    `json:"id"`
+	PeopleID    int       `json:"people_id"`
+	RefreshHash string    `json:"-"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	RevokedAt   time.Time `json:"revoked_at"`
+	Created     time.Time `json:"created"`
+}
+
+// Пара токенов, выдаваемая при входе и обновлении.
+type TokenPair struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	TokenType    string    `json:"token_type"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
@@ -0,0 +1,127 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// Сравнение оценки задачи с фактически затраченным временем по завершённым сессиям.
+// Overrun - затраченное время превысило оценку, Overdue - срок прошёл, а задача не выполнена и не отменена.
+// People - затраченное время по пользователям, работавшим над задачей.
+type TaskEstimate struct {
+	TaskID          int                `json:"task_id"`
+	TaskTitle       string             `json:"task_title"`
+	Status          TaskStatus         `json:"status"`
+	ProjectID       int                `json:"project_id"`
+	ProjectName     string             `json:"project_name"`
+	DueDate         time.Time          `json:"due_date"`
+	Estimate        string             `json:"estimate"`
+	EstimateSeconds int64              `json:"estimate_seconds"`
+	Actual          string             `json:"actual"`
+	ActualSeconds   int64              `json:"actual_seconds"`
+	Overrun         bool               `json:"overrun"`
+	Overdue         bool               `json:"overdue"`
+	People          []PeopleTimeActual `json:"people"`
+}
+
+// Время, затраченное пользователем на задачу.
+type PeopleTimeActual struct {
+	PeopleID      int    `json:"people_id"`
+	Surname       string `json:"surname"`
+	Name          string `json:"name"`
+	Patronymic    string `json:"patronymic"`
+	Actual        string `json:"actual"`
+	ActualSeconds int64  `json:"actual_seconds"`
+}
+
+// Сравнение суммарной оценки задач проекта с затраченным временем.
+// Estimate и Actual суммируются только по задачам с оценкой, задачи лишь со сроком учитываются в OverdueTasks.
+// OverrunTasks и OverdueTasks - число задач проекта с превышением оценки и с прошедшим сроком.
+type ProjectEstimate struct {
+	ProjectID       int    `json:"project_id"`
+	ProjectName     string `json:"project_name"`
+	Estimate        string `json:"estimate"`
+	EstimateSeconds int64  `json:"estimate_seconds"`
+	Actual          string `json:"actual"`
+	ActualSeconds   int64  `json:"actual_seconds"`
+	Overrun         bool   `json:"overrun"`
+	OverrunTasks    int    `json:"overrun_tasks"`
+	OverdueTasks    int    `json:"overdue_tasks"`
+}
+
+// Отчёт об оценках: задачи с оценкой или сроком и сводка по их проектам.
+type EstimateReport struct {
+	Tasks    []TaskEstimate    `json:"tasks"`
+	Projects []ProjectEstimate `json:"projects"`
+}
+
+// SetEstimate задаёт оценку задачи, нулевая длительность - задача без оценки.
+func (e *TaskEstimate) SetEstimate(estimate time.Duration) {
+	e.EstimateSeconds = int64(estimate / time.Second)
+	e.Estimate = ""
+	if estimate > 0 {
+		e.Estimate = estimate.String()
+	}
+}
+
+// SetActual задаёт затраченное время и отмечает превышение оценки.
+// Вызывается после SetEstimate.
+// Время задачи без оценки превышением не считается.
+func (e *TaskEstimate) SetActual(actual time.Duration) {
+	actual = actual.Truncate(time.Second)
+	e.Actual = actual.String()
+	e.ActualSeconds = int64(actual / time.Second)
+	e.Overrun = e.EstimateSeconds > 0 && e.ActualSeconds > e.EstimateSeconds
+}
+
+// SetActual задаёт время, затраченное пользователем.
+func (p *PeopleTimeActual) SetActual(actual time.Duration) {
+	actual = actual.Truncate(time.Second)
+	p.Actual = actual.String()
+	p.ActualSeconds = int64(actual / time.Second)
+}
+
+// NewEstimateReport собирает отчёт из задач с уже посчитанным временем и отмечает задачи,
+// срок которых прошёл к моменту now. Задачи без проекта в сводку по проектам не входят, проекты упорядочены по ID.
+func NewEstimateReport(tasks []TaskEstimate, now time.Time) EstimateReport {
+	report := EstimateReport{Tasks: tasks, Projects: []ProjectEstimate{}}
+	if report.Tasks == nil {
+		report.Tasks = []TaskEstimate{}
+	}
+
+	projects := make(map[int]*ProjectEstimate)
+	for i := range report.Tasks {
+		task := &report.Tasks[i]
+		task.Overdue = !task.DueDate.IsZero() && task.DueDate.Before(now) &&
+			task.Status != StatusDone && task.Status != StatusCancelled
+
+		if task.ProjectID == 0 {
+			continue
+		}
+		project, ok := projects[task.ProjectID]
+		if !ok {
+			project = &ProjectEstimate{ProjectID: task.ProjectID, ProjectName: task.ProjectName}
+			projects[task.ProjectID] = project
+		}
+		if task.EstimateSeconds > 0 {
+			project.EstimateSeconds += task.EstimateSeconds
+			project.ActualSeconds += task.ActualSeconds
+		}
+		if task.Overrun {
+			project.OverrunTasks++
+		}
+		if task.Overdue {
+			project.OverdueTasks++
+		}
+	}
+
+	for _, project := range projects {
+		project.Estimate = (time.Duration(project.EstimateSeconds) * time.Second).String()
+		project.Actual = (time.Duration(project.ActualSeconds) * time.Second).String()
+		project.Overrun = project.EstimateSeconds > 0 && project.ActualSeconds > project.EstimateSeconds
+		report.Projects = append(report.Projects, *project)
+	}
+	sort.Slice(report.Projects, func(i, j int) bool { return report.Projects[i].ProjectID < report.Projects[j].ProjectID })
+
+	return report
+}
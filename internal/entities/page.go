@@ -0,0 +1,33 @@
+package entities
+
+// Параметры страницы списка из запроса клиента.
+// Sort - поля сортировки через запятую, "-" перед полем задаёт убывание, например "surname,-id".
+// Cursor - непрозрачный курсор из ответа на предыдущую страницу.
+type PageQuery struct {
+	Limit  int
+	Cursor string
+	Sort   string
+}
+
+// Поле сортировки списка.
+type SortField struct {
+	Field string
+	Desc  bool
+}
+
+// Запрос страницы к хранилищу.
+// After - значения полей Sort последней записи предыдущей страницы, пусто для первой страницы.
+// Нулевой Limit снимает ограничение, пустой Sort означает сортировку по id.
+type PageRequest struct {
+	Limit int
+	Sort  []SortField
+	After []any
+}
+
+// Страница списка. NextCursor пуст на последней странице,
+// Total - число записей, удовлетворяющих фильтру, без учёта курсора.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Total      int    `json:"-"`
+}
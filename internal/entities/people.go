@@ -1,11 +1,32 @@
 package entities
 
+// Роль пользователя, определяет доступные ему операции.
+type Role string
+
+const (
+	RoleAdmin   Role = "admin"
+	RoleManager Role = "manager"
+	RoleMember  Role = "member"
+)
+
+// Паспортные данные видны только администраторам, для остальных поля не выводятся.
+// ManagerID - руководитель, в команду менеджера входят пользователи с его ID.
 type People struct {
 	ID             int    `json:"id"`
-	PassportSeries int    `json:"passport_series"`
-	PassportNumber int    `json:"passport_number"`
+	PassportSeries int    `json:"passport_series,omitempty"`
+	PassportNumber int    `json:"passport_number,omitempty"`
 	Surname        string `json:"surname"`
 	Name           string `json:"name"`
 	Patronymic     string `json:"patronymic"`
 	Address        string `json:"address"`
+	Role           Role   `json:"role"`
+	ManagerID      int    `json:"manager_id"`
+}
+
+// Фильтр пользователей. Заданные поля People должны совпадать,
+// строковые поля (surname, name, patronymic, address) сравниваются способом из Match по имени поля в JSON,
+// поля без способа сравнения - на точное совпадение.
+type PeopleFilter struct {
+	People
+	Match map[string]MatchOp
 }
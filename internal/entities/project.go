@@ -0,0 +1,15 @@
+package entities
+
+// Структура для проекта, объединяющего задачи
+type Project struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Структура для вывода трудозатрат по проекту за определённый период.
+type ProjectTimeSpent struct {
+	ProjectID   int    `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	TimeSpent   string `json:"time_spent"`
+}
@@ -0,0 +1,42 @@
+package entities
+
+import "time"
+
+// Повторяющаяся задача: шаблон задачи и правило повторения.
+// Rule - правило RRULE из RFC 5545, например FREQ=WEEKLY;BYDAY=MO.
+// Start - начало расписания, повторения наследуют его время суток.
+// AssigneeIDs - пользователи, которые назначаются исполнителями каждой созданной задачи.
+// NextRun - следующее повторение, нулевое у исчерпанного правила, LastRun - последнее созданное повторение.
+type Recurrence struct {
+	ID              int       `json:"id"`
+	Title           string    `json:"title"`
+	Description     string    `json:"description"`
+	ProjectID       int       `json:"project_id"`
+	Estimate        string    `json:"estimate"`
+	EstimateSeconds int64     `json:"estimate_seconds"`
+	AssigneeIDs     []int     `json:"assignee_ids"`
+	Rule            string    `json:"rule"`
+	Start           time.Time `json:"start"`
+	NextRun         time.Time `json:"next_run"`
+	LastRun         time.Time `json:"last_run"`
+}
+
+// SetEstimate задаёт оценку создаваемых задач, нулевая длительность - задачи без оценки.
+func (r *Recurrence) SetEstimate(estimate time.Duration) {
+	r.EstimateSeconds = int64(estimate / time.Second)
+	r.Estimate = ""
+	if estimate > 0 {
+		r.Estimate = estimate.String()
+	}
+}
+
+// Task возвращает задачу повторения со статусом status.
+func (r Recurrence) Task(status TaskStatus) Task {
+	return Task{
+		Title:           r.Title,
+		Description:     r.Description,
+		Status:          status,
+		ProjectID:       r.ProjectID,
+		EstimateSeconds: r.EstimateSeconds,
+	}
+}
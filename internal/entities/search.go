@@ -0,0 +1,28 @@
+package entities
+
+// Способ сравнения строкового поля в фильтре.
+type MatchOp string
+
+const (
+	MatchExact    MatchOp = "exact"    // точное совпадение, по умолчанию
+	MatchIExact   MatchOp = "iexact"   // совпадение без учёта регистра
+	MatchPrefix   MatchOp = "prefix"   // начало значения без учёта регистра
+	MatchContains MatchOp = "contains" // часть значения без учёта регистра
+)
+
+// Тип записи в результатах поиска.
+type SearchType string
+
+const (
+	SearchPeople SearchType = "people"
+	SearchTask   SearchType = "task"
+)
+
+// Результат полнотекстового поиска.
+// Title - ФИО пользователя или заголовок задачи, Rank - релевантность, результаты упорядочены по её убыванию.
+type SearchResult struct {
+	Type  SearchType `json:"type"`
+	ID    int        `json:"id"`
+	Title string     `json:"title"`
+	Rank  float64    `json:"rank"`
+}
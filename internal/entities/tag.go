@@ -0,0 +1,17 @@
+package entities
+
+// Структура для метки задачи, например "bugfix" или "feature".
+// Задача может иметь несколько меток, одна метка - несколько задач.
+type Tag struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Структура для вывода трудозатрат по метке за определённый период.
+// Время задачи с несколькими метками учитывается в каждой из них.
+type TagTimeSpent struct {
+	TagID     int     `json:"tag_id"`
+	TagName   string  `json:"tag_name"`
+	TimeSpent string  `json:"time_spent"`
+	Hours     float64 `json:"hours"`
+}
@@ -4,12 +4,17 @@ import (
 	"time"
 )
 
-// Структура для хранения данных о времени
+// Структура для хранения данных о времени.
+// Одна запись - одна сессия работы пользователя над задачей,
+// открытая сессия имеет StartTime и нулевой EndTime.
+// Overlaps отмечает запись, пересекающуюся с другими записями пользователя.
 type TimeEntry struct {
 	ID        int       `json:"id"`
+	TaskID    int       `json:"task_id"`
 	PeopleID  int       `json:"people_id"`
 	StartTime time.Time `json:"start_time"`
 	EndTime   time.Time `json:"end_time"`
+	Overlaps  bool      `json:"overlaps"`
 	Created   time.Time `json:"created"`
 }
 
@@ -18,21 +23,215 @@ func (t *TimeEntry) Duration() time.Duration {
 	return t.EndTime.Sub(t.StartTime)
 }
 
-// Структура для задачи
+// OverlapChanges изменения записей пользователя, пересекающихся с новой записью времени.
+// Хранилище применяет их в одной транзакции с сохранением новой записи.
+type OverlapChanges struct {
+	Trim []int // записи, окончание которых переносится на начало новой записи
+	Flag []int // записи, помечаемые пересекающимися вместе с новой записью
+}
+
+// TimerStart запуск таймера, который хранилище выполняет в одной транзакции со сменой статуса задачи.
+// Нулевой PeopleID означает, что таймер не запускается.
+type TimerStart struct {
+	PeopleID  int
+	StartTime time.Time
+	Overlaps  OverlapChanges
+}
+
+// Статус задачи, допустимые переходы между статусами задаются в сервисе.
+type TaskStatus string
+
+// Статусы по умолчанию
+const (
+	StatusTodo       TaskStatus = "todo"
+	StatusInProgress TaskStatus = "in_progress"
+	StatusReview     TaskStatus = "review"
+	StatusDone       TaskStatus = "done"
+	StatusCancelled  TaskStatus = "cancelled"
+)
+
+// Структура для задачи.
+// Tags заполняется при чтении задачи, метки назначаются и снимаются отдельными запросами.
+// ParentID - родительская задача, 0 у задачи верхнего уровня.
+// Assignees - текущие исполнители задачи.
+// BlockedBy - задачи, которые блокируют эту задачу, Blocks - задачи, которые блокирует она.
+// Subtasks и Rollup заполняются только при чтении задачи вместе с поддеревом.
+// DueDate - срок выполнения, нулевой у задачи без срока.
+// Estimate - оценка задачи как длительность, например 4h30m, EstimateSeconds заполняется при чтении.
+// Checklist и Progress заполняются только при чтении задачи по ID, Progress - у задачи с чек-листом.
 type Task struct {
-	ID          int       `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	TimeEntry   TimeEntry `json:"timeEntry"`
+	ID              int                `json:"id"`
+	Title           string             `json:"title"`
+	Description     string             `json:"description"`
+	Status          TaskStatus         `json:"status"`
+	ProjectID       int                `json:"project_id"`
+	ParentID        int                `json:"parent_id"`
+	DueDate         time.Time          `json:"due_date"`
+	Estimate        string             `json:"estimate"`
+	EstimateSeconds int64              `json:"estimate_seconds"`
+	TimeEntry       TimeEntry          `json:"timeEntry"`
+	Tags            []Tag              `json:"tags"`
+	Assignees       []TaskAssignment   `json:"assignees"`
+	BlockedBy       []TaskRef          `json:"blocked_by"`
+	Blocks          []TaskRef          `json:"blocks"`
+	Checklist       []ChecklistItem    `json:"checklist,omitempty"`
+	Progress        *ChecklistProgress `json:"checklist_progress,omitempty"`
+	Subtasks        []Task             `json:"subtasks,omitempty"`
+	Rollup          *TaskRollup        `json:"rollup,omitempty"`
+}
+
+// SetEstimate задаёт оценку задачи, нулевая длительность - задача без оценки.
+func (t *Task) SetEstimate(estimate time.Duration) {
+	t.EstimateSeconds = int64(estimate / time.Second)
+	t.Estimate = ""
+	if estimate > 0 {
+		t.Estimate = estimate.String()
+	}
+}
+
+// SetChecklist задаёт чек-лист задачи и считает долю выполненных пунктов, пустой чек-лист не имеет прогресса.
+func (t *Task) SetChecklist(items []ChecklistItem) {
+	t.Checklist = items
+	t.Progress = nil
+	if len(items) == 0 {
+		return
+	}
+
+	progress := ChecklistProgress{Total: len(items)}
+	for _, item := range items {
+		if item.Done {
+			progress.Done++
+		}
+	}
+	progress.Percent = progress.Done * 100 / progress.Total
+	t.Progress = &progress
+}
+
+// Пункт чек-листа задачи. DoneAt - время отметки, нулевое у невыполненного пункта.
+type ChecklistItem struct {
+	ID       int       `json:"id"`
+	TaskID   int       `json:"task_id"`
+	Position int       `json:"position"`
+	Title    string    `json:"title"`
+	Done     bool      `json:"done"`
+	DoneAt   time.Time `json:"done_at"`
+}
+
+// Выполнение чек-листа, Percent округляется вниз: 100 - только когда отмечены все пункты.
+type ChecklistProgress struct {
+	Total   int `json:"total"`
+	Done    int `json:"done"`
+	Percent int `json:"percent"`
+}
+
+// Назначение пользователя на задачу. У текущего назначения UnassignedAt нулевое.
+type TaskAssignment struct {
+	ID           int       `json:"id"`
+	TaskID       int       `json:"task_id"`
+	PeopleID     int       `json:"people_id"`
+	Surname      string    `json:"surname"`
+	Name         string    `json:"name"`
+	Patronymic   string    `json:"patronymic"`
+	AssignedAt   time.Time `json:"assigned_at"`
+	UnassignedAt time.Time `json:"unassigned_at"`
+}
+
+// Current сообщает, действует ли назначение сейчас.
+func (a TaskAssignment) Current() bool {
+	return a.UnassignedAt.IsZero()
+}
+
+// Трудозатраты одного пользователя по задаче.
+// Assigned - пользователь назначен на задачу сейчас, время учитывается и у снятых исполнителей.
+type AssigneeTimeSpent struct {
+	PeopleID   int     `json:"people_id"`
+	Surname    string  `json:"surname"`
+	Name       string  `json:"name"`
+	Patronymic string  `json:"patronymic"`
+	Assigned   bool    `json:"assigned"`
+	TimeSpent  string  `json:"time_spent"`
+	Hours      float64 `json:"hours"`
+}
+
+// Краткие данные связанной задачи.
+type TaskRef struct {
+	ID     int        `json:"id"`
+	Title  string     `json:"title"`
+	Status TaskStatus `json:"status"`
+}
+
+// Finished сообщает, завершена ли задача: выполненная или отменённая задача больше не блокирует другие.
+func (t TaskRef) Finished() bool {
+	return t.Status == StatusDone || t.Status == StatusCancelled
+}
+
+// Время по завершённым сессиям задачи: собственное и вместе со всеми подзадачами.
+type TaskRollup struct {
+	TimeSpent             string `json:"time_spent"`
+	TimeSpentSeconds      int64  `json:"time_spent_seconds"`
+	TotalTimeSpent        string `json:"total_time_spent"`
+	TotalTimeSpentSeconds int64  `json:"total_time_spent_seconds"`
+}
+
+// BuildSubtree раскладывает задачи поддерева descendants по родителям, начиная с t,
+// и считает время каждой задачи. spent - собственное время задач по их ID.
+// Подзадачи выводятся в порядке descendants.
+func (t *Task) BuildSubtree(descendants []Task, spent map[int]time.Duration) {
+	children := make(map[int][]Task)
+	for _, task := range descendants {
+		children[task.ParentID] = append(children[task.ParentID], task)
+	}
+
+	var build func(task *Task) time.Duration
+	build = func(task *Task) time.Duration {
+		own := spent[task.ID].Truncate(time.Second)
+		total := own
+		for _, child := range children[task.ID] {
+			total += build(&child)
+			task.Subtasks = append(task.Subtasks, child)
+		}
+		task.Rollup = &TaskRollup{
+			TimeSpent:             own.String(),
+			TimeSpentSeconds:      int64(own / time.Second),
+			TotalTimeSpent:        total.String(),
+			TotalTimeSpentSeconds: int64(total / time.Second),
+		}
+		return total
+	}
+	build(t)
+}
+
+// Фильтр списка задач, нулевые значения не учитываются.
+// Tag - имя метки, выводятся только задачи с этой меткой.
+// ParentID - выводятся только прямые подзадачи этой задачи.
+type TaskFilter struct {
+	ProjectID int    `json:"project_id"`
+	Tag       string `json:"tag"`
+	ParentID  int    `json:"parent_id"`
 }
 
 // Структура для вывода трудозатрат по пользователю определённый период.
 type TaskTimeSpent struct {
-	PeopleID   int    `json:"people_id"`
-	Surname    string `json:"surname"`
-	Name       string `json:"name"`
-	Patronymic string `json:"patronymic"`
-	TaskID     int    `json:"task_id"`
-	TaskTitle  string `json:"task_title"`
-	TimeSpent  string `json:"time_spent"`
+	PeopleID   int     `json:"people_id"`
+	Surname    string  `json:"surname"`
+	Name       string  `json:"name"`
+	Patronymic string  `json:"patronymic"`
+	TaskID     int     `json:"task_id"`
+	TaskTitle  string  `json:"task_title"`
+	TimeSpent  string  `json:"time_spent"`
+	Hours      float64 `json:"hours"`
+}
+
+// Текущий таймер пользователя: запущенная или приостановленная сессия работы над задачей.
+// Сессия может состоять из нескольких отрезков, разделённых паузами.
+type ActiveTimer struct {
+	TimeEntryID    int       `json:"time_entry_id"`
+	TaskID         int       `json:"task_id"`
+	TaskTitle      string    `json:"task_title"`
+	PeopleID       int       `json:"people_id"`
+	SessionStart   time.Time `json:"session_start"`
+	SegmentStart   time.Time `json:"segment_start"`
+	Paused         bool      `json:"paused"`
+	Elapsed        string    `json:"elapsed"`
+	ElapsedSeconds int64     `json:"elapsed_seconds"`
 }
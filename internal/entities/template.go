@@ -0,0 +1,37 @@
+package entities
+
+import "time"
+
+// Шаблон задачи для повторяющейся работы, например чек-листа релиза.
+// TitlePattern - заголовок с подстановками: {date}, {week}, {month}, {year} и значения из TemplateParams.Vars,
+// например "Release {version} checklist".
+// AssigneeID - исполнитель по умолчанию, Checklist - пункты чек-листа создаваемой задачи.
+type TaskTemplate struct {
+	ID              int      `json:"id"`
+	Name            string   `json:"name"`
+	TitlePattern    string   `json:"title_pattern"`
+	Description     string   `json:"description"`
+	ProjectID       int      `json:"project_id"`
+	AssigneeID      int      `json:"assignee_id"`
+	Estimate        string   `json:"estimate"`
+	EstimateSeconds int64    `json:"estimate_seconds"`
+	Checklist       []string `json:"checklist"`
+}
+
+// SetEstimate задаёт оценку создаваемых задач, нулевая длительность - задачи без оценки.
+func (t *TaskTemplate) SetEstimate(estimate time.Duration) {
+	t.EstimateSeconds = int64(estimate / time.Second)
+	t.Estimate = ""
+	if estimate > 0 {
+		t.Estimate = estimate.String()
+	}
+}
+
+// Параметры создания задачи по шаблону, ненулевые значения заменяют значения шаблона.
+// Vars - значения подстановок заголовка.
+type TemplateParams struct {
+	ProjectID  int               `json:"project_id"`
+	ParentID   int               `json:"parent_id"`
+	AssigneeID int               `json:"assignee_id"`
+	Vars       map[string]string `json:"vars"`
+}
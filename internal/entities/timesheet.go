@@ -0,0 +1,46 @@
+package entities
+
+import "time"
+
+// Состояние недельного табеля.
+type TimesheetStatus string
+
+const (
+	TimesheetDraft     TimesheetStatus = "draft"
+	TimesheetSubmitted TimesheetStatus = "submitted"
+	TimesheetApproved  TimesheetStatus = "approved"
+	TimesheetRejected  TimesheetStatus = "rejected"
+)
+
+// Недельный табель пользователя: часы по задачам за каждый день недели.
+// Week - неделя в формате ISO 8601 (2026-W42), Days - даты с понедельника по воскресенье.
+type Timesheet struct {
+	PeopleID    int             `json:"people_id"`
+	Week        string          `json:"week"`
+	WeekStart   time.Time       `json:"week_start"`
+	Status      TimesheetStatus `json:"status"`
+	Comment     string          `json:"comment"`
+	SubmittedAt time.Time       `json:"submitted_at"`
+	ReviewedAt  time.Time       `json:"reviewed_at"`
+	ReviewedBy  int             `json:"reviewed_by"`
+	Days        []string        `json:"days"`
+	Rows        []TimesheetRow  `json:"rows"`
+	DayTotals   []float64       `json:"day_totals"`
+	TotalHours  float64         `json:"total_hours"`
+}
+
+// Строка табеля: часы по задаче за каждый день недели.
+type TimesheetRow struct {
+	TaskID     int       `json:"task_id"`
+	TaskTitle  string    `json:"task_title"`
+	Hours      []float64 `json:"hours"`
+	TotalHours float64   `json:"total_hours"`
+}
+
+// Отрезок работы над задачей, из которых собирается табель.
+type TaskTimeSegment struct {
+	TaskID    int       `json:"task_id"`
+	TaskTitle string    `json:"task_title"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// apiKeyMarker начало каждого ключа, позволяет отличить API ключ от других токенов.
+const apiKeyMarker = "tsk_"
+
+// apiKeyTouchInterval - время последнего использования ключа сохраняется не чаще этого интервала.
+const apiKeyTouchInterval = time.Minute
+
+// APIKeyService представляет сервис API ключей интеграций.
+type APIKeyService struct {
+	storage storage.APIKeyManage
+	people  storage.PeopleManage
+}
+
+// NewAPIKeyService создает новый экземпляр APIKeyService.
+func NewAPIKeyService(s storage.APIKeyManage, p storage.PeopleManage) *APIKeyService {
+	return &APIKeyService{storage: s, people: p}
+}
+
+// Create выпускает ключ пользователю, выполняющему запрос.
+// Ключ имеет вид tsk_<prefix>.<secret> и возвращается только при создании.
+func (a *APIKeyService) Create(ctx context.Context, name string, scopes []entities.Scope) (entities.APIKeyCreated, error) {
+	caller, ok := CallerFromContext(ctx)
+	if !ok {
+		return entities.APIKeyCreated{}, ErrUnauthorized
+	}
+
+	if strings.TrimSpace(name) == "" {
+		return entities.APIKeyCreated{}, fmt.Errorf("%w: name is required", ErrInvalidAPIKey)
+	}
+
+	if len(scopes) == 0 {
+		return entities.APIKeyCreated{}, fmt.Errorf("%w: at least one scope is required", ErrInvalidAPIKey)
+	}
+
+	for _, scope := range scopes {
+		if !slices.Contains(entities.Scopes, scope) {
+			return entities.APIKeyCreated{}, fmt.Errorf("%w: unknown scope %q", ErrInvalidAPIKey, scope)
+		}
+	}
+
+	prefix := make([]byte, 6)
+	if _, err := rand.Read(prefix); err != nil {
+		return entities.APIKeyCreated{}, fmt.Errorf("failed to generate key prefix: %w", err)
+	}
+
+	secret, err := randomToken(32)
+	if err != nil {
+		return entities.APIKeyCreated{}, err
+	}
+
+	key := entities.APIKey{
+		PeopleID: caller.PeopleID,
+		Name:     name,
+		Prefix:   hex.EncodeToString(prefix),
+		KeyHash:  hashToken(secret),
+		Scopes:   scopes,
+		Created:  time.Now().UTC(),
+	}
+
+	key.ID, err = a.storage.CreateAPIKey(ctx, key)
+	if err != nil {
+		return entities.APIKeyCreated{}, err
+	}
+
+	return entities.APIKeyCreated{APIKey: key, Key: apiKeyMarker + key.Prefix + "." + secret}, nil
+}
+
+// List возвращает ключи пользователя peopleID, по умолчанию - выполняющего запрос.
+// Ключи другого пользователя видит только администратор.
+func (a *APIKeyService) List(ctx context.Context, peopleID int) ([]entities.APIKey, error) {
+	if caller, ok := CallerFromContext(ctx); ok {
+		if peopleID == 0 {
+			peopleID = caller.PeopleID
+		}
+		if peopleID != caller.PeopleID && caller.Role != entities.RoleAdmin {
+			return nil, fmt.Errorf("%w: cannot list api keys of people ID %d", ErrForbidden, peopleID)
+		}
+	}
+
+	return a.storage.ListAPIKeys(ctx, peopleID)
+}
+
+// Revoke отзывает ключ, пользователь отзывает свои ключи, администратор - любые.
+func (a *APIKeyService) Revoke(ctx context.Context, keyID int) error {
+	ownerID := 0
+	if caller, ok := CallerFromContext(ctx); ok && caller.Role != entities.RoleAdmin {
+		ownerID = caller.PeopleID
+	}
+
+	return a.storage.RevokeAPIKey(ctx, keyID, ownerID, time.Now().UTC())
+}
+
+// Authenticate проверяет API ключ и возвращает пользователя-владельца с областями доступа ключа.
+func (a *APIKeyService) Authenticate(ctx context.Context, rawKey string) (entities.Caller, error) {
+	prefix, secret, ok := strings.Cut(strings.TrimPrefix(rawKey, apiKeyMarker), ".")
+	if !ok || !strings.HasPrefix(rawKey, apiKeyMarker) {
+		return entities.Caller{}, ErrUnauthorized
+	}
+
+	key, err := a.storage.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		return entities.Caller{}, ErrUnauthorized
+	}
+
+	if subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(hashToken(secret))) != 1 || !key.RevokedAt.IsZero() {
+		return entities.Caller{}, ErrUnauthorized
+	}
+
+	people, err := a.people.GetByID(ctx, key.PeopleID)
+	if err != nil {
+		return entities.Caller{}, ErrUnauthorized
+	}
+
+	now := time.Now().UTC()
+	if now.Sub(key.LastUsedAt) > apiKeyTouchInterval {
+		if err := a.storage.TouchAPIKey(ctx, key.ID, now); err != nil {
+			return entities.Caller{}, err
+		}
+	}
+
+	return entities.Caller{PeopleID: key.PeopleID, Role: people.Role, APIKeyID: key.ID, Scopes: key.Scopes}, nil
+}
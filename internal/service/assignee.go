@@ -0,0 +1,89 @@
+package service
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"errors"
+	"time"
+)
+
+// AssigneeService представляет сервис для работы с исполнителями задач.
+type AssigneeService struct {
+	storage storage.AssigneeManage
+	tasks   storage.TaskManage
+	access  *Access
+}
+
+// NewAssigneeService создает новый экземпляр AssigneeService.
+func NewAssigneeService(s storage.AssigneeManage, tasks storage.TaskManage, access *Access) *AssigneeService {
+	return &AssigneeService{storage: s, tasks: tasks, access: access}
+}
+
+// Assign добавляет исполнителя задачи, прежние исполнители остаются назначенными.
+// Назначать исполнителей могут администратор и менеджер своей команды.
+func (a *AssigneeService) Assign(ctx context.Context, taskID, peopleID int) error {
+	if err := a.authorize(ctx, taskID, peopleID); err != nil {
+		return err
+	}
+
+	return a.storage.Assign(ctx, taskID, peopleID, time.Now().UTC())
+}
+
+// Unassign снимает исполнителя с задачи. Записи времени исполнителя по задаче сохраняются.
+func (a *AssigneeService) Unassign(ctx context.Context, taskID, peopleID int) error {
+	if err := a.authorize(ctx, taskID, peopleID); err != nil {
+		return err
+	}
+
+	return a.storage.Unassign(ctx, taskID, peopleID, time.Now().UTC())
+}
+
+// History возвращает историю назначений задачи.
+func (a *AssigneeService) History(ctx context.Context, taskID int) ([]entities.TaskAssignment, error) {
+	if _, err := a.tasks.GetByID(ctx, taskID, false); err != nil {
+		return nil, err
+	}
+
+	return a.storage.History(ctx, taskID)
+}
+
+// TimeByAssignee возвращает трудозатраты по задаче в разрезе исполнителей.
+// Администратор видит всех, остальные - только тех, от чьего имени могут действовать.
+func (a *AssigneeService) TimeByAssignee(ctx context.Context, taskID int) ([]entities.AssigneeTimeSpent, error) {
+	if _, err := a.tasks.GetByID(ctx, taskID, false); err != nil {
+		return nil, err
+	}
+
+	spent, err := a.storage.TimeByAssignee(ctx, taskID)
+	if err != nil || isAdmin(ctx) {
+		return spent, err
+	}
+
+	visible := make([]entities.AssigneeTimeSpent, 0, len(spent))
+	for _, entry := range spent {
+		if _, err := a.access.actFor(ctx, entry.PeopleID); err != nil {
+			if errors.Is(err, ErrForbidden) {
+				continue
+			}
+			return nil, err
+		}
+		visible = append(visible, entry)
+	}
+
+	return visible, nil
+}
+
+// authorize проверяет запрос на изменение исполнителей задачи.
+func (a *AssigneeService) authorize(ctx context.Context, taskID, peopleID int) error {
+	if err := validate(assigneeRequest{TaskID: taskID, PeopleID: peopleID}, assigneeRules); err != nil {
+		return err
+	}
+
+	if err := requireRole(ctx, entities.RoleAdmin, entities.RoleManager); err != nil {
+		return err
+	}
+
+	_, err := a.access.actFor(ctx, peopleID)
+	return err
+}
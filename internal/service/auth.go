@@ -0,0 +1,256 @@
+package service
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// AuthConfig настройки выдачи токенов.
+type AuthConfig struct {
+	Secret     []byte        // ключ подписи HMAC для access token
+	AccessTTL  time.Duration // время жизни access token
+	RefreshTTL time.Duration // время жизни сессии и refresh token
+}
+
+// AuthService представляет сервис входа пользователей и проверки токенов.
+type AuthService struct {
+	storage storage.AuthManage
+	people  storage.PeopleManage
+	cfg     AuthConfig
+}
+
+// NewAuthService создает новый экземпляр AuthService.
+// Данные пользователей используются для определения роли при проверке токена.
+func NewAuthService(s storage.AuthManage, p storage.PeopleManage, cfg AuthConfig) *AuthService {
+	return &AuthService{storage: s, people: p, cfg: cfg}
+}
+
+// accessClaims содержимое access token, sub - ID пользователя, sid - ID сессии.
+type accessClaims struct {
+	SessionID string `json:"sid"`
+	jwt.RegisteredClaims
+}
+
+// Login проверяет пароль пользователя и открывает новую сессию.
+func (a *AuthService) Login(ctx context.Context, peopleID int, password string) (entities.TokenPair, error) {
+	hash, err := a.storage.GetPasswordHash(ctx, peopleID)
+	if err != nil || hash == "" {
+		return entities.TokenPair{}, ErrUnauthorized
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return entities.TokenPair{}, ErrUnauthorized
+	}
+
+	sessionID, err := randomToken(16)
+	if err != nil {
+		return entities.TokenPair{}, err
+	}
+
+	secret, err := randomToken(32)
+	if err != nil {
+		return entities.TokenPair{}, err
+	}
+
+	now := time.Now().UTC()
+
+	err = a.storage.CreateSession(ctx, entities.Session{
+		ID:          sessionID,
+		PeopleID:    peopleID,
+		RefreshHash: hashToken(secret),
+		ExpiresAt:   now.Add(a.cfg.RefreshTTL),
+	})
+	if err != nil {
+		return entities.TokenPair{}, err
+	}
+
+	return a.issue(peopleID, sessionID, secret, now)
+}
+
+// Refresh выдаёт новую пару токенов по refresh token, предыдущий refresh token становится недействительным.
+func (a *AuthService) Refresh(ctx context.Context, refreshToken string) (entities.TokenPair, error) {
+	sessionID, secret, ok := strings.Cut(refreshToken, ".")
+	if !ok {
+		return entities.TokenPair{}, ErrUnauthorized
+	}
+
+	session, err := a.activeSession(ctx, sessionID)
+	if err != nil {
+		return entities.TokenPair{}, err
+	}
+
+	newSecret, err := randomToken(32)
+	if err != nil {
+		return entities.TokenPair{}, err
+	}
+
+	now := time.Now().UTC()
+
+	if err := a.storage.RotateSession(ctx, sessionID, hashToken(secret), hashToken(newSecret), now.Add(a.cfg.RefreshTTL)); err != nil {
+		return entities.TokenPair{}, ErrUnauthorized
+	}
+
+	return a.issue(session.PeopleID, sessionID, newSecret, now)
+}
+
+// Logout отзывает сессию, её access и refresh token перестают приниматься.
+func (a *AuthService) Logout(ctx context.Context, sessionID string) error {
+	return a.storage.RevokeSession(ctx, sessionID, time.Now().UTC())
+}
+
+// Authenticate проверяет подпись и срок действия access token и то, что его сессия не отозвана.
+// Роль читается из данных пользователя, поэтому её изменение действует без перевыпуска токенов.
+func (a *AuthService) Authenticate(ctx context.Context, accessToken string) (entities.Caller, error) {
+	var claims accessClaims
+
+	_, err := jwt.ParseWithClaims(accessToken, &claims, func(*jwt.Token) (interface{}, error) {
+		return a.cfg.Secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return entities.Caller{}, fmt.Errorf("%w: %v", ErrUnauthorized, err)
+	}
+
+	peopleID, err := strconv.Atoi(claims.Subject)
+	if err != nil {
+		return entities.Caller{}, ErrUnauthorized
+	}
+
+	session, err := a.activeSession(ctx, claims.SessionID)
+	if err != nil {
+		return entities.Caller{}, err
+	}
+
+	if session.PeopleID != peopleID {
+		return entities.Caller{}, ErrUnauthorized
+	}
+
+	people, err := a.people.GetByID(ctx, peopleID)
+	if err != nil {
+		return entities.Caller{}, ErrUnauthorized
+	}
+
+	return entities.Caller{PeopleID: peopleID, SessionID: session.ID, Role: people.Role}, nil
+}
+
+// SetPassword задаёт пароль пользователя и отзывает все его сессии.
+// Пользователь меняет свой пароль, подтверждая его текущим паролем, чтобы одного токена или ключа API
+// было недостаточно для захвата учётной записи. Администратор задаёт пароль любому пользователю без текущего пароля.
+func (a *AuthService) SetPassword(ctx context.Context, peopleID int, currentPassword, password string) error {
+	caller, ok := CallerFromContext(ctx)
+	if ok && caller.PeopleID != peopleID && caller.Role != entities.RoleAdmin {
+		return ErrForbidden
+	}
+
+	if ok && caller.PeopleID == peopleID {
+		hash, err := a.storage.GetPasswordHash(ctx, peopleID)
+		if err != nil {
+			return err
+		}
+
+		if hash == "" || bcrypt.CompareHashAndPassword([]byte(hash), []byte(currentPassword)) != nil {
+			return fmt.Errorf("%w: current password is incorrect", ErrForbidden)
+		}
+	}
+
+	return a.setPassword(ctx, peopleID, password)
+}
+
+// InitPassword задаёт пароль пользователю без пароля, используется для первоначальной настройки.
+// Возвращает false, если пароль уже был задан.
+func (a *AuthService) InitPassword(ctx context.Context, peopleID int, password string) (bool, error) {
+	hash, err := a.storage.GetPasswordHash(ctx, peopleID)
+	if err != nil {
+		return false, err
+	}
+
+	if hash != "" {
+		return false, nil
+	}
+
+	return true, a.setPassword(ctx, peopleID, password)
+}
+
+func (a *AuthService) setPassword(ctx context.Context, peopleID int, password string) error {
+	if len(password) < 8 {
+		return fmt.Errorf("%w: password must be at least 8 characters", ErrWeakPassword)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	if err := a.storage.SetPasswordHash(ctx, peopleID, string(hash)); err != nil {
+		return err
+	}
+
+	return a.storage.RevokePeopleSessions(ctx, peopleID, time.Now().UTC())
+}
+
+// activeSession возвращает сессию, если она существует, не отозвана и не истекла.
+func (a *AuthService) activeSession(ctx context.Context, sessionID string) (entities.Session, error) {
+	session, err := a.storage.GetSession(ctx, sessionID)
+	if err != nil {
+		return session, ErrUnauthorized
+	}
+
+	if !session.RevokedAt.IsZero() || time.Now().UTC().After(session.ExpiresAt) {
+		return session, ErrUnauthorized
+	}
+
+	return session, nil
+}
+
+// issue подписывает access token и собирает refresh token из ID сессии и секрета.
+func (a *AuthService) issue(peopleID int, sessionID, secret string, now time.Time) (entities.TokenPair, error) {
+	expiresAt := now.Add(a.cfg.AccessTTL)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims{
+		SessionID: sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(peopleID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+
+	signed, err := token.SignedString(a.cfg.Secret)
+	if err != nil {
+		return entities.TokenPair{}, err
+	}
+
+	return entities.TokenPair{
+		AccessToken:  signed,
+		RefreshToken: sessionID + "." + secret,
+		TokenType:    "Bearer",
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+// randomToken возвращает случайную строку из n байт.
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.New("failed to generate token")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken возвращает SHA-256 хеш токена, в хранилище токены хранятся только в виде хеша.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
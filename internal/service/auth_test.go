@@ -0,0 +1,111 @@
+package service
+
+import (
+	"TaskSync/internal/entities"
+	"context"
+	"testing"
+)
+
+func TestSetPassword(t *testing.T) {
+	const current, next = "current-password", "next-password"
+
+	tests := []struct {
+		name     string
+		ctx      func(team) context.Context
+		peopleID func(team) int
+		current  string
+		want     error
+	}{
+		{
+			name:     "own with current password",
+			ctx:      team.members,
+			peopleID: func(tm team) int { return tm.member },
+			current:  current,
+		},
+		{
+			name:     "own without current password",
+			ctx:      team.members,
+			peopleID: func(tm team) int { return tm.member },
+			want:     ErrForbidden,
+		},
+		{
+			name:     "own with wrong current password",
+			ctx:      team.members,
+			peopleID: func(tm team) int { return tm.member },
+			current:  "wrong-password",
+			want:     ErrForbidden,
+		},
+		{
+			name:     "admin own without current password",
+			ctx:      team.admins,
+			peopleID: func(tm team) int { return tm.admin },
+			want:     ErrForbidden,
+		},
+		{
+			name:     "admin resets other",
+			ctx:      team.admins,
+			peopleID: func(tm team) int { return tm.member },
+		},
+		{
+			name:     "manager sets team member",
+			ctx:      team.managers,
+			peopleID: func(tm team) int { return tm.member },
+			current:  current,
+			want:     ErrForbidden,
+		},
+		{
+			name:     "member sets other",
+			ctx:      team.members,
+			peopleID: func(tm team) int { return tm.outsider },
+			current:  current,
+			want:     ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, s := newTestService(t)
+			tm := newTeam(t, s)
+			for _, id := range []int{tm.admin, tm.manager, tm.member, tm.outsider} {
+				if _, err := svc.Auth.InitPassword(context.Background(), id, current); err != nil {
+					t.Fatalf("InitPassword: %v", err)
+				}
+			}
+
+			peopleID := tt.peopleID(tm)
+			checkError(t, svc.Auth.SetPassword(tt.ctx(tm), peopleID, tt.current, next), tt.want, "SetPassword")
+
+			// Пароль меняется только при успехе
+			password := next
+			if tt.want != nil {
+				password = current
+			}
+			if _, err := svc.Auth.Login(context.Background(), peopleID, password); err != nil {
+				t.Fatalf("Login with %s: %v", password, err)
+			}
+		})
+	}
+}
+
+func TestSetPasswordRevokesSessions(t *testing.T) {
+	svc, s := newTestService(t)
+	tm := newTeam(t, s)
+
+	if _, err := svc.Auth.InitPassword(context.Background(), tm.member, "current-password"); err != nil {
+		t.Fatalf("InitPassword: %v", err)
+	}
+	tokens, err := svc.Auth.Login(context.Background(), tm.member, "current-password")
+	checkError(t, err, nil, "Login")
+
+	caller, err := svc.Auth.Authenticate(context.Background(), tokens.AccessToken)
+	checkError(t, err, nil, "Authenticate")
+	if caller.PeopleID != tm.member || caller.Role != entities.RoleMember {
+		t.Fatalf("Authenticate = %+v", caller)
+	}
+
+	ctx := ContextWithCaller(context.Background(), caller)
+	checkError(t, svc.Auth.SetPassword(ctx, tm.member, "current-password", "next-password"), nil, "SetPassword")
+
+	_, err = svc.Auth.Authenticate(context.Background(), tokens.AccessToken)
+	checkError(t, err, ErrUnauthorized, "Authenticate after SetPassword")
+}
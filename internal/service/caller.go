@@ -0,0 +1,84 @@
+package service
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"fmt"
+)
+
+type callerKey struct{}
+
+// ContextWithCaller сохраняет в контексте пользователя, выполняющего запрос.
+func ContextWithCaller(ctx context.Context, caller entities.Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext возвращает пользователя, выполняющего запрос.
+// Внутренние вызовы выполняются без пользователя в контексте.
+func CallerFromContext(ctx context.Context) (entities.Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(entities.Caller)
+	return caller, ok
+}
+
+// Access проверяет права пользователя, выполняющего запрос, согласно его роли.
+// Внутренние вызовы без пользователя в контексте не ограничиваются.
+type Access struct {
+	people storage.PeopleManage
+}
+
+// NewAccess создает новый экземпляр Access.
+func NewAccess(p storage.PeopleManage) *Access {
+	return &Access{people: p}
+}
+
+// requireRole разрешает операцию только пользователям с одной из перечисленных ролей.
+func requireRole(ctx context.Context, roles ...entities.Role) error {
+	caller, ok := CallerFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	for _, role := range roles {
+		if caller.Role == role {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: role %q is not allowed", ErrForbidden, caller.Role)
+}
+
+// isAdmin сообщает, есть ли у пользователя из контекста полный доступ.
+func isAdmin(ctx context.Context) bool {
+	caller, ok := CallerFromContext(ctx)
+	return !ok || caller.Role == entities.RoleAdmin
+}
+
+// actFor подставляет пользователя из контекста, если peopleID не задан,
+// и проверяет право действовать от имени другого пользователя:
+// администратор - от имени любого, менеджер - от имени своей команды, участник - только от своего.
+func (a *Access) actFor(ctx context.Context, peopleID int) (int, error) {
+	caller, ok := CallerFromContext(ctx)
+	if !ok {
+		return peopleID, nil
+	}
+
+	if peopleID == 0 || peopleID == caller.PeopleID {
+		return caller.PeopleID, nil
+	}
+
+	switch caller.Role {
+	case entities.RoleAdmin:
+		return peopleID, nil
+	case entities.RoleManager:
+		people, err := a.people.GetByID(ctx, peopleID)
+		if err != nil {
+			return 0, err
+		}
+		if people.ManagerID == caller.PeopleID {
+			return peopleID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: cannot act for people ID %d", ErrForbidden, peopleID)
+}
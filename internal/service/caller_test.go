@@ -0,0 +1,64 @@
+package service
+
+import (
+	"TaskSync/internal/entities"
+	"context"
+	"testing"
+)
+
+func TestActFor(t *testing.T) {
+	_, s := newTestService(t)
+	tm := newTeam(t, s)
+	access := NewAccess(s.PeopleManage)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		peopleID int
+		want     int
+		wantErr  error
+	}{
+		{"internal call", context.Background(), tm.member, tm.member, nil},
+		{"internal call without people", context.Background(), 0, 0, nil},
+		{"admin for anyone", tm.admins(), tm.outsider, tm.outsider, nil},
+		{"admin for self by default", tm.admins(), 0, tm.admin, nil},
+		{"manager for team member", tm.managers(), tm.member, tm.member, nil},
+		{"manager for self by default", tm.managers(), 0, tm.manager, nil},
+		{"manager for other team", tm.managers(), tm.outsider, 0, ErrForbidden},
+		{"manager for admin", tm.managers(), tm.admin, 0, ErrForbidden},
+		{"member for self", tm.members(), tm.member, tm.member, nil},
+		{"member for self by default", tm.members(), 0, tm.member, nil},
+		{"member for manager", tm.members(), tm.manager, 0, ErrForbidden},
+		{"member for other member", tm.members(), tm.outsider, 0, ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := access.actFor(tt.ctx, tt.peopleID)
+			checkError(t, err, tt.wantErr, "actFor")
+			if got != tt.want {
+				t.Fatalf("actFor = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		roles []entities.Role
+		want  error
+	}{
+		{"internal call", context.Background(), []entities.Role{entities.RoleAdmin}, nil},
+		{"allowed", as(1, entities.RoleManager), []entities.Role{entities.RoleAdmin, entities.RoleManager}, nil},
+		{"denied", as(1, entities.RoleMember), []entities.Role{entities.RoleAdmin, entities.RoleManager}, ErrForbidden},
+		{"admin only", as(1, entities.RoleManager), []entities.Role{entities.RoleAdmin}, ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkError(t, requireRole(tt.ctx, tt.roles...), tt.want, "requireRole")
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"TaskSync/internal/storage"
+	"context"
+	"time"
+)
+
+// ChecklistService представляет сервис для работы с чек-листами задач.
+type ChecklistService struct {
+	storage storage.ChecklistManage
+}
+
+// NewChecklistService создает новый экземпляр ChecklistService.
+func NewChecklistService(s storage.ChecklistManage) *ChecklistService {
+	return &ChecklistService{storage: s}
+}
+
+// Add добавляет пункт в конец чек-листа задачи и возвращает его ID.
+func (c *ChecklistService) Add(ctx context.Context, taskID int, title string) (int, error) {
+	if err := validate(checklistRequest{TaskID: taskID, Title: title}, checklistAddRules); err != nil {
+		return 0, err
+	}
+
+	return c.storage.AddItem(ctx, taskID, title)
+}
+
+// Check отмечает пункт чек-листа выполненным или снимает отметку.
+// Пункты отмечаются независимо друг от друга, повторная отметка сохраняет время первой.
+func (c *ChecklistService) Check(ctx context.Context, taskID, itemID int, done bool) error {
+	if err := validate(checklistRequest{TaskID: taskID, ItemID: itemID}, checklistItemRules); err != nil {
+		return err
+	}
+
+	var doneAt time.Time
+	if done {
+		doneAt = time.Now().UTC()
+	}
+
+	return c.storage.SetItemDone(ctx, taskID, itemID, doneAt)
+}
+
+// Remove удаляет пункт из чек-листа задачи.
+func (c *ChecklistService) Remove(ctx context.Context, taskID, itemID int) error {
+	if err := validate(checklistRequest{TaskID: taskID, ItemID: itemID}, checklistItemRules); err != nil {
+		return err
+	}
+
+	return c.storage.DeleteItem(ctx, taskID, itemID)
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"TaskSync/internal/storage"
+	"context"
+)
+
+// DependencyService представляет сервис для работы с зависимостями между задачами.
+type DependencyService struct {
+	storage storage.DependencyManage
+}
+
+// NewDependencyService создает новый экземпляр DependencyService.
+func NewDependencyService(s storage.DependencyManage) *DependencyService {
+	return &DependencyService{storage: s}
+}
+
+// Add добавляет зависимость: задача blockerID блокирует задачу blockedID.
+// Зависимость, замыкающая цепочку задач в цикл, отклоняется хранилищем.
+func (d *DependencyService) Add(ctx context.Context, blockerID, blockedID int) error {
+	if err := validate(dependencyRequest{BlockerID: blockerID, BlockedID: blockedID}, dependencyRules); err != nil {
+		return err
+	}
+
+	return d.storage.Add(ctx, blockerID, blockedID)
+}
+
+// Remove удаляет зависимость между задачами.
+func (d *DependencyService) Remove(ctx context.Context, blockerID, blockedID int) error {
+	if err := validate(dependencyRequest{BlockerID: blockerID, BlockedID: blockedID}, dependencyRules); err != nil {
+		return err
+	}
+
+	return d.storage.Remove(ctx, blockerID, blockedID)
+}
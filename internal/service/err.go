@@ -0,0 +1,26 @@
+package service
+
+import "TaskSync/internal/domain"
+
+var (
+	ErrTimerRunning   = domain.New(domain.ErrConflict, "timer already running")
+	ErrTimerNotPaused = domain.New(domain.ErrConflict, "timer is not paused")
+	ErrNoActiveTimer  = domain.New(domain.ErrNotFound, "no active timer")
+
+	ErrTimeEntryOverlap = domain.New(domain.ErrConflict, "time entry overlaps another entry")
+
+	ErrUnknownStatus     = domain.New(domain.ErrValidation, "unknown task status")
+	ErrInvalidTransition = domain.New(domain.ErrConflict, "task status transition is not allowed")
+
+	ErrTaskBlocked = domain.New(domain.ErrConflict, "task is blocked by unfinished tasks")
+
+	ErrUnauthorized = domain.New(domain.ErrUnauthorized, "unauthorized")
+	ErrForbidden    = domain.New(domain.ErrForbidden, "forbidden")
+	ErrWeakPassword = domain.New(domain.ErrValidation, "weak password")
+
+	ErrInvalidAPIKey = domain.New(domain.ErrValidation, "invalid api key request")
+
+	ErrInvalidWeek    = domain.New(domain.ErrValidation, "invalid week")
+	ErrTimesheetState = domain.New(domain.ErrConflict, "timesheet state does not allow this action")
+	ErrPeriodLocked   = domain.New(domain.ErrConflict, "time period is approved and locked")
+)
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"TaskSync/internal/entities"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OverlapPolicy определяет поведение при пересечении интервалов времени одного пользователя.
+type OverlapPolicy string
+
+const (
+	OverlapReject OverlapPolicy = "reject" // новая запись отклоняется
+	OverlapTrim   OverlapPolicy = "trim"   // предыдущая запись обрезается до начала новой
+	OverlapFlag   OverlapPolicy = "flag"   // запись сохраняется, пересекающиеся записи помечаются
+)
+
+// ParseOverlapPolicy разбирает политику из конфигурации, по умолчанию используется OverlapReject.
+func ParseOverlapPolicy(s string) (OverlapPolicy, error) {
+	switch p := OverlapPolicy(strings.ToLower(strings.TrimSpace(s))); p {
+	case "":
+		return OverlapReject, nil
+	case OverlapReject, OverlapTrim, OverlapFlag:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown overlap policy %q", s)
+	}
+}
+
+// OverlapError возвращается, когда интервал пересекается с уже записанным временем пользователя.
+type OverlapError struct {
+	Entries []entities.TimeEntry
+}
+
+func (e *OverlapError) Error() string {
+	ids := make([]string, 0, len(e.Entries))
+	for _, entry := range e.Entries {
+		ids = append(ids, strconv.Itoa(entry.ID))
+	}
+	return fmt.Sprintf("time entry overlaps entries %s", strings.Join(ids, ", "))
+}
+
+func (e *OverlapError) Unwrap() error {
+	return ErrTimeEntryOverlap
+}
+
+// resolveOverlaps применяет политику пересечений к интервалу [start, end) пользователя,
+// нулевой end означает открытую сессию.
+// Возвращает изменения пересекающихся записей, хранилище применяет их в одной транзакции с сохранением новой записи.
+func (t *TimeService) resolveOverlaps(ctx context.Context, peopleID int, start, end time.Time) (entities.OverlapChanges, error) {
+	entries, err := t.storage.OverlappingTimeEntries(ctx, peopleID, start, end, 0)
+	if err != nil {
+		return entities.OverlapChanges{}, err
+	}
+
+	if len(entries) == 0 {
+		return entities.OverlapChanges{}, nil
+	}
+
+	ids := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+
+	// Обрезка и пометка меняют записи, поэтому они не должны задевать согласованные недели
+	if t.overlapPolicy == OverlapTrim || t.overlapPolicy == OverlapFlag {
+		if err := t.checkEntriesUnlocked(ctx, entries); err != nil {
+			return entities.OverlapChanges{}, err
+		}
+	}
+
+	switch t.overlapPolicy {
+	case OverlapTrim:
+		// Обрезать можно только записи, начатые раньше новой
+		for _, entry := range entries {
+			if !entry.StartTime.Before(start) {
+				return entities.OverlapChanges{}, &OverlapError{Entries: entries}
+			}
+		}
+
+		return entities.OverlapChanges{Trim: ids}, nil
+
+	case OverlapFlag:
+		return entities.OverlapChanges{Flag: ids}, nil
+
+	default:
+		return entities.OverlapChanges{}, &OverlapError{Entries: entries}
+	}
+}
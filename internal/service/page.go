@@ -0,0 +1,199 @@
+package service
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Размер страницы списка по умолчанию и максимальный.
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 500
+)
+
+// sortKeys поля сортировки списка и значения этих полей у записи.
+// Значения последней записи страницы сохраняются в курсоре, тип значения задаёт тип поля при разборе курсора.
+type sortKeys[T any] map[string]func(T) any
+
+var peopleSortKeys = sortKeys[entities.People]{
+	"id":         func(p entities.People) any { return p.ID },
+	"surname":    func(p entities.People) any { return p.Surname },
+	"name":       func(p entities.People) any { return p.Name },
+	"patronymic": func(p entities.People) any { return p.Patronymic },
+}
+
+var taskSortKeys = sortKeys[entities.Task]{
+	"id":         func(t entities.Task) any { return t.ID },
+	"title":      func(t entities.Task) any { return t.Title },
+	"status":     func(t entities.Task) any { return string(t.Status) },
+	"project_id": func(t entities.Task) any { return t.ProjectID },
+}
+
+var errMalformedCursor = errors.New("is malformed")
+
+// cursor содержимое курсора: сортировка, для которой он выдан, и значения полей сортировки последней записи.
+type cursor struct {
+	Sort  string            `json:"s"`
+	After []json.RawMessage `json:"a"`
+}
+
+// pageRequest проверяет параметры страницы и преобразует их в запрос к хранилищу.
+// Сортировка всегда дополняется полем id, чтобы порядок записей был однозначным.
+// Хранилище запрашивает на одну запись больше, по ней определяется наличие следующей страницы.
+func pageRequest[T any](query entities.PageQuery, keys sortKeys[T]) (entities.PageRequest, error) {
+	var fields []domain.FieldError
+
+	limit := query.Limit
+	switch {
+	case limit == 0:
+		limit = defaultPageLimit
+	case limit < 0 || limit > maxPageLimit:
+		fields = append(fields, domain.FieldError{Field: "limit", Message: fmt.Sprintf("must be between 1 and %d", maxPageLimit)})
+	}
+
+	sort, err := parseSort(query.Sort, keys)
+	if err != nil {
+		fields = append(fields, domain.FieldError{Field: "sort", Message: err.Error()})
+	}
+
+	if len(fields) != 0 {
+		return entities.PageRequest{}, &domain.ValidationError{Fields: fields}
+	}
+
+	request := entities.PageRequest{Limit: limit + 1, Sort: sort}
+
+	if query.Cursor != "" {
+		if request.After, err = decodeCursor(query.Cursor, sort, keys); err != nil {
+			return entities.PageRequest{}, domain.NewFieldError("cursor", err.Error())
+		}
+	}
+
+	return request, nil
+}
+
+// newPage собирает страницу из записей хранилища. Если хранилище вернуло лишнюю запись,
+// курсор следующей страницы указывает на последнюю запись текущей.
+func newPage[T any](items []T, total int, request entities.PageRequest, keys sortKeys[T]) entities.Page[T] {
+	page := entities.Page[T]{Items: items, Total: total}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+
+	limit := request.Limit - 1
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.NextCursor = encodeCursor(page.Items[limit-1], request.Sort, keys)
+	}
+
+	return page
+}
+
+// parseSort разбирает список полей сортировки вида "surname,-id".
+func parseSort[T any](value string, keys sortKeys[T]) ([]entities.SortField, error) {
+	var sort []entities.SortField
+	seen := make(map[string]bool)
+
+	if value != "" {
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			field := entities.SortField{Field: strings.TrimPrefix(part, "-"), Desc: strings.HasPrefix(part, "-")}
+
+			if _, ok := keys[field.Field]; !ok {
+				return nil, fmt.Errorf("unknown field %q, expected one of %s", field.Field, strings.Join(sortFieldNames(keys), ", "))
+			}
+			if seen[field.Field] {
+				return nil, fmt.Errorf("field %q is repeated", field.Field)
+			}
+
+			seen[field.Field] = true
+			sort = append(sort, field)
+		}
+	}
+
+	if !seen["id"] {
+		sort = append(sort, entities.SortField{Field: "id"})
+	}
+
+	return sort, nil
+}
+
+// formatSort возвращает сортировку в виде параметра запроса, курсор выдаётся для этого значения.
+func formatSort(sort []entities.SortField) string {
+	parts := make([]string, len(sort))
+	for i, field := range sort {
+		parts[i] = field.Field
+		if field.Desc {
+			parts[i] = "-" + field.Field
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func encodeCursor[T any](item T, sort []entities.SortField, keys sortKeys[T]) string {
+	c := cursor{Sort: formatSort(sort)}
+	for _, field := range sort {
+		value, _ := json.Marshal(keys[field.Field](item))
+		c.After = append(c.After, value)
+	}
+
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor возвращает значения полей сортировки из курсора.
+// Курсор, выданный для другой сортировки, не принимается.
+func decodeCursor[T any](value string, sort []entities.SortField, keys sortKeys[T]) ([]any, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errMalformedCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, errMalformedCursor
+	}
+
+	if c.Sort != formatSort(sort) {
+		return nil, fmt.Errorf("was issued for sort %q", c.Sort)
+	}
+
+	if len(c.After) != len(sort) {
+		return nil, errMalformedCursor
+	}
+
+	var zero T
+	after := make([]any, len(sort))
+	for i, field := range sort {
+		// Значение разбирается в тип поля, например int для id
+		switch keys[field.Field](zero).(type) {
+		case int:
+			var v int
+			err = json.Unmarshal(c.After[i], &v)
+			after[i] = v
+		default:
+			var v string
+			err = json.Unmarshal(c.After[i], &v)
+			after[i] = v
+		}
+		if err != nil {
+			return nil, errMalformedCursor
+		}
+	}
+
+	return after, nil
+}
+
+func sortFieldNames[T any](keys sortKeys[T]) []string {
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -4,44 +4,120 @@ import (
 	"TaskSync/internal/entities"
 	"TaskSync/internal/storage"
 	"context"
+	"fmt"
+	"time"
 )
 
 // PeopleService представляет сервис для работы с данными пользователей.
 type PeopleService struct {
-	storage storage.PeopleManage
+	storage    storage.PeopleManage
+	timesheets storage.TimesheetManage
 }
 
 // NewPeopleService создает новый экземпляр PeopleService.
-func NewPeopleService(s storage.PeopleManage) *PeopleService {
-	return &PeopleService{storage: s}
+// Табели используются для запрета удаления пользователей с согласованным временем.
+func NewPeopleService(s storage.PeopleManage, timesheets storage.TimesheetManage) *PeopleService {
+	return &PeopleService{storage: s, timesheets: timesheets}
 }
 
-// Create создает новую запись пользователя.
+// Create создает новую запись пользователя, доступно только администратору.
 func (p *PeopleService) Create(ctx context.Context, people entities.People) (int, error) {
+	if err := requireRole(ctx, entities.RoleAdmin); err != nil {
+		return 0, err
+	}
+
+	if err := validate(people, peopleCreateRules); err != nil {
+		return 0, err
+	}
+
 	return p.storage.Create(ctx, people)
 }
 
 // GetByID возвращает данные пользователя по его ID.
 func (p *PeopleService) GetByID(ctx context.Context, peopleID int) (entities.People, error) {
-	return p.storage.GetByID(ctx, peopleID)
+	people, err := p.storage.GetByID(ctx, peopleID)
+	if err != nil {
+		return people, err
+	}
+
+	return hidePassport(ctx, people), nil
 }
 
-// GetByFilter возвращает список пользователей, отфильтрованных по указанным параметрам.
-func (p *PeopleService) GetByFilter(ctx context.Context, filterPeople entities.People, limit, offset int) ([]entities.People, error) {
-	return p.storage.GetByFilter(ctx, filterPeople, limit, offset)
+// GetByFilter возвращает страницу пользователей, отфильтрованных по указанным параметрам.
+// Для ФИО и адреса в filterPeople.Match можно задать способ сравнения, по умолчанию точное совпадение.
+// Фильтр по паспортным данным учитывается только для администратора.
+func (p *PeopleService) GetByFilter(ctx context.Context, filterPeople entities.PeopleFilter, query entities.PageQuery) (entities.Page[entities.People], error) {
+	if !isAdmin(ctx) {
+		filterPeople.PassportSeries, filterPeople.PassportNumber = 0, 0
+	}
+
+	if err := validate(filterPeople, peopleFilterRules); err != nil {
+		return entities.Page[entities.People]{}, err
+	}
+
+	request, err := pageRequest(query, peopleSortKeys)
+	if err != nil {
+		return entities.Page[entities.People]{}, err
+	}
+
+	peopleList, total, err := p.storage.GetByFilter(ctx, filterPeople, request)
+	if err != nil {
+		return entities.Page[entities.People]{}, err
+	}
+
+	for i := range peopleList {
+		peopleList[i] = hidePassport(ctx, peopleList[i])
+	}
+
+	return newPage(peopleList, total, request, peopleSortKeys), nil
 }
 
-// List возвращает список всех пользователей.
-func (p *PeopleService) List(ctx context.Context) ([]entities.People, error) {
-	return p.storage.List(ctx)
+// List возвращает страницу всех пользователей.
+func (p *PeopleService) List(ctx context.Context, query entities.PageQuery) (entities.Page[entities.People], error) {
+	return p.GetByFilter(ctx, entities.PeopleFilter{}, query)
 }
 
 // Update обновляет данные пользователя.
+// Администратор меняет любые данные, пользователь - только ФИО и адрес в своей записи.
 func (p *PeopleService) Update(ctx context.Context, people entities.People) error {
+	if err := validate(people, peopleUpdateRules); err != nil {
+		return err
+	}
+
+	if !isAdmin(ctx) {
+		caller, _ := CallerFromContext(ctx)
+		if people.ID != caller.PeopleID || people.PassportSeries != 0 || people.PassportNumber != 0 ||
+			people.Role != "" || people.ManagerID != 0 {
+			return fmt.Errorf("%w: only own name and address can be changed", ErrForbidden)
+		}
+	}
+
 	return p.storage.Update(ctx, people)
 }
 
-// Delete удаляет пользователя по его ID.
+// Delete удаляет пользователя по его ID, доступно только администратору.
+// Пользователь с согласованными табелями не удаляется, иначе его записи времени в этих неделях изменились бы.
 func (p *PeopleService) Delete(ctx context.Context, peopleID int) error {
+	if err := requireRole(ctx, entities.RoleAdmin); err != nil {
+		return err
+	}
+
+	week, err := p.timesheets.ApprovedWeek(ctx, peopleID, time.Unix(0, 0).UTC(), time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		return err
+	}
+
+	if !week.IsZero() {
+		return fmt.Errorf("%w: people ID %d has approved week %s", ErrPeriodLocked, peopleID, formatWeek(week))
+	}
+
 	return p.storage.Delete(ctx, peopleID)
 }
+
+// hidePassport скрывает паспортные данные от всех, кроме администратора.
+func hidePassport(ctx context.Context, people entities.People) entities.People {
+	if !isAdmin(ctx) {
+		people.PassportSeries, people.PassportNumber = 0, 0
+	}
+	return people
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"TaskSync/internal/entities"
+	"context"
+	"testing"
+)
+
+func TestPeopleAccess(t *testing.T) {
+	newPerson := func() entities.People {
+		passportNumber++
+		return entities.People{PassportSeries: 2000, PassportNumber: passportNumber, Surname: "New", Name: "Person", Address: "Address"}
+	}
+
+	tests := []struct {
+		name   string
+		action func(svc *Service, tm team) error
+		want   error
+	}{
+		{
+			name:   "admin creates",
+			action: func(svc *Service, tm team) error { _, err := svc.People.Create(tm.admins(), newPerson()); return err },
+		},
+		{
+			name: "manager creates", want: ErrForbidden,
+			action: func(svc *Service, tm team) error { _, err := svc.People.Create(tm.managers(), newPerson()); return err },
+		},
+		{
+			name: "member creates", want: ErrForbidden,
+			action: func(svc *Service, tm team) error { _, err := svc.People.Create(tm.members(), newPerson()); return err },
+		},
+		{
+			name:   "admin deletes",
+			action: func(svc *Service, tm team) error { return svc.People.Delete(tm.admins(), tm.outsider) },
+		},
+		{
+			name: "manager deletes", want: ErrForbidden,
+			action: func(svc *Service, tm team) error { return svc.People.Delete(tm.managers(), tm.member) },
+		},
+		{
+			name: "member deletes", want: ErrForbidden,
+			action: func(svc *Service, tm team) error { return svc.People.Delete(tm.members(), tm.outsider) },
+		},
+		{
+			name: "member updates own name",
+			action: func(svc *Service, tm team) error {
+				return svc.People.Update(tm.members(), entities.People{ID: tm.member, Surname: "Renamed", Address: "New address"})
+			},
+		},
+		{
+			name: "member updates other", want: ErrForbidden,
+			action: func(svc *Service, tm team) error {
+				return svc.People.Update(tm.members(), entities.People{ID: tm.outsider, Surname: "Renamed"})
+			},
+		},
+		{
+			name: "member changes own role", want: ErrForbidden,
+			action: func(svc *Service, tm team) error {
+				return svc.People.Update(tm.members(), entities.People{ID: tm.member, Role: entities.RoleAdmin})
+			},
+		},
+		{
+			name: "member changes own passport", want: ErrForbidden,
+			action: func(svc *Service, tm team) error {
+				return svc.People.Update(tm.members(), entities.People{ID: tm.member, PassportSeries: 4321})
+			},
+		},
+		{
+			name: "manager changes team member", want: ErrForbidden,
+			action: func(svc *Service, tm team) error {
+				return svc.People.Update(tm.managers(), entities.People{ID: tm.member, Surname: "Renamed"})
+			},
+		},
+		{
+			name: "admin changes role",
+			action: func(svc *Service, tm team) error {
+				return svc.People.Update(tm.admins(), entities.People{ID: tm.member, Role: entities.RoleManager})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, s := newTestService(t)
+			tm := newTeam(t, s)
+			checkError(t, tt.action(svc, tm), tt.want, tt.name)
+		})
+	}
+}
+
+func TestPeoplePassportHidden(t *testing.T) {
+	svc, s := newTestService(t)
+	tm := newTeam(t, s)
+
+	person, err := s.PeopleManage.GetByID(context.Background(), tm.member)
+	checkError(t, err, nil, "GetByID")
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		visible bool
+	}{
+		{"admin", tm.admins(), true},
+		{"internal call", context.Background(), true},
+		{"manager", tm.managers(), false},
+		{"member self", tm.members(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.People.GetByID(tt.ctx, tm.member)
+			checkError(t, err, nil, "GetByID")
+			if visible := got.PassportSeries != 0 || got.PassportNumber != 0; visible != tt.visible {
+				t.Fatalf("GetByID passport = %d %d, want visible %t", got.PassportSeries, got.PassportNumber, tt.visible)
+			}
+
+			list, err := svc.People.List(tt.ctx, entities.PageQuery{})
+			checkError(t, err, nil, "List")
+			for _, p := range list.Items {
+				if visible := p.PassportSeries != 0 || p.PassportNumber != 0; visible != tt.visible {
+					t.Fatalf("List passport of people ID %d = %d %d, want visible %t", p.ID, p.PassportSeries, p.PassportNumber, tt.visible)
+				}
+			}
+
+			// Поиск по паспорту доступен только администратору, остальным фильтр не применяется
+			filter := entities.PeopleFilter{People: entities.People{PassportSeries: person.PassportSeries, PassportNumber: person.PassportNumber}}
+			page, err := svc.People.GetByFilter(tt.ctx, filter, entities.PageQuery{})
+			checkError(t, err, nil, "GetByFilter")
+			want := 4
+			if tt.visible {
+				want = 1
+			}
+			if page.Total != want {
+				t.Fatalf("GetByFilter by passport total = %d, want %d", page.Total, want)
+			}
+		})
+	}
+}
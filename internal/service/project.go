@@ -0,0 +1,55 @@
+package service
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+)
+
+// ProjectService представляет сервис для работы с данными проектов.
+// Создавать, изменять и удалять проекты могут администратор и менеджер.
+type ProjectService struct {
+	storage storage.ProjectManage
+}
+
+// NewProjectService создает новый экземпляр ProjectService.
+func NewProjectService(s storage.ProjectManage) *ProjectService {
+	return &ProjectService{storage: s}
+}
+
+// Create создает новый проект.
+func (p *ProjectService) Create(ctx context.Context, project entities.Project) (int, error) {
+	if err := requireRole(ctx, entities.RoleAdmin, entities.RoleManager); err != nil {
+		return 0, err
+	}
+
+	return p.storage.Create(ctx, project)
+}
+
+// GetByID возвращает данные проекта по его ID.
+func (p *ProjectService) GetByID(ctx context.Context, projectID int) (entities.Project, error) {
+	return p.storage.GetByID(ctx, projectID)
+}
+
+// List возвращает список всех проектов.
+func (p *ProjectService) List(ctx context.Context) ([]entities.Project, error) {
+	return p.storage.List(ctx)
+}
+
+// Update обновляет данные проекта.
+func (p *ProjectService) Update(ctx context.Context, project entities.Project) error {
+	if err := requireRole(ctx, entities.RoleAdmin, entities.RoleManager); err != nil {
+		return err
+	}
+
+	return p.storage.Update(ctx, project)
+}
+
+// Delete удаляет проект по его ID, задачи проекта остаются без проекта.
+func (p *ProjectService) Delete(ctx context.Context, projectID int) error {
+	if err := requireRole(ctx, entities.RoleAdmin, entities.RoleManager); err != nil {
+		return err
+	}
+
+	return p.storage.Delete(ctx, projectID)
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"TaskSync/pkg/rrule"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RecurrenceService представляет сервис для работы с повторяющимися задачами.
+type RecurrenceService struct {
+	storage  storage.RecurrenceManage
+	access   *Access
+	workflow *Workflow
+}
+
+// NewRecurrenceService создает новый экземпляр RecurrenceService.
+// Созданные по расписанию задачи получают начальный статус workflow.
+func NewRecurrenceService(s storage.RecurrenceManage, access *Access, workflow *Workflow) *RecurrenceService {
+	return &RecurrenceService{storage: s, access: access, workflow: workflow}
+}
+
+// Create создает повторяющуюся задачу. Правило сохраняется в каноническом виде,
+// первым создаётся первое повторение после текущего момента.
+// Создавать повторяющиеся задачи могут администратор и менеджер - с исполнителями из своей команды.
+func (r *RecurrenceService) Create(ctx context.Context, rec entities.Recurrence) (int, error) {
+	if err := validate(rec, recurrenceCreateRules); err != nil {
+		return 0, err
+	}
+
+	rule, err := rrule.Parse(rec.Rule)
+	if err != nil {
+		return 0, domain.NewFieldError("rule", err.Error())
+	}
+
+	if err := requireRole(ctx, entities.RoleAdmin, entities.RoleManager); err != nil {
+		return 0, err
+	}
+
+	for _, peopleID := range rec.AssigneeIDs {
+		if _, err := r.access.actFor(ctx, peopleID); err != nil {
+			return 0, err
+		}
+	}
+
+	rec.Rule = rule.String()
+	rec.Start = rec.Start.UTC().Truncate(time.Second)
+	_, rec.EstimateSeconds = schedule(time.Time{}, rec.Estimate)
+
+	next, ok := rule.Next(rec.Start, time.Now().UTC())
+	if !ok {
+		return 0, domain.NewFieldError("rule", "has no occurrences in the future")
+	}
+	rec.NextRun = next
+
+	return r.storage.Create(ctx, rec)
+}
+
+// GetByID возвращает повторяющуюся задачу по её ID.
+func (r *RecurrenceService) GetByID(ctx context.Context, recurrenceID int) (entities.Recurrence, error) {
+	return r.storage.GetByID(ctx, recurrenceID)
+}
+
+// List возвращает все повторяющиеся задачи.
+func (r *RecurrenceService) List(ctx context.Context) ([]entities.Recurrence, error) {
+	return r.storage.List(ctx)
+}
+
+// Delete удаляет повторяющуюся задачу, уже созданные по ней задачи остаются.
+func (r *RecurrenceService) Delete(ctx context.Context, recurrenceID int) error {
+	if err := requireRole(ctx, entities.RoleAdmin, entities.RoleManager); err != nil {
+		return err
+	}
+
+	return r.storage.Delete(ctx, recurrenceID)
+}
+
+// Materialize создает задачи повторений, наступивших к моменту now, и возвращает число созданных задач.
+// Из пропущенных повторений, например пока сервер был остановлен, создаётся только последнее.
+// Уже созданное повторение не создаётся повторно, поэтому вызов можно безопасно повторять.
+// Ошибка одной повторяющейся задачи не мешает остальным, ошибки возвращаются вместе.
+func (r *RecurrenceService) Materialize(ctx context.Context, now time.Time) (int, error) {
+	due, err := r.storage.Due(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	var errs []error
+
+	for _, rec := range due {
+		rule, err := rrule.Parse(rec.Rule)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("recurrence ID %d: %w", rec.ID, err))
+			continue
+		}
+
+		occurrence := rec.NextRun
+		if missed := rule.Between(rec.Start, rec.NextRun, now); len(missed) > 0 {
+			occurrence = missed[len(missed)-1]
+		}
+
+		// Нулевое следующее повторение завершает расписание
+		next, _ := rule.Next(rec.Start, now)
+
+		taskID, err := r.storage.Materialize(ctx, rec.ID, occurrence, rec.Task(r.workflow.Initial()), next)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if taskID != 0 {
+			created++
+		}
+	}
+
+	return created, errors.Join(errs...)
+}
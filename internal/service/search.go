@@ -0,0 +1,62 @@
+package service
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Число результатов поиска по умолчанию и максимальное.
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// SearchService представляет сервис полнотекстового поиска.
+type SearchService struct {
+	storage storage.SearchManage
+}
+
+// NewSearchService создает новый экземпляр SearchService.
+func NewSearchService(s storage.SearchManage) *SearchService {
+	return &SearchService{storage: s}
+}
+
+// Search ищет пользователей и задачи, в которых каждое слово запроса q совпадает с началом какого-либо слова.
+// Слова запроса - последовательности букв и цифр, остальные символы игнорируются.
+func (s *SearchService) Search(ctx context.Context, q string, limit int) ([]entities.SearchResult, error) {
+	var fields []domain.FieldError
+
+	terms := strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(terms) == 0 {
+		fields = append(fields, domain.FieldError{Field: "q", Message: "must contain at least one letter or digit"})
+	}
+
+	switch {
+	case limit == 0:
+		limit = defaultSearchLimit
+	case limit < 0 || limit > maxSearchLimit:
+		fields = append(fields, domain.FieldError{Field: "limit", Message: fmt.Sprintf("must be between 1 and %d", maxSearchLimit)})
+	}
+
+	if len(fields) != 0 {
+		return nil, &domain.ValidationError{Fields: fields}
+	}
+
+	results, err := s.storage.Search(ctx, terms, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if results == nil {
+		results = []entities.SearchResult{}
+	}
+
+	return results, nil
+}
@@ -12,38 +12,179 @@ import (
 type People interface {
 	Create(ctx context.Context, people entities.People) (int, error)
 	GetByID(ctx context.Context, peopleID int) (entities.People, error)
-	GetByFilter(ctx context.Context, filterPeople entities.People, limit, offset int) ([]entities.People, error)
-	List(ctx context.Context) ([]entities.People, error)
+	GetByFilter(ctx context.Context, filterPeople entities.PeopleFilter, query entities.PageQuery) (entities.Page[entities.People], error)
+	List(ctx context.Context, query entities.PageQuery) (entities.Page[entities.People], error)
 	Update(ctx context.Context, people entities.People) error
 	Delete(ctx context.Context, peopleID int) error
 }
 
 type Task interface {
 	Create(ctx context.Context, task entities.Task) (int, error)
-	GetByID(ctx context.Context, taskID int) (entities.Task, error)
-	List(ctx context.Context) ([]entities.Task, error)
+	GetByID(ctx context.Context, taskID int, subtree bool) (entities.Task, error)
+	List(ctx context.Context, filter entities.TaskFilter, query entities.PageQuery) (entities.Page[entities.Task], error)
+	Children(ctx context.Context, taskID int, query entities.PageQuery) (entities.Page[entities.Task], error)
 	Update(ctx context.Context, taskID int, title string, description string) error
 	UpdatePeople(ctx context.Context, peopleID, taskID int) error
+	UpdateProject(ctx context.Context, projectID, taskID int) error
+	UpdateParent(ctx context.Context, parentID, taskID int) error
+	UpdateSchedule(ctx context.Context, taskID int, dueDate time.Time, estimate string) error
+	Transition(ctx context.Context, taskID int, status entities.TaskStatus, peopleID int) error
 	Delete(ctx context.Context, taskID int) error
 }
 
+type Project interface {
+	Create(ctx context.Context, project entities.Project) (int, error)
+	GetByID(ctx context.Context, projectID int) (entities.Project, error)
+	List(ctx context.Context) ([]entities.Project, error)
+	Update(ctx context.Context, project entities.Project) error
+	Delete(ctx context.Context, projectID int) error
+}
+
+// метки задач
+type Tag interface {
+	Create(ctx context.Context, tag entities.Tag) (int, error)
+	GetByID(ctx context.Context, tagID int) (entities.Tag, error)
+	List(ctx context.Context) ([]entities.Tag, error)
+	Update(ctx context.Context, tag entities.Tag) error
+	Delete(ctx context.Context, tagID int) error
+	Attach(ctx context.Context, taskID, tagID int) error
+	Detach(ctx context.Context, taskID, tagID int) error
+}
+
+// зависимости между задачами
+type Dependency interface {
+	Add(ctx context.Context, blockerID, blockedID int) error
+	Remove(ctx context.Context, blockerID, blockedID int) error
+}
+
+// исполнители задач и трудозатраты по ним
+type Assignee interface {
+	Assign(ctx context.Context, taskID, peopleID int) error
+	Unassign(ctx context.Context, taskID, peopleID int) error
+	History(ctx context.Context, taskID int) ([]entities.TaskAssignment, error)
+	TimeByAssignee(ctx context.Context, taskID int) ([]entities.AssigneeTimeSpent, error)
+}
+
+// повторяющиеся задачи, создаваемые по расписанию
+type Recurrence interface {
+	Create(ctx context.Context, rec entities.Recurrence) (int, error)
+	GetByID(ctx context.Context, recurrenceID int) (entities.Recurrence, error)
+	List(ctx context.Context) ([]entities.Recurrence, error)
+	Delete(ctx context.Context, recurrenceID int) error
+	Materialize(ctx context.Context, now time.Time) (int, error)
+}
+
+// шаблоны задач и создание задач по ним
+type Template interface {
+	Create(ctx context.Context, tpl entities.TaskTemplate) (int, error)
+	GetByID(ctx context.Context, templateID int) (entities.TaskTemplate, error)
+	List(ctx context.Context) ([]entities.TaskTemplate, error)
+	Update(ctx context.Context, tpl entities.TaskTemplate) error
+	Delete(ctx context.Context, templateID int) error
+	Instantiate(ctx context.Context, templateID int, params entities.TemplateParams) (int, error)
+}
+
+// чек-листы задач
+type Checklist interface {
+	Add(ctx context.Context, taskID int, title string) (int, error)
+	Check(ctx context.Context, taskID, itemID int, done bool) error
+	Remove(ctx context.Context, taskID, itemID int) error
+}
+
 // управление временем выполнения
 type Time interface {
-	StartTimeEntry(ctx context.Context, taskID int, timeEntries time.Time) error
-	EndTimeEntry(ctx context.Context, taskID int, endTime time.Time) error
-	TasksTimeSpent(ctx context.Context, peopleID int, startTime, endTime time.Time) ([]entities.TaskTimeSpent, error)
+	StartTimeEntry(ctx context.Context, taskID, peopleID int, startTime time.Time) (int, error)
+	EndTimeEntry(ctx context.Context, taskID, peopleID int, endTime time.Time) error
+	EndTaskTimeEntries(ctx context.Context, taskID int, endTime time.Time) error
+	PauseTimeEntry(ctx context.Context, peopleID int, pauseTime time.Time) error
+	ResumeTimeEntry(ctx context.Context, peopleID int, resumeTime time.Time) (int, error)
+	ActiveTimer(ctx context.Context, peopleID int) (entities.ActiveTimer, error)
+	ListTimeEntries(ctx context.Context, taskID int) ([]entities.TimeEntry, error)
+	TasksTimeSpent(ctx context.Context, peopleID, projectID int, startTime, endTime time.Time) ([]entities.TaskTimeSpent, error)
+	ProjectsTimeSpent(ctx context.Context, peopleID int, startTime, endTime time.Time) ([]entities.ProjectTimeSpent, error)
+	TagsTimeSpent(ctx context.Context, peopleID, projectID int, startTime, endTime time.Time) ([]entities.TagTimeSpent, error)
+	Estimates(ctx context.Context, peopleID, projectID int) (entities.EstimateReport, error)
+}
+
+// вход пользователей и проверка токенов
+type Auth interface {
+	Login(ctx context.Context, peopleID int, password string) (entities.TokenPair, error)
+	Refresh(ctx context.Context, refreshToken string) (entities.TokenPair, error)
+	Logout(ctx context.Context, sessionID string) error
+	Authenticate(ctx context.Context, accessToken string) (entities.Caller, error)
+	SetPassword(ctx context.Context, peopleID int, currentPassword, password string) error
+	InitPassword(ctx context.Context, peopleID int, password string) (bool, error)
+}
+
+// ключи доступа интеграций
+type APIKey interface {
+	Create(ctx context.Context, name string, scopes []entities.Scope) (entities.APIKeyCreated, error)
+	List(ctx context.Context, peopleID int) ([]entities.APIKey, error)
+	Revoke(ctx context.Context, keyID int) error
+	Authenticate(ctx context.Context, rawKey string) (entities.Caller, error)
+}
+
+// недельные табели и их согласование
+type Timesheet interface {
+	Get(ctx context.Context, peopleID int, week string) (entities.Timesheet, error)
+	Submit(ctx context.Context, peopleID int, week string) error
+	Approve(ctx context.Context, peopleID int, week, comment string) error
+	Reject(ctx context.Context, peopleID int, week, comment string) error
+}
+
+// полнотекстовый поиск по пользователям и задачам
+type Search interface {
+	Search(ctx context.Context, q string, limit int) ([]entities.SearchResult, error)
 }
 
 type Service struct {
 	People
 	Task
+	Project
+	Tag
+	Dependency
+	Assignee
+	Recurrence
+	Template
+	Checklist
 	Time
+	Auth
+	APIKey
+	Timesheet
+	Search
+}
+
+// Config настройки бизнес-логики сервисов.
+// Если Workflow не задан, используется таблица переходов по умолчанию.
+type Config struct {
+	OverlapPolicy OverlapPolicy
+	Workflow      *Workflow
+	Auth          AuthConfig
 }
 
-func NewService(s *storage.Storage) *Service {
+func NewService(s *storage.Storage, cfg Config) *Service {
+	if cfg.Workflow == nil {
+		cfg.Workflow = DefaultWorkflow()
+	}
+
+	access := NewAccess(s.PeopleManage)
+	timeService := NewTimeService(s.TimeManage, s.TimesheetManage, s.DependencyManage, access, cfg.OverlapPolicy)
+	taskService := NewTaskService(s.TaskManage, access, timeService, cfg.Workflow)
+
 	return &Service{
-		People: NewPeopleService(s.PeopleManage),
-		Task:   NewTaskService(s.TaskManage),
-		Time:   NewTimeService(s.TimeManage),
+		People:     NewPeopleService(s.PeopleManage, s.TimesheetManage),
+		Task:       taskService,
+		Project:    NewProjectService(s.ProjectManage),
+		Tag:        NewTagService(s.TagManage),
+		Dependency: NewDependencyService(s.DependencyManage),
+		Assignee:   NewAssigneeService(s.AssigneeManage, s.TaskManage, access),
+		Recurrence: NewRecurrenceService(s.RecurrenceManage, access, cfg.Workflow),
+		Template:   NewTemplateService(s.TemplateManage, taskService, access),
+		Checklist:  NewChecklistService(s.ChecklistManage),
+		Time:       timeService,
+		Auth:       NewAuthService(s.AuthManage, s.PeopleManage, cfg.Auth),
+		APIKey:     NewAPIKeyService(s.APIKeyManage, s.PeopleManage),
+		Timesheet:  NewTimesheetService(s.TimesheetManage, s.TimeManage, access),
+		Search:     NewSearchService(s.SearchManage),
 	}
 }
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// newTestService создает сервисы поверх пустого хранилища в памяти.
+func newTestService(t *testing.T) (*Service, *storage.Storage) {
+	t.Helper()
+	s := storage.NewMemoryStorage()
+	svc := NewService(s, Config{Auth: AuthConfig{Secret: []byte("test-secret"), AccessTTL: time.Hour, RefreshTTL: 24 * time.Hour}})
+	return svc, s
+}
+
+// team пользователи с разными ролями: участник member входит в команду менеджера manager,
+// участник outsider - ни в чью.
+type team struct {
+	admin, manager, member, outsider int
+}
+
+// passportNumber последний выданный номер паспорта, номера не повторяются между проверками.
+var passportNumber = 100000
+
+func createPeople(t *testing.T, s *storage.Storage, surname string, role entities.Role, managerID int) int {
+	t.Helper()
+	passportNumber++
+	id, err := s.PeopleManage.Create(context.Background(), entities.People{
+		PassportSeries: 1000,
+		PassportNumber: passportNumber,
+		Surname:        surname,
+		Name:           "Name",
+		Address:        "Address",
+		Role:           role,
+		ManagerID:      managerID,
+	})
+	if err != nil {
+		t.Fatalf("PeopleManage.Create(%s): %v", surname, err)
+	}
+	return id
+}
+
+func newTeam(t *testing.T, s *storage.Storage) team {
+	t.Helper()
+	var tm team
+	tm.admin = createPeople(t, s, "Admin", entities.RoleAdmin, 0)
+	tm.manager = createPeople(t, s, "Manager", entities.RoleManager, 0)
+	tm.member = createPeople(t, s, "Member", entities.RoleMember, tm.manager)
+	tm.outsider = createPeople(t, s, "Outsider", entities.RoleMember, 0)
+	return tm
+}
+
+// as возвращает контекст запроса пользователя с ролью role.
+func as(peopleID int, role entities.Role) context.Context {
+	return ContextWithCaller(context.Background(), entities.Caller{PeopleID: peopleID, Role: role})
+}
+
+func (tm team) admins() context.Context   { return as(tm.admin, entities.RoleAdmin) }
+func (tm team) managers() context.Context { return as(tm.manager, entities.RoleManager) }
+func (tm team) members() context.Context  { return as(tm.member, entities.RoleMember) }
+
+func createTask(t *testing.T, s *storage.Storage, task entities.Task) int {
+	t.Helper()
+	id, err := s.TaskManage.Create(context.Background(), task, entities.OverlapChanges{})
+	if err != nil {
+		t.Fatalf("TaskManage.Create(%s): %v", task.Title, err)
+	}
+	return id
+}
+
+// checkError проверяет, что err - want, nil want означает успех.
+func checkError(t *testing.T, err, want error, call string) {
+	t.Helper()
+	if want == nil && err != nil {
+		t.Fatalf("%s: unexpected error: %v", call, err)
+	}
+	if want != nil && !errors.Is(err, want) {
+		t.Fatalf("%s: error = %v, want %v", call, err, want)
+	}
+}
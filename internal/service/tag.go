@@ -0,0 +1,60 @@
+package service
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+)
+
+// TagService представляет сервис для работы с метками задач.
+type TagService struct {
+	storage storage.TagManage
+}
+
+// NewTagService создает новый экземпляр TagService.
+func NewTagService(s storage.TagManage) *TagService {
+	return &TagService{storage: s}
+}
+
+// Create создает новую метку, имя метки уникально.
+func (t *TagService) Create(ctx context.Context, tag entities.Tag) (int, error) {
+	if err := validate(tag, tagCreateRules); err != nil {
+		return 0, err
+	}
+
+	return t.storage.Create(ctx, tag)
+}
+
+// GetByID возвращает метку по её ID.
+func (t *TagService) GetByID(ctx context.Context, tagID int) (entities.Tag, error) {
+	return t.storage.GetByID(ctx, tagID)
+}
+
+// List возвращает все метки.
+func (t *TagService) List(ctx context.Context) ([]entities.Tag, error) {
+	return t.storage.List(ctx)
+}
+
+// Update переименовывает метку.
+func (t *TagService) Update(ctx context.Context, tag entities.Tag) error {
+	if err := validate(tag, tagUpdateRules); err != nil {
+		return err
+	}
+
+	return t.storage.Update(ctx, tag)
+}
+
+// Delete удаляет метку по её ID, метка снимается со всех задач.
+func (t *TagService) Delete(ctx context.Context, tagID int) error {
+	return t.storage.Delete(ctx, tagID)
+}
+
+// Attach назначает метку задаче.
+func (t *TagService) Attach(ctx context.Context, taskID, tagID int) error {
+	return t.storage.AttachTag(ctx, taskID, tagID)
+}
+
+// Detach снимает метку с задачи.
+func (t *TagService) Detach(ctx context.Context, taskID, tagID int) error {
+	return t.storage.DetachTag(ctx, taskID, tagID)
+}
@@ -4,44 +4,252 @@ import (
 	"TaskSync/internal/entities"
 	"TaskSync/internal/storage"
 	"context"
+	"fmt"
+	"time"
 )
 
 // TaskService представляет сервис для работы с данными задач.
 type TaskService struct {
-	storage storage.TaskManage
+	storage  storage.TaskManage
+	access   *Access
+	time     *TimeService
+	workflow *Workflow
 }
 
 // NewTaskService создает новый экземпляр TaskService.
-func NewTaskService(t storage.TaskManage) *TaskService {
-	return &TaskService{storage: t}
+// Сервис времени проверяет запись о времени новой задачи и используется в автоматических действиях при смене статуса задачи.
+func NewTaskService(t storage.TaskManage, access *Access, timeService *TimeService, workflow *Workflow) *TaskService {
+	return &TaskService{storage: t, access: access, time: timeService, workflow: workflow}
 }
 
-// Create создает новую задачу для пользователя.
+// Create создает новую задачу для пользователя, задача получает начальный статус.
+// Запись о времени новой задачи проверяется сервисом времени, пересечения обрабатываются согласно его политике.
+// Записать время и назначить задачу можно только на пользователя, от имени которого разрешено действовать.
 func (t *TaskService) Create(ctx context.Context, task entities.Task) (int, error) {
-	return t.storage.Create(ctx, task)
+	if err := validate(task, taskCreateRules); err != nil {
+		return 0, err
+	}
+
+	if task.TimeEntry.PeopleID != 0 {
+		if _, err := t.access.actFor(ctx, task.TimeEntry.PeopleID); err != nil {
+			return 0, err
+		}
+	}
+
+	overlaps, err := t.time.taskEntryOverlaps(ctx, task.TimeEntry)
+	if err != nil {
+		return 0, err
+	}
+
+	task.Status = t.workflow.Initial()
+	task.DueDate, task.EstimateSeconds = schedule(task.DueDate, task.Estimate)
+	return t.storage.Create(ctx, task, overlaps)
 }
 
 // GetByID возвращает данные задачи по её ID.
-func (t *TaskService) GetByID(ctx context.Context, taskID int) (entities.Task, error) {
-	return t.storage.GetByID(ctx, taskID)
+// С subtree задача возвращается вместе со всеми подзадачами и временем, просуммированным по поддереву.
+func (t *TaskService) GetByID(ctx context.Context, taskID int, subtree bool) (entities.Task, error) {
+	return t.storage.GetByID(ctx, taskID, subtree)
 }
 
-// List возвращает список всех задач.
-func (t *TaskService) List(ctx context.Context) ([]entities.Task, error) {
-	return t.storage.List(ctx)
+// List возвращает страницу задач, удовлетворяющих фильтру.
+func (t *TaskService) List(ctx context.Context, filter entities.TaskFilter, query entities.PageQuery) (entities.Page[entities.Task], error) {
+	request, err := pageRequest(query, taskSortKeys)
+	if err != nil {
+		return entities.Page[entities.Task]{}, err
+	}
+
+	taskList, total, err := t.storage.List(ctx, filter, request)
+	if err != nil {
+		return entities.Page[entities.Task]{}, err
+	}
+
+	return newPage(taskList, total, request, taskSortKeys), nil
+}
+
+// Children возвращает страницу прямых подзадач задачи.
+func (t *TaskService) Children(ctx context.Context, taskID int, query entities.PageQuery) (entities.Page[entities.Task], error) {
+	// Несуществующая задача - ошибка, а не пустой список
+	if _, err := t.storage.GetByID(ctx, taskID, false); err != nil {
+		return entities.Page[entities.Task]{}, err
+	}
+
+	return t.List(ctx, entities.TaskFilter{ParentID: taskID}, query)
 }
 
 // Update обновляет данные задачи.
 func (t *TaskService) Update(ctx context.Context, taskID int, title string, description string) error {
+	if err := validate(entities.Task{ID: taskID, Title: title, Description: description}, taskUpdateRules); err != nil {
+		return err
+	}
+
+	if err := t.authorize(ctx, taskID); err != nil {
+		return err
+	}
+
 	return t.storage.Update(ctx, taskID, title, description)
 }
 
-// UpdatePeople обновляет исполнителя задачи.
+// UpdatePeople делает пользователя единственным исполнителем задачи, записи о выполненной работе не меняются.
+// Переназначать задачи может администратор и менеджер - на себя или участника своей команды.
 func (t *TaskService) UpdatePeople(ctx context.Context, peopleID, taskID int) error {
+	if err := requireRole(ctx, entities.RoleAdmin, entities.RoleManager); err != nil {
+		return err
+	}
+
+	if _, err := t.access.actFor(ctx, peopleID); err != nil {
+		return err
+	}
+
 	return t.storage.UpdatePeople(ctx, peopleID, taskID)
 }
 
-// Delete удаляет задачу по её ID.
+// UpdateProject переносит задачу в проект.
+func (t *TaskService) UpdateProject(ctx context.Context, projectID, taskID int) error {
+	if err := t.authorize(ctx, taskID); err != nil {
+		return err
+	}
+
+	return t.storage.UpdateProject(ctx, projectID, taskID)
+}
+
+// UpdateSchedule задаёт срок выполнения и оценку задачи, например 4h30m.
+// Нулевой срок и пустая оценка снимают их с задачи.
+func (t *TaskService) UpdateSchedule(ctx context.Context, taskID int, dueDate time.Time, estimate string) error {
+	if err := validate(entities.Task{ID: taskID, Estimate: estimate}, taskScheduleRules); err != nil {
+		return err
+	}
+
+	if err := t.authorize(ctx, taskID); err != nil {
+		return err
+	}
+
+	dueDate, seconds := schedule(dueDate, estimate)
+	return t.storage.UpdateSchedule(ctx, taskID, dueDate, time.Duration(seconds)*time.Second)
+}
+
+// schedule приводит срок к UTC и переводит проверенную оценку в секунды, пустая оценка - 0.
+func schedule(dueDate time.Time, estimate string) (time.Time, int64) {
+	if !dueDate.IsZero() {
+		dueDate = dueDate.UTC()
+	}
+
+	d, _ := time.ParseDuration(estimate)
+	return dueDate, int64(d / time.Second)
+}
+
+// UpdateParent переносит задачу под другую задачу, нулевой parentID делает её задачей верхнего уровня.
+// Перенос под собственную подзадачу отклоняется хранилищем.
+func (t *TaskService) UpdateParent(ctx context.Context, parentID, taskID int) error {
+	if err := validate(entities.Task{ID: taskID, ParentID: parentID}, taskParentRules); err != nil {
+		return err
+	}
+
+	if err := t.authorize(ctx, taskID); err != nil {
+		return err
+	}
+
+	return t.storage.UpdateParent(ctx, parentID, taskID)
+}
+
+// Transition переводит задачу в новый статус, если переход разрешён таблицей переходов.
+// При переходе в in_progress запускается таймер пользователя peopleID
+// (по умолчанию - выполняющего запрос, при внутреннем вызове - последнего работавшего над задачей
+// или первого из текущих исполнителей),
+// при переходе в done закрываются все открытые сессии по задаче.
+// Закрыть чужие сессии можно только от имени их владельцев, иначе переход в done отклоняется.
+func (t *TaskService) Transition(ctx context.Context, taskID int, status entities.TaskStatus, peopleID int) error {
+	if !t.workflow.Known(status) {
+		return fmt.Errorf("%w: %q", ErrUnknownStatus, status)
+	}
+
+	if err := t.authorize(ctx, taskID); err != nil {
+		return err
+	}
+
+	task, err := t.storage.GetByID(ctx, taskID, false)
+	if err != nil {
+		return err
+	}
+
+	if !t.workflow.CanTransition(task.Status, status) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, task.Status, status)
+	}
+
+	now := time.Now().UTC()
+
+	// Сессии проверяются до смены статуса, чтобы отказ не оставил выполненную задачу с открытыми сессиями
+	if status == entities.StatusDone {
+		if err := t.time.checkTaskEntries(ctx, taskID, now); err != nil {
+			return err
+		}
+	}
+
+	// Таймер запускается в одной транзакции со сменой статуса, чтобы отказ в запуске не оставил задачу
+	// в работе без учёта времени, а неудачная смена статуса - лишнюю сессию
+	var timer entities.TimerStart
+	if status == entities.StatusInProgress {
+		if peopleID == 0 {
+			peopleID = task.TimeEntry.PeopleID
+			if peopleID == 0 && len(task.Assignees) > 0 {
+				peopleID = task.Assignees[0].PeopleID
+			}
+			if caller, ok := CallerFromContext(ctx); ok {
+				peopleID = caller.PeopleID
+			}
+		}
+		if peopleID != 0 {
+			timer, err = t.time.prepareStart(ctx, taskID, peopleID, now)
+			if err != nil {
+				return fmt.Errorf("failed to start timer: %w", err)
+			}
+		}
+	}
+
+	if err := t.storage.UpdateStatus(ctx, taskID, task.Status, status, timer); err != nil {
+		return err
+	}
+
+	if status == entities.StatusDone {
+		if err := t.time.EndTaskTimeEntries(ctx, taskID, now); err != nil {
+			return fmt.Errorf("failed to end time entries: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Delete удаляет задачу по её ID, удалять задачи могут администратор и менеджер.
+// Задача с записями времени внутри согласованных недель не удаляется.
 func (t *TaskService) Delete(ctx context.Context, taskID int) error {
+	if err := requireRole(ctx, entities.RoleAdmin, entities.RoleManager); err != nil {
+		return err
+	}
+
+	if err := t.time.checkTaskUnlocked(ctx, taskID); err != nil {
+		return err
+	}
+
 	return t.storage.Delete(ctx, taskID)
 }
+
+// authorize разрешает изменять задачу администратору, менеджеру и текущим исполнителям задачи.
+func (t *TaskService) authorize(ctx context.Context, taskID int) error {
+	if err := requireRole(ctx, entities.RoleAdmin, entities.RoleManager); err == nil {
+		return nil
+	}
+
+	task, err := t.storage.GetByID(ctx, taskID, false)
+	if err != nil {
+		return err
+	}
+
+	caller, _ := CallerFromContext(ctx)
+	for _, assignee := range task.Assignees {
+		if assignee.PeopleID == caller.PeopleID {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: task ID %d is not assigned to people ID %d", ErrForbidden, taskID, caller.PeopleID)
+}
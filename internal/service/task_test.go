@@ -0,0 +1,185 @@
+package service
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTaskAccess(t *testing.T) {
+	tests := []struct {
+		name   string
+		action func(svc *Service, tm team, taskID int) error
+		want   error
+	}{
+		{
+			name: "admin reassigns to anyone",
+			action: func(svc *Service, tm team, taskID int) error {
+				return svc.Task.UpdatePeople(tm.admins(), tm.outsider, taskID)
+			},
+		},
+		{
+			name: "manager reassigns to team member",
+			action: func(svc *Service, tm team, taskID int) error {
+				return svc.Task.UpdatePeople(tm.managers(), tm.member, taskID)
+			},
+		},
+		{
+			name: "manager reassigns to other team",
+			action: func(svc *Service, tm team, taskID int) error {
+				return svc.Task.UpdatePeople(tm.managers(), tm.outsider, taskID)
+			},
+			want: ErrForbidden,
+		},
+		{
+			name: "member reassigns to self",
+			action: func(svc *Service, tm team, taskID int) error {
+				return svc.Task.UpdatePeople(tm.members(), tm.member, taskID)
+			},
+			want: ErrForbidden,
+		},
+		{
+			name: "assignee updates",
+			action: func(svc *Service, tm team, taskID int) error {
+				return svc.Task.Update(tm.members(), taskID, "Renamed", "")
+			},
+		},
+		{
+			name: "other member updates",
+			action: func(svc *Service, tm team, taskID int) error {
+				return svc.Task.Update(as(tm.outsider, entities.RoleMember), taskID, "Renamed", "")
+			},
+			want: ErrForbidden,
+		},
+		{
+			name: "manager updates",
+			action: func(svc *Service, tm team, taskID int) error {
+				return svc.Task.Update(tm.managers(), taskID, "Renamed", "")
+			},
+		},
+		{
+			name: "other member reschedules",
+			action: func(svc *Service, tm team, taskID int) error {
+				return svc.Task.UpdateSchedule(as(tm.outsider, entities.RoleMember), taskID, time.Time{}, "1h")
+			},
+			want: ErrForbidden,
+		},
+		{
+			name: "other member changes status",
+			action: func(svc *Service, tm team, taskID int) error {
+				return svc.Task.Transition(as(tm.outsider, entities.RoleMember), taskID, entities.StatusCancelled, 0)
+			},
+			want: ErrForbidden,
+		},
+		{
+			name: "assignee changes status",
+			action: func(svc *Service, tm team, taskID int) error {
+				return svc.Task.Transition(tm.members(), taskID, entities.StatusCancelled, 0)
+			},
+		},
+		{
+			name:   "member deletes",
+			action: func(svc *Service, tm team, taskID int) error { return svc.Task.Delete(tm.members(), taskID) },
+			want:   ErrForbidden,
+		},
+		{
+			name:   "manager deletes",
+			action: func(svc *Service, tm team, taskID int) error { return svc.Task.Delete(tm.managers(), taskID) },
+		},
+		{
+			name: "member creates with own time",
+			action: func(svc *Service, tm team, taskID int) error {
+				_, err := svc.Task.Create(tm.members(), entities.Task{Title: "New", TimeEntry: entities.TimeEntry{PeopleID: tm.member}})
+				return err
+			},
+		},
+		{
+			name: "member creates with time of other",
+			action: func(svc *Service, tm team, taskID int) error {
+				_, err := svc.Task.Create(tm.members(), entities.Task{Title: "New", TimeEntry: entities.TimeEntry{PeopleID: tm.outsider}})
+				return err
+			},
+			want: ErrForbidden,
+		},
+		{
+			name: "member creates project",
+			action: func(svc *Service, tm team, taskID int) error {
+				_, err := svc.Project.Create(tm.members(), entities.Project{Name: "Project"})
+				return err
+			},
+			want: ErrForbidden,
+		},
+		{
+			name: "manager creates project",
+			action: func(svc *Service, tm team, taskID int) error {
+				_, err := svc.Project.Create(tm.managers(), entities.Project{Name: "Project"})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, s := newTestService(t)
+			tm := newTeam(t, s)
+			taskID := createTask(t, s, entities.Task{Title: "Task"})
+			if err := s.AssigneeManage.Assign(context.Background(), taskID, tm.member, time.Now().UTC()); err != nil {
+				t.Fatalf("Assign: %v", err)
+			}
+
+			checkError(t, tt.action(svc, tm, taskID), tt.want, tt.name)
+		})
+	}
+}
+
+// racingStatus хранилище задач, в котором статус задачи меняется другим запросом
+// между чтением задачи и сменой её статуса.
+type racingStatus struct {
+	storage.TaskManage
+	status entities.TaskStatus
+}
+
+func (r racingStatus) UpdateStatus(ctx context.Context, taskID int, from, to entities.TaskStatus, timer entities.TimerStart) error {
+	if err := r.TaskManage.UpdateStatus(ctx, taskID, from, r.status, entities.TimerStart{}); err != nil {
+		return err
+	}
+	return r.TaskManage.UpdateStatus(ctx, taskID, from, to, timer)
+}
+
+func TestTransitionStatusChanged(t *testing.T) {
+	s := storage.NewMemoryStorage()
+	tm := newTeam(t, s)
+	pausedTaskID := createTask(t, s, entities.Task{Title: "Paused"})
+	taskID := createTask(t, s, entities.Task{Title: "Task"})
+	checkError(t, s.AssigneeManage.Assign(context.Background(), taskID, tm.member, time.Now().UTC()), nil, "Assign")
+
+	hourAgo := time.Now().UTC().Add(-time.Hour)
+	paused, err := s.TimeManage.StartTimeEntry(context.Background(), pausedTaskID, tm.member, hourAgo, entities.OverlapChanges{})
+	checkError(t, err, nil, "StartTimeEntry")
+	checkError(t, s.TimeManage.PauseTimeEntry(context.Background(), tm.member, hourAgo.Add(30*time.Minute)), nil, "PauseTimeEntry")
+
+	s.TaskManage = racingStatus{TaskManage: s.TaskManage, status: entities.StatusCancelled}
+	svc := NewService(s, Config{})
+
+	err = svc.Task.Transition(tm.members(), taskID, entities.StatusInProgress, 0)
+	if !errors.Is(err, domain.ErrNoRecordsFound) {
+		t.Fatalf("Transition = %v, want %v", err, domain.ErrNoRecordsFound)
+	}
+
+	// Смена статуса не удалась - новой сессии нет, приостановленный таймер не завершён
+	entries, err := s.TimeManage.ListTimeEntries(context.Background(), taskID)
+	checkError(t, err, nil, "ListTimeEntries")
+	if len(entries) != 0 {
+		t.Fatalf("time entries after failed Transition = %+v, want none", entries)
+	}
+
+	timer, err := s.TimeManage.ActiveTimeEntry(context.Background(), tm.member)
+	checkError(t, err, nil, "ActiveTimeEntry")
+	if timer.TimeEntryID != paused || !timer.Paused {
+		t.Fatalf("active timer after failed Transition = %+v, want paused entry %d", timer, paused)
+	}
+}
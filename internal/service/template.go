@@ -0,0 +1,222 @@
+package service
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TemplateService представляет сервис для работы с шаблонами задач.
+type TemplateService struct {
+	storage storage.TemplateManage
+	task    Task
+	access  *Access
+}
+
+// NewTemplateService создает новый экземпляр TemplateService.
+// Задачи по шаблону создаются через сервис задач и проходят его проверки.
+func NewTemplateService(s storage.TemplateManage, task Task, access *Access) *TemplateService {
+	return &TemplateService{storage: s, task: task, access: access}
+}
+
+// Create создает шаблон задачи. Создавать и менять шаблоны могут администратор и менеджер.
+func (t *TemplateService) Create(ctx context.Context, tpl entities.TaskTemplate) (int, error) {
+	if err := validate(tpl, templateCreateRules); err != nil {
+		return 0, err
+	}
+
+	if err := requireRole(ctx, entities.RoleAdmin, entities.RoleManager); err != nil {
+		return 0, err
+	}
+
+	_, tpl.EstimateSeconds = schedule(time.Time{}, tpl.Estimate)
+	return t.storage.Create(ctx, tpl)
+}
+
+// GetByID возвращает шаблон задачи по его ID.
+func (t *TemplateService) GetByID(ctx context.Context, templateID int) (entities.TaskTemplate, error) {
+	return t.storage.GetByID(ctx, templateID)
+}
+
+// List возвращает все шаблоны задач.
+func (t *TemplateService) List(ctx context.Context) ([]entities.TaskTemplate, error) {
+	return t.storage.List(ctx)
+}
+
+// Update заменяет данные шаблона целиком, уже созданные по нему задачи не меняются.
+func (t *TemplateService) Update(ctx context.Context, tpl entities.TaskTemplate) error {
+	if err := validate(tpl, templateUpdateRules); err != nil {
+		return err
+	}
+
+	if err := requireRole(ctx, entities.RoleAdmin, entities.RoleManager); err != nil {
+		return err
+	}
+
+	_, tpl.EstimateSeconds = schedule(time.Time{}, tpl.Estimate)
+	return t.storage.Update(ctx, tpl)
+}
+
+// Delete удаляет шаблон задачи, уже созданные по нему задачи остаются.
+func (t *TemplateService) Delete(ctx context.Context, templateID int) error {
+	if err := requireRole(ctx, entities.RoleAdmin, entities.RoleManager); err != nil {
+		return err
+	}
+
+	return t.storage.Delete(ctx, templateID)
+}
+
+// Instantiate создает задачу по шаблону и возвращает её ID.
+// Заголовок получается из шаблона подстановкой {date}, {week}, {month}, {year} на текущую дату
+// и значений params.Vars, которые имеют приоритет над встроенными. Подстановка без значения - ошибка.
+// Ненулевые проект и исполнитель из params заменяют значения шаблона, чек-лист копируется в задачу.
+// Назначить исполнителя, из params или шаблона, может администратор и менеджер - на себя или участника своей команды.
+func (t *TemplateService) Instantiate(ctx context.Context, templateID int, params entities.TemplateParams) (int, error) {
+	if err := validate(params, templateParamsRules); err != nil {
+		return 0, err
+	}
+
+	tpl, err := t.storage.GetByID(ctx, templateID)
+	if err != nil {
+		return 0, err
+	}
+
+	title, err := renderTitle(tpl.TitlePattern, templateVars(time.Now().UTC(), params.Vars))
+	if err != nil {
+		return 0, err
+	}
+
+	task := entities.Task{
+		Title:       title,
+		Description: tpl.Description,
+		ProjectID:   tpl.ProjectID,
+		ParentID:    params.ParentID,
+		Estimate:    tpl.Estimate,
+	}
+	if params.ProjectID != 0 {
+		task.ProjectID = params.ProjectID
+	}
+
+	// Исполнитель назначается так же, как при создании задачи
+	task.TimeEntry.PeopleID = tpl.AssigneeID
+	if params.AssigneeID != 0 {
+		task.TimeEntry.PeopleID = params.AssigneeID
+	}
+
+	if task.TimeEntry.PeopleID != 0 {
+		if err := requireRole(ctx, entities.RoleAdmin, entities.RoleManager); err != nil {
+			return 0, err
+		}
+		if _, err := t.access.actFor(ctx, task.TimeEntry.PeopleID); err != nil {
+			return 0, err
+		}
+	}
+
+	for _, item := range tpl.Checklist {
+		task.Checklist = append(task.Checklist, entities.ChecklistItem{Title: item})
+	}
+
+	return t.task.Create(ctx, task)
+}
+
+// templateVars возвращает значения подстановок заголовка на момент now.
+// Неделя записывается по ISO 8601, например 2026-W42.
+func templateVars(now time.Time, vars map[string]string) map[string]string {
+	year, week := now.ISOWeek()
+	values := map[string]string{
+		"date":  now.Format(time.DateOnly),
+		"week":  fmt.Sprintf("%d-W%02d", year, week),
+		"month": now.Format("2006-01"),
+		"year":  now.Format("2006"),
+	}
+
+	for name, value := range vars {
+		values[name] = value
+	}
+
+	return values
+}
+
+// renderTitle подставляет значения в заголовок шаблона.
+func renderTitle(pattern string, values map[string]string) (string, error) {
+	names, ok := placeholders(pattern)
+	if !ok {
+		return "", domain.NewFieldError("title_pattern", "is malformed")
+	}
+
+	var missing []string
+	for _, name := range names {
+		if _, ok := values[name]; !ok {
+			missing = append(missing, "{"+name+"}")
+		}
+	}
+	if len(missing) > 0 {
+		return "", domain.NewFieldError("vars", "missing values for "+strings.Join(missing, ", "))
+	}
+
+	var b strings.Builder
+	rest := pattern
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start < 0 {
+			b.WriteString(rest)
+			break
+		}
+		end := start + strings.IndexByte(rest[start:], '}')
+		b.WriteString(rest[:start])
+		b.WriteString(values[rest[start+1:end]])
+		rest = rest[end+1:]
+	}
+
+	return b.String(), nil
+}
+
+// placeholders возвращает имена подстановок заголовка без повторов.
+// false - фигурные скобки не парные или имя подстановки не из букв, цифр и подчёркиваний.
+func placeholders(pattern string) ([]string, bool) {
+	var names []string
+	seen := make(map[string]bool)
+
+	rest := pattern
+	for {
+		start := strings.IndexAny(rest, "{}")
+		if start < 0 {
+			return names, true
+		}
+		if rest[start] == '}' {
+			return nil, false
+		}
+
+		length := strings.IndexAny(rest[start+1:], "{}")
+		if length < 0 || rest[start+1+length] == '{' {
+			return nil, false
+		}
+
+		name := rest[start+1 : start+1+length]
+		if !placeholderName(name) {
+			return nil, false
+		}
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+
+		rest = rest[start+length+2:]
+	}
+}
+
+func placeholderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r != '_' && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -4,30 +4,456 @@ import (
 	"TaskSync/internal/entities"
 	"TaskSync/internal/storage"
 	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // TimeService представляет сервис для работы с данными времени задач.
 type TimeService struct {
-	storage storage.TimeManage
+	storage       storage.TimeManage
+	timesheets    storage.TimesheetManage
+	dependencies  storage.DependencyManage
+	access        *Access
+	overlapPolicy OverlapPolicy
 }
 
 // NewTimeService создает новый экземпляр TimeService.
-func NewTimeService(t storage.TimeManage) *TimeService {
-	return &TimeService{storage: t}
+// Табели используются для запрета изменения времени внутри согласованных недель,
+// зависимости - для запрета работы над заблокированными задачами.
+func NewTimeService(t storage.TimeManage, timesheets storage.TimesheetManage, dependencies storage.DependencyManage, access *Access, overlapPolicy OverlapPolicy) *TimeService {
+	return &TimeService{storage: t, timesheets: timesheets, dependencies: dependencies, access: access, overlapPolicy: overlapPolicy}
 }
 
-// StartTimeEntry начинает запись времени для задачи.
-func (t *TimeService) StartTimeEntry(ctx context.Context, taskID int, timeEntries time.Time) error {
-	return t.storage.StartTimeEntry(ctx, taskID, timeEntries)
+// StartTimeEntry открывает новую сессию работы пользователя над задачей.
+// Если peopleID не задан, используется пользователь, выполняющий запрос.
+// У пользователя может быть запущен только один таймер, приостановленный таймер при старте завершается.
+// Пересечение с уже записанным временем обрабатывается согласно политике сервиса.
+// Работа над задачей, которую блокируют незавершённые задачи, не начинается.
+func (t *TimeService) StartTimeEntry(ctx context.Context, taskID, peopleID int, startTime time.Time) (int, error) {
+	timer, err := t.prepareStart(ctx, taskID, peopleID, startTime)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.storage.StartTimeEntry(ctx, taskID, timer.PeopleID, timer.StartTime, timer.Overlaps)
+}
+
+// prepareStart проверяет, что пользователь может начать работу над задачей, и готовит запуск таймера:
+// определяет пользователя, время начала и изменения пересекающихся записей.
+func (t *TimeService) prepareStart(ctx context.Context, taskID, peopleID int, startTime time.Time) (entities.TimerStart, error) {
+	if err := validate(timeRequest{TaskID: taskID, PeopleID: peopleID}, timeEntryRules); err != nil {
+		return entities.TimerStart{}, err
+	}
+
+	peopleID, err := t.access.actFor(ctx, peopleID)
+	if err != nil {
+		return entities.TimerStart{}, err
+	}
+
+	if err := t.checkUnblocked(ctx, taskID); err != nil {
+		return entities.TimerStart{}, err
+	}
+
+	timer, err := t.storage.ActiveTimeEntry(ctx, peopleID)
+	if err != nil {
+		return entities.TimerStart{}, err
+	}
+
+	if timer.TimeEntryID != 0 && !timer.Paused {
+		return entities.TimerStart{}, fmt.Errorf("%w on task ID %d", ErrTimerRunning, timer.TaskID)
+	}
+
+	startTime = orNow(startTime)
+
+	// Сессия, начатая в прошлом, сразу занимает время до текущего момента
+	if err := t.checkUnlocked(ctx, peopleID, startTime, time.Time{}); err != nil {
+		return entities.TimerStart{}, err
+	}
+
+	overlaps, err := t.resolveOverlaps(ctx, peopleID, startTime, time.Time{})
+	if err != nil {
+		return entities.TimerStart{}, err
+	}
+
+	return entities.TimerStart{PeopleID: peopleID, StartTime: startTime, Overlaps: overlaps}, nil
+}
+
+// EndTimeEntry завершает открытую сессию пользователя по задаче.
+// Время завершения не может быть раньше начала запущенного отрезка сессии.
+func (t *TimeService) EndTimeEntry(ctx context.Context, taskID, peopleID int, endTime time.Time) error {
+	if err := validate(timeRequest{TaskID: taskID, PeopleID: peopleID}, timeEntryRules); err != nil {
+		return err
+	}
+
+	peopleID, err := t.access.actFor(ctx, peopleID)
+	if err != nil {
+		return err
+	}
+
+	endTime = orNow(endTime)
+
+	timer, err := t.storage.ActiveTimeEntry(ctx, peopleID)
+	if err != nil {
+		return err
+	}
+
+	// Завершение меняет весь запущенный отрезок, у приостановленной сессии время не меняется
+	lockStart := endTime
+	if timer.TaskID == taskID && !timer.Paused {
+		if err := validate(timeRequest{Start: timer.SegmentStart, End: endTime}, segmentEndRules); err != nil {
+			return err
+		}
+		lockStart = timer.SegmentStart
+	}
+
+	if err := t.checkUnlocked(ctx, peopleID, lockStart, endTime); err != nil {
+		return err
+	}
+
+	return t.storage.EndTimeEntry(ctx, taskID, peopleID, endTime)
+}
+
+// EndTaskTimeEntries закрывает все открытые сессии по задаче.
+// Чужие сессии закрываются только от имени их владельцев, отрезки, задевающие согласованные недели, не закрываются.
+func (t *TimeService) EndTaskTimeEntries(ctx context.Context, taskID int, endTime time.Time) error {
+	endTime = orNow(endTime)
+
+	if err := t.checkTaskEntries(ctx, taskID, endTime); err != nil {
+		return err
+	}
+
+	return t.storage.EndTaskTimeEntries(ctx, taskID, endTime)
+}
+
+// PauseTimeEntry приостанавливает запущенный таймер пользователя.
+func (t *TimeService) PauseTimeEntry(ctx context.Context, peopleID int, pauseTime time.Time) error {
+	if err := validate(timeRequest{PeopleID: peopleID}, timerRules); err != nil {
+		return err
+	}
+
+	peopleID, err := t.access.actFor(ctx, peopleID)
+	if err != nil {
+		return err
+	}
+
+	timer, err := t.storage.ActiveTimeEntry(ctx, peopleID)
+	if err != nil {
+		return err
+	}
+
+	if timer.TimeEntryID == 0 || timer.Paused {
+		return ErrNoActiveTimer
+	}
+
+	pauseTime = orNow(pauseTime)
+
+	if err := validate(timeRequest{Start: timer.SegmentStart, End: pauseTime}, segmentEndRules); err != nil {
+		return err
+	}
+
+	if err := t.checkUnlocked(ctx, peopleID, timer.SegmentStart, pauseTime); err != nil {
+		return err
+	}
+
+	return t.storage.PauseTimeEntry(ctx, peopleID, pauseTime)
+}
+
+// ResumeTimeEntry продолжает приостановленный таймер пользователя новым отрезком сессии.
+func (t *TimeService) ResumeTimeEntry(ctx context.Context, peopleID int, resumeTime time.Time) (int, error) {
+	if err := validate(timeRequest{PeopleID: peopleID}, timerRules); err != nil {
+		return 0, err
+	}
+
+	peopleID, err := t.access.actFor(ctx, peopleID)
+	if err != nil {
+		return 0, err
+	}
+
+	timer, err := t.storage.ActiveTimeEntry(ctx, peopleID)
+	if err != nil {
+		return 0, err
+	}
+
+	if timer.TimeEntryID == 0 {
+		return 0, ErrNoActiveTimer
+	}
+
+	if !timer.Paused {
+		return 0, ErrTimerNotPaused
+	}
+
+	resumeTime = orNow(resumeTime)
+
+	if err := t.checkUnlocked(ctx, peopleID, resumeTime, time.Time{}); err != nil {
+		return 0, err
+	}
+
+	overlaps, err := t.resolveOverlaps(ctx, peopleID, resumeTime, time.Time{})
+	if err != nil {
+		return 0, err
+	}
+
+	return t.storage.ResumeTimeEntry(ctx, peopleID, resumeTime, overlaps)
+}
+
+// ActiveTimer возвращает текущий таймер пользователя и общее время сессии с учётом пауз.
+func (t *TimeService) ActiveTimer(ctx context.Context, peopleID int) (entities.ActiveTimer, error) {
+	peopleID, err := t.access.actFor(ctx, peopleID)
+	if err != nil {
+		return entities.ActiveTimer{}, err
+	}
+
+	timer, err := t.storage.ActiveTimeEntry(ctx, peopleID)
+	if err != nil {
+		return timer, err
+	}
+
+	if timer.TimeEntryID == 0 {
+		return timer, ErrNoActiveTimer
+	}
+
+	// Хранилище учитывает только закрытые отрезки, время текущего отрезка добавляется здесь
+	elapsed := time.Duration(timer.ElapsedSeconds) * time.Second
+	if !timer.Paused {
+		elapsed += time.Since(timer.SegmentStart).Truncate(time.Second)
+	}
+
+	timer.ElapsedSeconds = int64(elapsed / time.Second)
+	timer.Elapsed = elapsed.String()
+
+	return timer, nil
+}
+
+// ListTimeEntries возвращает сессии работы над задачей.
+// Администратор видит все сессии, остальные - только тех, от чьего имени могут действовать.
+func (t *TimeService) ListTimeEntries(ctx context.Context, taskID int) ([]entities.TimeEntry, error) {
+	entries, err := t.storage.ListTimeEntries(ctx, taskID)
+	if err != nil || isAdmin(ctx) {
+		return entries, err
+	}
+
+	visible := make([]entities.TimeEntry, 0, len(entries))
+	for _, entry := range entries {
+		// Записи удалённых пользователей видит только администратор
+		if entry.PeopleID == 0 {
+			continue
+		}
+		if _, err := t.access.actFor(ctx, entry.PeopleID); err != nil {
+			if errors.Is(err, ErrForbidden) {
+				continue
+			}
+			return nil, err
+		}
+		visible = append(visible, entry)
+	}
+
+	return visible, nil
+}
+
+// checkTaskUnlocked запрещает удалять задачу, записи времени которой задевают согласованные недели.
+func (t *TimeService) checkTaskUnlocked(ctx context.Context, taskID int) error {
+	entries, err := t.storage.ListTimeEntries(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	return t.checkEntriesUnlocked(ctx, entries)
+}
+
+// checkTaskEntries проверяет, что открытые и приостановленные сессии по задаче можно закрыть в endTime
+// от имени пользователя, выполняющего запрос, и что запущенные отрезки не задевают согласованные недели.
+func (t *TimeService) checkTaskEntries(ctx context.Context, taskID int, endTime time.Time) error {
+	entries, err := t.storage.ListTimeEntries(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	checked := make(map[int]bool)
+	for _, entry := range entries {
+		if entry.StartTime.IsZero() || entry.PeopleID == 0 || checked[entry.PeopleID] {
+			continue
+		}
+		checked[entry.PeopleID] = true
+
+		timer, err := t.storage.ActiveTimeEntry(ctx, entry.PeopleID)
+		if err != nil {
+			return err
+		}
+
+		if timer.TimeEntryID == 0 || timer.TaskID != taskID {
+			continue
+		}
+
+		if _, err := t.access.actFor(ctx, entry.PeopleID); err != nil {
+			return err
+		}
+
+		if !timer.Paused {
+			if err := t.checkUnlocked(ctx, entry.PeopleID, timer.SegmentStart, endTime); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// taskEntryOverlaps проверяет запись о времени, создаваемую вместе с задачей, так же, как запуск таймера:
+// время не должно попадать в согласованную неделю, пересечения обрабатываются согласно политике сервиса.
+// Запись без времени начала только назначает исполнителя и не проверяется.
+func (t *TimeService) taskEntryOverlaps(ctx context.Context, entry entities.TimeEntry) (entities.OverlapChanges, error) {
+	if entry.PeopleID == 0 || entry.StartTime.IsZero() {
+		return entities.OverlapChanges{}, nil
+	}
+
+	if err := t.checkUnlocked(ctx, entry.PeopleID, entry.StartTime, entry.EndTime); err != nil {
+		return entities.OverlapChanges{}, err
+	}
+
+	return t.resolveOverlaps(ctx, entry.PeopleID, entry.StartTime, entry.EndTime)
+}
+
+// checkUnlocked запрещает изменять время пользователя, если период [start, end] задевает согласованную неделю.
+// Нулевой end означает открытую сессию, она проверяется до текущего момента.
+func (t *TimeService) checkUnlocked(ctx context.Context, peopleID int, start, end time.Time) error {
+	if end.IsZero() {
+		end = time.Now().UTC()
+	}
+	if end.Before(start) {
+		end = start
+	}
+
+	week, err := t.timesheets.ApprovedWeek(ctx, peopleID, start, end)
+	if err != nil {
+		return err
+	}
+
+	if !week.IsZero() {
+		return fmt.Errorf("%w: week %s", ErrPeriodLocked, formatWeek(week))
+	}
+
+	return nil
+}
+
+// checkEntriesUnlocked запрещает изменять и удалять записи, задевающие согласованные недели их пользователей.
+func (t *TimeService) checkEntriesUnlocked(ctx context.Context, entries []entities.TimeEntry) error {
+	for _, entry := range entries {
+		if entry.StartTime.IsZero() || entry.PeopleID == 0 {
+			continue
+		}
+		if err := t.checkUnlocked(ctx, entry.PeopleID, entry.StartTime, entry.EndTime); err != nil {
+			return fmt.Errorf("time entry ID %d: %w", entry.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// checkUnblocked запрещает начинать работу над задачей, пока не завершены блокирующие её задачи.
+func (t *TimeService) checkUnblocked(ctx context.Context, taskID int) error {
+	blockers, err := t.dependencies.Blockers(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	var unfinished []string
+	for _, blocker := range blockers {
+		if !blocker.Finished() {
+			unfinished = append(unfinished, strconv.Itoa(blocker.ID))
+		}
+	}
+
+	if len(unfinished) > 0 {
+		return fmt.Errorf("%w: task ID %d is blocked by tasks %s", ErrTaskBlocked, taskID, strings.Join(unfinished, ", "))
+	}
+
+	return nil
 }
 
-// EndTimeEntry завершает запись времени для задачи.
-func (t *TimeService) EndTimeEntry(ctx context.Context, taskID int, endTime time.Time) error {
-	return t.storage.EndTimeEntry(ctx, taskID, endTime)
+// orNow подставляет текущее время в UTC, если время не задано в запросе.
+func orNow(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Now().UTC()
+	}
+	return t
 }
 
 // GetTaskTimeSpent возвращает трудозатраты по пользователю за заданный период.
-func (t *TimeService) TasksTimeSpent(ctx context.Context, peopleID int, startTime, endTime time.Time) ([]entities.TaskTimeSpent, error) {
-	return t.storage.TasksTimeSpent(ctx, peopleID, startTime, endTime)
+// Участник видит только свои трудозатраты, менеджер - также трудозатраты своей команды,
+// администратор без указания пользователя получает трудозатраты всех пользователей.
+func (t *TimeService) TasksTimeSpent(ctx context.Context, peopleID, projectID int, startTime, endTime time.Time) ([]entities.TaskTimeSpent, error) {
+	request := timeRequest{PeopleID: peopleID, ProjectID: projectID, Start: startTime, End: endTime}
+	if err := validate(request, timeRangeRules); err != nil {
+		return nil, err
+	}
+
+	if peopleID != 0 || !isAdmin(ctx) {
+		var err error
+		if peopleID, err = t.access.actFor(ctx, peopleID); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.storage.TasksTimeSpent(ctx, peopleID, projectID, startTime, endTime)
+}
+
+// ProjectsTimeSpent возвращает трудозатраты по проектам за заданный период.
+// Сводку по всем пользователям получает только администратор, остальным по умолчанию выводятся свои трудозатраты.
+func (t *TimeService) ProjectsTimeSpent(ctx context.Context, peopleID int, startTime, endTime time.Time) ([]entities.ProjectTimeSpent, error) {
+	if err := validate(timeRequest{PeopleID: peopleID, Start: startTime, End: endTime}, timeRangeRules); err != nil {
+		return nil, err
+	}
+
+	if peopleID != 0 || !isAdmin(ctx) {
+		var err error
+		if peopleID, err = t.access.actFor(ctx, peopleID); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.storage.ProjectsTimeSpent(ctx, peopleID, startTime, endTime)
+}
+
+// TagsTimeSpent возвращает трудозатраты по меткам задач за заданный период, с фильтром по проекту.
+// Сводку по всем пользователям получает только администратор, остальным по умолчанию выводятся свои трудозатраты.
+func (t *TimeService) TagsTimeSpent(ctx context.Context, peopleID, projectID int, startTime, endTime time.Time) ([]entities.TagTimeSpent, error) {
+	request := timeRequest{PeopleID: peopleID, ProjectID: projectID, Start: startTime, End: endTime}
+	if err := validate(request, timeRangeRules); err != nil {
+		return nil, err
+	}
+
+	if peopleID != 0 || !isAdmin(ctx) {
+		var err error
+		if peopleID, err = t.access.actFor(ctx, peopleID); err != nil {
+			return nil, err
+		}
+	}
+
+	return t.storage.TagsTimeSpent(ctx, peopleID, projectID, startTime, endTime)
+}
+
+// Estimates сравнивает оценки задач с затраченным временем и отмечает задачи с превышением оценки и прошедшим сроком.
+// Отчёт по всем задачам получает только администратор, остальным по умолчанию выводятся задачи, над которыми они работали
+// или на которые назначены.
+func (t *TimeService) Estimates(ctx context.Context, peopleID, projectID int) (entities.EstimateReport, error) {
+	if err := validate(timeRequest{PeopleID: peopleID, ProjectID: projectID}, estimateReportRules); err != nil {
+		return entities.EstimateReport{}, err
+	}
+
+	if peopleID != 0 || !isAdmin(ctx) {
+		var err error
+		if peopleID, err = t.access.actFor(ctx, peopleID); err != nil {
+			return entities.EstimateReport{}, err
+		}
+	}
+
+	estimates, err := t.storage.TaskEstimates(ctx, peopleID, projectID)
+	if err != nil {
+		return entities.EstimateReport{}, err
+	}
+
+	return entities.NewEstimateReport(estimates, time.Now().UTC()), nil
 }
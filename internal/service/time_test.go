@@ -0,0 +1,169 @@
+package service
+
+import (
+	"TaskSync/internal/entities"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTimeAccess(t *testing.T) {
+	hourAgo := time.Now().UTC().Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		action func(svc *Service, tm team, taskID int) error
+		want   error
+	}{
+		{
+			name: "member starts own",
+			action: func(svc *Service, tm team, taskID int) error {
+				_, err := svc.Time.StartTimeEntry(tm.members(), taskID, 0, hourAgo)
+				return err
+			},
+		},
+		{
+			name: "member starts for other",
+			action: func(svc *Service, tm team, taskID int) error {
+				_, err := svc.Time.StartTimeEntry(tm.members(), taskID, tm.outsider, hourAgo)
+				return err
+			},
+			want: ErrForbidden,
+		},
+		{
+			name: "manager starts for team member",
+			action: func(svc *Service, tm team, taskID int) error {
+				_, err := svc.Time.StartTimeEntry(tm.managers(), taskID, tm.member, hourAgo)
+				return err
+			},
+		},
+		{
+			name: "manager starts for other team",
+			action: func(svc *Service, tm team, taskID int) error {
+				_, err := svc.Time.StartTimeEntry(tm.managers(), taskID, tm.outsider, hourAgo)
+				return err
+			},
+			want: ErrForbidden,
+		},
+		{
+			name: "admin starts for anyone",
+			action: func(svc *Service, tm team, taskID int) error {
+				_, err := svc.Time.StartTimeEntry(tm.admins(), taskID, tm.outsider, hourAgo)
+				return err
+			},
+		},
+		{
+			name: "member ends own",
+			action: func(svc *Service, tm team, taskID int) error {
+				if _, err := svc.Time.StartTimeEntry(tm.members(), taskID, 0, hourAgo); err != nil {
+					return err
+				}
+				return svc.Time.EndTimeEntry(tm.members(), taskID, 0, time.Time{})
+			},
+		},
+		{
+			name: "member ends other",
+			action: func(svc *Service, tm team, taskID int) error {
+				if _, err := svc.Time.StartTimeEntry(tm.admins(), taskID, tm.outsider, hourAgo); err != nil {
+					return err
+				}
+				return svc.Time.EndTimeEntry(tm.members(), taskID, tm.outsider, time.Time{})
+			},
+			want: ErrForbidden,
+		},
+		{
+			name: "member pauses other",
+			action: func(svc *Service, tm team, taskID int) error {
+				return svc.Time.PauseTimeEntry(tm.members(), tm.outsider, time.Time{})
+			},
+			want: ErrForbidden,
+		},
+		{
+			name: "member reads own time spent",
+			action: func(svc *Service, tm team, taskID int) error {
+				_, err := svc.Time.TasksTimeSpent(tm.members(), 0, 0, hourAgo.AddDate(0, 0, -7), hourAgo)
+				return err
+			},
+		},
+		{
+			name: "member reads time spent of other",
+			action: func(svc *Service, tm team, taskID int) error {
+				_, err := svc.Time.TasksTimeSpent(tm.members(), tm.outsider, 0, hourAgo.AddDate(0, 0, -7), hourAgo)
+				return err
+			},
+			want: ErrForbidden,
+		},
+		{
+			name: "manager reads team time spent",
+			action: func(svc *Service, tm team, taskID int) error {
+				_, err := svc.Time.TasksTimeSpent(tm.managers(), tm.member, 0, hourAgo.AddDate(0, 0, -7), hourAgo)
+				return err
+			},
+		},
+		{
+			name: "manager reads other team time spent",
+			action: func(svc *Service, tm team, taskID int) error {
+				_, err := svc.Time.TasksTimeSpent(tm.managers(), tm.outsider, 0, hourAgo.AddDate(0, 0, -7), hourAgo)
+				return err
+			},
+			want: ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, s := newTestService(t)
+			tm := newTeam(t, s)
+			taskID := createTask(t, s, entities.Task{Title: "Task"})
+
+			checkError(t, tt.action(svc, tm, taskID), tt.want, tt.name)
+		})
+	}
+}
+
+func TestListTimeEntriesVisibility(t *testing.T) {
+	svc, s := newTestService(t)
+	tm := newTeam(t, s)
+	taskID := createTask(t, s, entities.Task{Title: "Task"})
+
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	for i, peopleID := range []int{tm.member, tm.outsider, tm.manager} {
+		from := start.Add(time.Duration(i) * time.Hour)
+		if _, err := s.TimeManage.StartTimeEntry(context.Background(), taskID, peopleID, from, entities.OverlapChanges{}); err != nil {
+			t.Fatalf("StartTimeEntry: %v", err)
+		}
+		if err := s.TimeManage.EndTimeEntry(context.Background(), taskID, peopleID, from.Add(30*time.Minute)); err != nil {
+			t.Fatalf("EndTimeEntry: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want []int
+	}{
+		{"admin", tm.admins(), []int{tm.member, tm.outsider, tm.manager}},
+		{"manager", tm.managers(), []int{tm.member, tm.manager}},
+		{"member", tm.members(), []int{tm.member}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := svc.Time.ListTimeEntries(tt.ctx, taskID)
+			checkError(t, err, nil, "ListTimeEntries")
+
+			got := make([]int, 0, len(entries))
+			for _, e := range entries {
+				got = append(got, e.PeopleID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ListTimeEntries people = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("ListTimeEntries people = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
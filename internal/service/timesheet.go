@@ -0,0 +1,273 @@
+package service
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+const daysInWeek = 7
+
+// TimesheetService представляет сервис недельных табелей.
+type TimesheetService struct {
+	storage storage.TimesheetManage
+	time    storage.TimeManage
+	access  *Access
+}
+
+// NewTimesheetService создает новый экземпляр TimesheetService.
+// Таймеры используются, чтобы не отправлять и не согласовывать неделю, время которой ещё идёт.
+func NewTimesheetService(s storage.TimesheetManage, t storage.TimeManage, access *Access) *TimesheetService {
+	return &TimesheetService{storage: s, time: t, access: access}
+}
+
+// Get возвращает табель пользователя за неделю: часы по задачам за каждый день.
+// Если неделя не задана, используется текущая, если пользователь не задан - выполняющий запрос.
+func (t *TimesheetService) Get(ctx context.Context, peopleID int, week string) (entities.Timesheet, error) {
+	peopleID, err := t.access.actFor(ctx, peopleID)
+	if err != nil {
+		return entities.Timesheet{}, err
+	}
+
+	weekStart, err := ParseWeek(week)
+	if err != nil {
+		return entities.Timesheet{}, err
+	}
+
+	sheet, err := t.storage.GetTimesheet(ctx, peopleID, weekStart)
+	if err != nil {
+		return sheet, err
+	}
+
+	weekEnd := weekStart.AddDate(0, 0, daysInWeek)
+	segments, err := t.storage.TimeSegments(ctx, peopleID, weekStart, weekEnd, time.Now().UTC())
+	if err != nil {
+		return sheet, err
+	}
+
+	fillTimesheet(&sheet, weekStart, segments)
+
+	return sheet, nil
+}
+
+// Submit отправляет табель на согласование, отправить можно черновик или отклонённый табель.
+func (t *TimesheetService) Submit(ctx context.Context, peopleID int, week string) error {
+	peopleID, err := t.access.actFor(ctx, peopleID)
+	if err != nil {
+		return err
+	}
+
+	weekStart, err := ParseWeek(week)
+	if err != nil {
+		return err
+	}
+
+	sheet, err := t.storage.GetTimesheet(ctx, peopleID, weekStart)
+	if err != nil {
+		return err
+	}
+
+	if sheet.Status != entities.TimesheetDraft && sheet.Status != entities.TimesheetRejected {
+		return fmt.Errorf("%w: timesheet is %s", ErrTimesheetState, sheet.Status)
+	}
+
+	if err := t.checkClosed(ctx, peopleID, weekStart); err != nil {
+		return err
+	}
+
+	return t.storage.SubmitTimesheet(ctx, peopleID, weekStart, time.Now().UTC())
+}
+
+// Approve согласует отправленный табель, после чего записи времени за неделю не изменяются.
+func (t *TimesheetService) Approve(ctx context.Context, peopleID int, week, comment string) error {
+	return t.review(ctx, peopleID, week, entities.TimesheetApproved, comment)
+}
+
+// Reject отклоняет отправленный табель с комментарием, пользователь может отправить его повторно.
+func (t *TimesheetService) Reject(ctx context.Context, peopleID int, week, comment string) error {
+	return t.review(ctx, peopleID, week, entities.TimesheetRejected, comment)
+}
+
+// review меняет состояние отправленного табеля. Согласует менеджер пользователя или администратор,
+// менеджер не может согласовать свой табель.
+func (t *TimesheetService) review(ctx context.Context, peopleID int, week string, status entities.TimesheetStatus, comment string) error {
+	if err := requireRole(ctx, entities.RoleAdmin, entities.RoleManager); err != nil {
+		return err
+	}
+
+	// Свой табель проверяется по уже подставленному ID: нулевой peopleID означает выполняющего запрос
+	peopleID, err := t.access.actFor(ctx, peopleID)
+	if err != nil {
+		return err
+	}
+
+	reviewerID := 0
+	if caller, ok := CallerFromContext(ctx); ok {
+		if caller.PeopleID == peopleID && caller.Role != entities.RoleAdmin {
+			return fmt.Errorf("%w: cannot review own timesheet", ErrForbidden)
+		}
+		reviewerID = caller.PeopleID
+	}
+
+	weekStart, err := ParseWeek(week)
+	if err != nil {
+		return err
+	}
+
+	sheet, err := t.storage.GetTimesheet(ctx, peopleID, weekStart)
+	if err != nil {
+		return err
+	}
+
+	if sheet.Status != entities.TimesheetSubmitted {
+		return fmt.Errorf("%w: timesheet is %s", ErrTimesheetState, sheet.Status)
+	}
+
+	if status == entities.TimesheetApproved {
+		if err := t.checkClosed(ctx, peopleID, weekStart); err != nil {
+			return err
+		}
+	}
+
+	return t.storage.ReviewTimesheet(ctx, peopleID, weekStart, status, comment, reviewerID, time.Now().UTC())
+}
+
+// checkClosed запрещает отправлять и согласовывать неделю, которая ещё не закончилась
+// или в которой у пользователя запущен таймер.
+func (t *TimesheetService) checkClosed(ctx context.Context, peopleID int, weekStart time.Time) error {
+	weekEnd := weekStart.AddDate(0, 0, daysInWeek)
+	if weekEnd.After(time.Now().UTC()) {
+		return fmt.Errorf("%w: week %s is not over", ErrTimesheetState, formatWeek(weekStart))
+	}
+
+	timer, err := t.time.ActiveTimeEntry(ctx, peopleID)
+	if err != nil {
+		return err
+	}
+
+	if timer.TimeEntryID != 0 && !timer.Paused && timer.SegmentStart.Before(weekEnd) {
+		return fmt.Errorf("%w: timer on task ID %d is running since %s", ErrTimesheetState, timer.TaskID, timer.SegmentStart.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// ParseWeek возвращает начало недели (понедельник 00:00 UTC) для недели в формате ISO 8601, например 2026-W42.
+// Пустая строка означает текущую неделю.
+func ParseWeek(week string) (time.Time, error) {
+	if week == "" {
+		return weekStart(time.Now().UTC()), nil
+	}
+
+	if len(week) != len("2006-W01") || week[4:6] != "-W" || !isDigits(week[:4]) || !isDigits(week[6:]) {
+		return time.Time{}, fmt.Errorf("%w: %q, expected format 2026-W42", ErrInvalidWeek, week)
+	}
+
+	year, _ := strconv.Atoi(week[:4])
+	num, _ := strconv.Atoi(week[6:])
+
+	// 4 января всегда приходится на первую неделю года
+	start := weekStart(time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)).AddDate(0, 0, (num-1)*daysInWeek)
+
+	if y, w := start.ISOWeek(); y != year || w != num {
+		return time.Time{}, fmt.Errorf("%w: %q, year %d has no such week", ErrInvalidWeek, week, year)
+	}
+
+	return start, nil
+}
+
+// isDigits сообщает, состоит ли строка только из цифр.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// formatWeek возвращает неделю в формате ISO 8601.
+func formatWeek(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+// weekStart возвращает понедельник недели, в которую попадает t.
+func weekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % daysInWeek // понедельник - 0
+	return day.AddDate(0, 0, -offset)
+}
+
+// fillTimesheet раскладывает отрезки работы по задачам и дням недели.
+// Отрезок, переходящий через полночь, делится между днями.
+func fillTimesheet(sheet *entities.Timesheet, start time.Time, segments []entities.TaskTimeSegment) {
+	end := start.AddDate(0, 0, daysInWeek)
+
+	sheet.Week = formatWeek(start)
+	sheet.WeekStart = start
+	sheet.Days = make([]string, daysInWeek)
+	for i := range sheet.Days {
+		sheet.Days[i] = start.AddDate(0, 0, i).Format(time.DateOnly)
+	}
+
+	seconds := make(map[int][]float64)
+	var order []int
+
+	for _, s := range segments {
+		from, to := s.StartTime.UTC(), s.EndTime.UTC()
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+
+		row, ok := seconds[s.TaskID]
+		if !ok {
+			row = make([]float64, daysInWeek)
+			seconds[s.TaskID] = row
+			order = append(order, s.TaskID)
+			sheet.Rows = append(sheet.Rows, entities.TimesheetRow{TaskID: s.TaskID, TaskTitle: s.TaskTitle})
+		}
+
+		for from.Before(to) {
+			day := int(from.Sub(start) / (24 * time.Hour))
+			next := start.AddDate(0, 0, day+1)
+			if next.After(to) {
+				next = to
+			}
+			row[day] += next.Sub(from).Seconds()
+			from = next
+		}
+	}
+
+	sheet.DayTotals = make([]float64, daysInWeek)
+	for i, taskID := range order {
+		row := &sheet.Rows[i]
+		row.Hours = make([]float64, daysInWeek)
+		var total float64
+		for day, sec := range seconds[taskID] {
+			row.Hours[day] = hours(sec)
+			sheet.DayTotals[day] += sec
+			total += sec
+		}
+		row.TotalHours = hours(total)
+	}
+
+	var total float64
+	for day, sec := range sheet.DayTotals {
+		sheet.DayTotals[day] = hours(sec)
+		total += sec
+	}
+	sheet.TotalHours = hours(total)
+}
+
+// hours переводит секунды в часы с точностью до сотых.
+func hours(seconds float64) float64 {
+	return math.Round(seconds/36) / 100
+}
@@ -0,0 +1,316 @@
+package service
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"testing"
+	"time"
+)
+
+// pastWeek прошедшая неделя, которую можно отправлять и согласовывать.
+const pastWeek = "2026-W02"
+
+func TestTimesheetReviewOwn(t *testing.T) {
+	tests := []struct {
+		name     string
+		reviewer func(team) int
+		role     entities.Role
+		peopleID func(team) int
+		want     error
+	}{
+		{
+			name:     "manager without people ID",
+			reviewer: func(tm team) int { return tm.manager }, role: entities.RoleManager,
+			peopleID: func(team) int { return 0 },
+			want:     ErrForbidden,
+		},
+		{
+			name:     "manager own ID",
+			reviewer: func(tm team) int { return tm.manager }, role: entities.RoleManager,
+			peopleID: func(tm team) int { return tm.manager },
+			want:     ErrForbidden,
+		},
+		{
+			name:     "manager team member",
+			reviewer: func(tm team) int { return tm.manager }, role: entities.RoleManager,
+			peopleID: func(tm team) int { return tm.member },
+		},
+		{
+			name:     "manager other team",
+			reviewer: func(tm team) int { return tm.manager }, role: entities.RoleManager,
+			peopleID: func(tm team) int { return tm.outsider },
+			want:     ErrForbidden,
+		},
+		{
+			name:     "admin without people ID",
+			reviewer: func(tm team) int { return tm.admin }, role: entities.RoleAdmin,
+			peopleID: func(team) int { return 0 },
+		},
+		{
+			name:     "member",
+			reviewer: func(tm team) int { return tm.member }, role: entities.RoleMember,
+			peopleID: func(tm team) int { return tm.member },
+			want:     ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, s := newTestService(t)
+			tm := newTeam(t, s)
+
+			// Каждый отправил свой табель, чтобы отказ не объяснялся состоянием табеля
+			for _, id := range []int{tm.admin, tm.manager, tm.member, tm.outsider} {
+				checkError(t, svc.Timesheet.Submit(as(id, entities.RoleMember), id, pastWeek), nil, "Submit")
+			}
+
+			reviewerID := tt.reviewer(tm)
+			ctx := as(reviewerID, tt.role)
+			checkError(t, svc.Timesheet.Approve(ctx, tt.peopleID(tm), pastWeek, ""), tt.want, "Approve")
+
+			peopleID := tt.peopleID(tm)
+			if peopleID == 0 {
+				peopleID = reviewerID
+			}
+			sheet, err := svc.Timesheet.Get(tm.admins(), peopleID, pastWeek)
+			checkError(t, err, nil, "Get")
+
+			want := entities.TimesheetApproved
+			if tt.want != nil {
+				want = entities.TimesheetSubmitted
+			}
+			if sheet.Status != want {
+				t.Fatalf("Status = %s, want %s", sheet.Status, want)
+			}
+		})
+	}
+}
+
+func TestTimesheetWorkflow(t *testing.T) {
+	svc, s := newTestService(t)
+	tm := newTeam(t, s)
+
+	steps := []struct {
+		name   string
+		action func() error
+		want   error
+		status entities.TimesheetStatus
+	}{
+		{"approve draft", func() error { return svc.Timesheet.Approve(tm.managers(), tm.member, pastWeek, "") }, ErrTimesheetState, entities.TimesheetDraft},
+		{"submit draft", func() error { return svc.Timesheet.Submit(tm.members(), 0, pastWeek) }, nil, entities.TimesheetSubmitted},
+		{"submit twice", func() error { return svc.Timesheet.Submit(tm.members(), 0, pastWeek) }, ErrTimesheetState, entities.TimesheetSubmitted},
+		{"reject", func() error { return svc.Timesheet.Reject(tm.managers(), tm.member, pastWeek, "missing Friday") }, nil, entities.TimesheetRejected},
+		{"reject rejected", func() error { return svc.Timesheet.Reject(tm.managers(), tm.member, pastWeek, "") }, ErrTimesheetState, entities.TimesheetRejected},
+		{"submit rejected", func() error { return svc.Timesheet.Submit(tm.members(), 0, pastWeek) }, nil, entities.TimesheetSubmitted},
+		{"approve", func() error { return svc.Timesheet.Approve(tm.managers(), tm.member, pastWeek, "") }, nil, entities.TimesheetApproved},
+		{"approve approved", func() error { return svc.Timesheet.Approve(tm.managers(), tm.member, pastWeek, "") }, ErrTimesheetState, entities.TimesheetApproved},
+		{"submit approved", func() error { return svc.Timesheet.Submit(tm.members(), 0, pastWeek) }, ErrTimesheetState, entities.TimesheetApproved},
+		{"reject approved", func() error { return svc.Timesheet.Reject(tm.managers(), tm.member, pastWeek, "") }, ErrTimesheetState, entities.TimesheetApproved},
+	}
+
+	for _, step := range steps {
+		checkError(t, step.action(), step.want, step.name)
+
+		sheet, err := svc.Timesheet.Get(tm.members(), 0, pastWeek)
+		checkError(t, err, nil, "Get after "+step.name)
+		if sheet.Status != step.status {
+			t.Fatalf("status after %s = %s, want %s", step.name, sheet.Status, step.status)
+		}
+	}
+
+	sheet, err := svc.Timesheet.Get(tm.members(), 0, pastWeek)
+	checkError(t, err, nil, "Get")
+	if sheet.ReviewedBy != tm.manager || sheet.Comment != "" {
+		t.Fatalf("Timesheet = %+v, want reviewed by people ID %d", sheet, tm.manager)
+	}
+}
+
+func TestTimesheetCheckClosed(t *testing.T) {
+	weekStart := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		week  string
+		timer func(t *testing.T, s *storage.Storage, taskID, peopleID int)
+		want  error
+	}{
+		{name: "past week", week: pastWeek},
+		{name: "current week", week: "", want: ErrTimesheetState},
+		{
+			name: "timer running since the week",
+			week: pastWeek,
+			timer: func(t *testing.T, s *storage.Storage, taskID, peopleID int) {
+				startTimer(t, s, taskID, peopleID, weekStart.Add(10*time.Hour))
+			},
+			want: ErrTimesheetState,
+		},
+		{
+			name: "timer started after the week",
+			week: pastWeek,
+			timer: func(t *testing.T, s *storage.Storage, taskID, peopleID int) {
+				startTimer(t, s, taskID, peopleID, weekStart.AddDate(0, 0, 7).Add(time.Hour))
+			},
+		},
+		{
+			name: "paused timer",
+			week: pastWeek,
+			timer: func(t *testing.T, s *storage.Storage, taskID, peopleID int) {
+				startTimer(t, s, taskID, peopleID, weekStart.Add(10*time.Hour))
+				if err := s.TimeManage.PauseTimeEntry(context.Background(), peopleID, weekStart.Add(11*time.Hour)); err != nil {
+					t.Fatalf("PauseTimeEntry: %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, s := newTestService(t)
+			tm := newTeam(t, s)
+			taskID := createTask(t, s, entities.Task{Title: "Task"})
+
+			if tt.timer != nil {
+				tt.timer(t, s, taskID, tm.member)
+			}
+
+			checkError(t, svc.Timesheet.Submit(tm.members(), 0, tt.week), tt.want, "Submit")
+		})
+	}
+
+	// Таймер, запущенный после отправки, не даёт согласовать неделю
+	svc, s := newTestService(t)
+	tm := newTeam(t, s)
+	taskID := createTask(t, s, entities.Task{Title: "Task"})
+
+	checkError(t, svc.Timesheet.Submit(tm.members(), 0, pastWeek), nil, "Submit")
+	startTimer(t, s, taskID, tm.member, weekStart.Add(10*time.Hour))
+	checkError(t, svc.Timesheet.Approve(tm.managers(), tm.member, pastWeek, ""), ErrTimesheetState, "Approve with running timer")
+}
+
+func startTimer(t *testing.T, s *storage.Storage, taskID, peopleID int, start time.Time) {
+	t.Helper()
+	if _, err := s.TimeManage.StartTimeEntry(context.Background(), taskID, peopleID, start, entities.OverlapChanges{}); err != nil {
+		t.Fatalf("StartTimeEntry: %v", err)
+	}
+}
+
+func TestParseWeek(t *testing.T) {
+	tests := []struct {
+		week string
+		want string
+	}{
+		{week: "2026-W42", want: "2026-10-12"},
+		{week: "2026-W01", want: "2025-12-29"},
+		{week: "2025-W01", want: "2024-12-30"},
+		{week: "2026-W52", want: "2026-12-21"},
+		{week: "2020-W53", want: "2020-12-28"},
+		// 2026 год начинается с четверга, в нём 53 недели, в 2027 - 52
+		{week: "2026-W53", want: "2026-12-28"},
+		{week: "2027-W53"},
+		{week: "2021-W53"},
+		{week: "2026-W00"},
+		{week: "2026-W60"},
+		{week: "2026-42"},
+		{week: "2026W42"},
+		{week: "2026-w42"},
+		{week: "2026-W4"},
+		{week: "2026-W420"},
+		{week: "26-W42"},
+		{week: "2026-W4x"},
+		{week: "2026-W+4"},
+		{week: "+026-W42"},
+		{week: "2026-W 4"},
+		{week: "W42-2026"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.week, func(t *testing.T) {
+			got, err := ParseWeek(tt.week)
+			if tt.want == "" {
+				checkError(t, err, ErrInvalidWeek, "ParseWeek")
+				return
+			}
+
+			checkError(t, err, nil, "ParseWeek")
+			if got.Format(time.DateOnly) != tt.want || got.Weekday() != time.Monday || got.Location() != time.UTC {
+				t.Fatalf("ParseWeek = %v, want %s 00:00 UTC", got, tt.want)
+			}
+			if formatWeek(got) != tt.week {
+				t.Fatalf("formatWeek = %s, want %s", formatWeek(got), tt.week)
+			}
+		})
+	}
+
+	got, err := ParseWeek("")
+	checkError(t, err, nil, "ParseWeek current")
+	if now := time.Now().UTC(); got.After(now) || now.Sub(got) >= 7*24*time.Hour || got.Weekday() != time.Monday {
+		t.Fatalf("ParseWeek current = %v, want Monday of the current week", got)
+	}
+}
+
+func TestFillTimesheet(t *testing.T) {
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	day := func(d, hour, minute int) time.Time {
+		return start.AddDate(0, 0, d).Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	segments := []entities.TaskTimeSegment{
+		// Через полночь с понедельника на вторник
+		{TaskID: 1, TaskTitle: "Build", StartTime: day(0, 22, 0), EndTime: day(1, 2, 0)},
+		// Начат в воскресенье прошлой недели, учитывается только понедельник
+		{TaskID: 2, TaskTitle: "Review", StartTime: day(-1, 23, 0), EndTime: day(0, 1, 0)},
+		// Через полночь в следующую неделю, учитывается только воскресенье
+		{TaskID: 1, TaskTitle: "Build", StartTime: day(6, 23, 30), EndTime: day(7, 0, 30)},
+		// Несколько суток подряд
+		{TaskID: 2, TaskTitle: "Review", StartTime: day(2, 12, 0), EndTime: day(4, 12, 0)},
+		{TaskID: 1, TaskTitle: "Build", StartTime: day(1, 9, 0), EndTime: day(1, 9, 20)},
+	}
+
+	var sheet entities.Timesheet
+	fillTimesheet(&sheet, start, segments)
+
+	if sheet.Week != "2026-W02" || len(sheet.Days) != 7 || sheet.Days[0] != "2026-01-05" || sheet.Days[6] != "2026-01-11" {
+		t.Fatalf("Week = %s, Days = %v", sheet.Week, sheet.Days)
+	}
+
+	wantRows := []struct {
+		taskID int
+		hours  []float64
+		total  float64
+	}{
+		{1, []float64{2, 2.33, 0, 0, 0, 0, 0.5}, 4.83},
+		{2, []float64{1, 0, 12, 24, 12, 0, 0}, 49},
+	}
+	if len(sheet.Rows) != len(wantRows) {
+		t.Fatalf("Rows = %+v", sheet.Rows)
+	}
+	for i, want := range wantRows {
+		row := sheet.Rows[i]
+		if row.TaskID != want.taskID || !equalHours(row.Hours, want.hours) || row.TotalHours != want.total {
+			t.Fatalf("Rows[%d] = %+v, want task %d %v total %v", i, row, want.taskID, want.hours, want.total)
+		}
+	}
+
+	if want := []float64{3, 2.33, 12, 24, 12, 0, 0.5}; !equalHours(sheet.DayTotals, want) || sheet.TotalHours != 53.83 {
+		t.Fatalf("DayTotals = %v, TotalHours = %v", sheet.DayTotals, sheet.TotalHours)
+	}
+
+	var empty entities.Timesheet
+	fillTimesheet(&empty, start, nil)
+	if len(empty.Rows) != 0 || !equalHours(empty.DayTotals, make([]float64, 7)) || empty.TotalHours != 0 {
+		t.Fatalf("empty timesheet = %+v", empty)
+	}
+}
+
+func equalHours(got, want []float64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
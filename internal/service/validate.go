@@ -0,0 +1,385 @@
+package service
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// rule правило проверки значения типа T: если check возвращает false, поле field получает ошибку message.
+// Правила одного запроса собираются в список и проверяются все сразу, клиент получает все ошибки одним ответом.
+type rule[T any] struct {
+	field   string
+	message string
+	check   func(T) bool
+}
+
+// validate проверяет v по правилам rules и объединяет нарушения в domain.ValidationError.
+func validate[T any](v T, rules []rule[T]) error {
+	var fields []domain.FieldError
+	for _, r := range rules {
+		if !r.check(v) {
+			fields = append(fields, domain.FieldError{Field: r.field, Message: r.message})
+		}
+	}
+
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return &domain.ValidationError{Fields: fields}
+}
+
+// Ограничения длины, совпадают с размерами столбцов в схеме БД.
+// Адрес хранится в столбце TEXT, его длина ограничивается только здесь.
+const (
+	maxNameLength    = 50
+	maxTitleLength   = 100
+	maxTagLength     = 50
+	maxItemLength    = 200
+	maxAddressLength = 200
+)
+
+// optional пропускает нулевое значение, при обновлении оно означает, что поле не меняется.
+func optional[T comparable](check func(T) bool) func(T) bool {
+	return func(v T) bool {
+		var zero T
+		return v == zero || check(v)
+	}
+}
+
+func between(min, max int) func(int) bool {
+	return func(v int) bool { return v >= min && v <= max }
+}
+
+func notNegative(v int) bool { return v >= 0 }
+
+func positive(v int) bool { return v > 0 }
+
+func notBlank(s string) bool { return strings.TrimSpace(s) != "" }
+
+func maxLength(n int) func(string) bool {
+	return func(s string) bool { return utf8.RuneCountInString(s) <= n }
+}
+
+// personName допускает в ФИО буквы, пробелы, дефисы и апострофы.
+func personName(s string) bool {
+	for _, r := range s {
+		if !unicode.IsLetter(r) && r != ' ' && r != '-' && r != '\'' {
+			return false
+		}
+	}
+	return true
+}
+
+func knownRole(r entities.Role) bool {
+	switch r {
+	case "", entities.RoleAdmin, entities.RoleManager, entities.RoleMember:
+		return true
+	}
+	return false
+}
+
+// duration проверяет, что s - длительность не короче секунды, например 4h30m.
+func duration(s string) bool {
+	d, err := time.ParseDuration(s)
+	return err == nil && d >= time.Second
+}
+
+// notBefore проверяет, что end не раньше start, незаданное время не проверяется.
+func notBefore(start, end time.Time) bool {
+	return start.IsZero() || end.IsZero() || !end.Before(start)
+}
+
+// nameRules правила для поля ФИО, required требует непустое значение.
+func nameRules(field string, value func(entities.People) string, required bool) []rule[entities.People] {
+	rules := []rule[entities.People]{
+		{field, fmt.Sprintf("must be at most %d characters", maxNameLength), func(p entities.People) bool { return maxLength(maxNameLength)(value(p)) }},
+		{field, "must contain only letters, spaces, hyphens and apostrophes", func(p entities.People) bool { return personName(value(p)) }},
+	}
+	if required {
+		rules = append([]rule[entities.People]{
+			{field, "is required", func(p entities.People) bool { return notBlank(value(p)) }},
+		}, rules...)
+	}
+	return rules
+}
+
+// Правила для данных пользователя
+var (
+	peopleCreateRules = concat(
+		[]rule[entities.People]{
+			{"passport_series", "must be a 4-digit number", func(p entities.People) bool { return between(1000, 9999)(p.PassportSeries) }},
+			{"passport_number", "must be a 6-digit number", func(p entities.People) bool { return between(100000, 999999)(p.PassportNumber) }},
+		},
+		nameRules("surname", func(p entities.People) string { return p.Surname }, true),
+		nameRules("name", func(p entities.People) string { return p.Name }, true),
+		nameRules("patronymic", func(p entities.People) string { return p.Patronymic }, false),
+		[]rule[entities.People]{
+			{"address", "is required", func(p entities.People) bool { return notBlank(p.Address) }},
+		},
+		peopleCommonRules,
+	)
+
+	peopleUpdateRules = concat(
+		[]rule[entities.People]{
+			{"id", "is required", func(p entities.People) bool { return positive(p.ID) }},
+			{"passport_series", "must be a 4-digit number", func(p entities.People) bool { return optional(between(1000, 9999))(p.PassportSeries) }},
+			{"passport_number", "must be a 6-digit number", func(p entities.People) bool { return optional(between(100000, 999999))(p.PassportNumber) }},
+		},
+		nameRules("surname", func(p entities.People) string { return p.Surname }, false),
+		nameRules("name", func(p entities.People) string { return p.Name }, false),
+		nameRules("patronymic", func(p entities.People) string { return p.Patronymic }, false),
+		peopleCommonRules,
+	)
+
+	peopleCommonRules = []rule[entities.People]{
+		{"address", fmt.Sprintf("must be at most %d characters", maxAddressLength), func(p entities.People) bool { return maxLength(maxAddressLength)(p.Address) }},
+		{"role", "must be one of admin, manager, member", func(p entities.People) bool { return knownRole(p.Role) }},
+		{"manager_id", "must not be negative", func(p entities.People) bool { return notNegative(p.ManagerID) }},
+	}
+)
+
+// Поля пользователя, для которых в фильтре можно задать способ сравнения.
+var peopleMatchFields = []string{"surname", "name", "patronymic", "address"}
+
+func knownMatch(op entities.MatchOp) bool {
+	switch op {
+	case "", entities.MatchExact, entities.MatchIExact, entities.MatchPrefix, entities.MatchContains:
+		return true
+	}
+	return false
+}
+
+// peopleFilterRules правила фильтра пользователей, по одному на поле со способом сравнения.
+var peopleFilterRules = func() []rule[entities.PeopleFilter] {
+	rules := make([]rule[entities.PeopleFilter], len(peopleMatchFields))
+	for i, field := range peopleMatchFields {
+		rules[i] = rule[entities.PeopleFilter]{field + "_match", "must be one of exact, iexact, prefix, contains",
+			func(f entities.PeopleFilter) bool { return knownMatch(f.Match[field]) }}
+	}
+	return rules
+}()
+
+// estimateMessage ошибка оценки задачи, записанной не длительностью.
+const estimateMessage = "must be a duration of at least 1s, e.g. 4h30m"
+
+// Правила для задач
+var (
+	taskCreateRules = []rule[entities.Task]{
+		{"title", "is required", func(t entities.Task) bool { return notBlank(t.Title) }},
+		{"title", fmt.Sprintf("must be at most %d characters", maxTitleLength), func(t entities.Task) bool { return maxLength(maxTitleLength)(t.Title) }},
+		{"project_id", "must not be negative", func(t entities.Task) bool { return notNegative(t.ProjectID) }},
+		{"parent_id", "must not be negative", func(t entities.Task) bool { return notNegative(t.ParentID) }},
+		{"timeEntry.people_id", "must not be negative", func(t entities.Task) bool { return notNegative(t.TimeEntry.PeopleID) }},
+		{"timeEntry.end_time", "must not be before timeEntry.start_time", func(t entities.Task) bool {
+			return notBefore(t.TimeEntry.StartTime, t.TimeEntry.EndTime)
+		}},
+		{"estimate", estimateMessage, func(t entities.Task) bool { return optional(duration)(t.Estimate) }},
+		{"checklist", checklistMessage, func(t entities.Task) bool {
+			for _, item := range t.Checklist {
+				if !checklistItem(item.Title) {
+					return false
+				}
+			}
+			return true
+		}},
+	}
+
+	// При обновлении пустой заголовок означает, что заголовок не меняется.
+	taskUpdateRules = []rule[entities.Task]{
+		{"task_id", "is required", func(t entities.Task) bool { return positive(t.ID) }},
+		{"title", "must not be blank", func(t entities.Task) bool { return optional(notBlank)(t.Title) }},
+		{"title", fmt.Sprintf("must be at most %d characters", maxTitleLength), func(t entities.Task) bool { return maxLength(maxTitleLength)(t.Title) }},
+	}
+
+	// Нулевой parent_id переносит задачу на верхний уровень.
+	// Пустые срок и оценка снимают их с задачи.
+	taskScheduleRules = []rule[entities.Task]{
+		{"task_id", "is required", func(t entities.Task) bool { return positive(t.ID) }},
+		{"estimate", estimateMessage, func(t entities.Task) bool { return optional(duration)(t.Estimate) }},
+	}
+
+	taskParentRules = []rule[entities.Task]{
+		{"task_id", "is required", func(t entities.Task) bool { return positive(t.ID) }},
+		{"parent_id", "must not be negative", func(t entities.Task) bool { return notNegative(t.ParentID) }},
+		{"parent_id", "must not be the task itself", func(t entities.Task) bool { return t.ParentID != t.ID }},
+	}
+)
+
+// Правила для повторяющихся задач, правило RRULE разбирается отдельно
+var recurrenceCreateRules = []rule[entities.Recurrence]{
+	{"title", "is required", func(r entities.Recurrence) bool { return notBlank(r.Title) }},
+	{"title", fmt.Sprintf("must be at most %d characters", maxTitleLength), func(r entities.Recurrence) bool { return maxLength(maxTitleLength)(r.Title) }},
+	{"project_id", "must not be negative", func(r entities.Recurrence) bool { return notNegative(r.ProjectID) }},
+	{"assignee_ids", "must contain only positive IDs", func(r entities.Recurrence) bool {
+		for _, id := range r.AssigneeIDs {
+			if !positive(id) {
+				return false
+			}
+		}
+		return true
+	}},
+	{"estimate", estimateMessage, func(r entities.Recurrence) bool { return optional(duration)(r.Estimate) }},
+	{"rule", "is required", func(r entities.Recurrence) bool { return notBlank(r.Rule) }},
+	{"start", "is required", func(r entities.Recurrence) bool { return !r.Start.IsZero() }},
+}
+
+// checklistMessage ошибка пункта чек-листа.
+var checklistMessage = fmt.Sprintf("items must not be blank and must be at most %d characters", maxItemLength)
+
+func checklistItem(title string) bool {
+	return notBlank(title) && maxLength(maxItemLength)(title)
+}
+
+// Правила для шаблонов задач, подстановки заголовка проверяются по синтаксису,
+// наличие их значений - при создании задачи по шаблону.
+var (
+	templateCreateRules = []rule[entities.TaskTemplate]{
+		{"name", "is required", func(t entities.TaskTemplate) bool { return notBlank(t.Name) }},
+		{"name", fmt.Sprintf("must be at most %d characters", maxTitleLength), func(t entities.TaskTemplate) bool { return maxLength(maxTitleLength)(t.Name) }},
+		{"title_pattern", "is required", func(t entities.TaskTemplate) bool { return notBlank(t.TitlePattern) }},
+		{"title_pattern", fmt.Sprintf("must be at most %d characters", maxTitleLength), func(t entities.TaskTemplate) bool {
+			return maxLength(maxTitleLength)(t.TitlePattern)
+		}},
+		{"title_pattern", "placeholders must be of the form {name} with letters, digits and underscores", func(t entities.TaskTemplate) bool {
+			_, ok := placeholders(t.TitlePattern)
+			return ok
+		}},
+		{"project_id", "must not be negative", func(t entities.TaskTemplate) bool { return notNegative(t.ProjectID) }},
+		{"assignee_id", "must not be negative", func(t entities.TaskTemplate) bool { return notNegative(t.AssigneeID) }},
+		{"estimate", estimateMessage, func(t entities.TaskTemplate) bool { return optional(duration)(t.Estimate) }},
+		{"checklist", checklistMessage, func(t entities.TaskTemplate) bool {
+			for _, title := range t.Checklist {
+				if !checklistItem(title) {
+					return false
+				}
+			}
+			return true
+		}},
+	}
+
+	templateUpdateRules = concat(
+		[]rule[entities.TaskTemplate]{
+			{"id", "is required", func(t entities.TaskTemplate) bool { return positive(t.ID) }},
+		},
+		templateCreateRules,
+	)
+
+	templateParamsRules = []rule[entities.TemplateParams]{
+		{"project_id", "must not be negative", func(p entities.TemplateParams) bool { return notNegative(p.ProjectID) }},
+		{"parent_id", "must not be negative", func(p entities.TemplateParams) bool { return notNegative(p.ParentID) }},
+		{"assignee_id", "must not be negative", func(p entities.TemplateParams) bool { return notNegative(p.AssigneeID) }},
+	}
+)
+
+// checklistRequest пункт чек-листа задачи.
+type checklistRequest struct {
+	TaskID int
+	ItemID int
+	Title  string
+}
+
+var (
+	checklistAddRules = []rule[checklistRequest]{
+		{"task_id", "is required", func(r checklistRequest) bool { return positive(r.TaskID) }},
+		{"title", "is required", func(r checklistRequest) bool { return notBlank(r.Title) }},
+		{"title", fmt.Sprintf("must be at most %d characters", maxItemLength), func(r checklistRequest) bool { return maxLength(maxItemLength)(r.Title) }},
+	}
+
+	checklistItemRules = []rule[checklistRequest]{
+		{"task_id", "is required", func(r checklistRequest) bool { return positive(r.TaskID) }},
+		{"item_id", "is required", func(r checklistRequest) bool { return positive(r.ItemID) }},
+	}
+)
+
+// Правила для меток
+var (
+	tagCreateRules = []rule[entities.Tag]{
+		{"name", "is required", func(t entities.Tag) bool { return notBlank(t.Name) }},
+		{"name", fmt.Sprintf("must be at most %d characters", maxTagLength), func(t entities.Tag) bool { return maxLength(maxTagLength)(t.Name) }},
+	}
+
+	tagUpdateRules = concat(
+		[]rule[entities.Tag]{
+			{"id", "is required", func(t entities.Tag) bool { return positive(t.ID) }},
+		},
+		tagCreateRules,
+	)
+)
+
+// dependencyRequest зависимость между задачами: BlockerID блокирует BlockedID.
+type dependencyRequest struct {
+	BlockerID int
+	BlockedID int
+}
+
+var dependencyRules = []rule[dependencyRequest]{
+	{"task_id", "is required", func(r dependencyRequest) bool { return positive(r.BlockerID) }},
+	{"blocked_id", "is required", func(r dependencyRequest) bool { return positive(r.BlockedID) }},
+	{"blocked_id", "must not be the task itself", func(r dependencyRequest) bool { return r.BlockerID != r.BlockedID }},
+}
+
+// assigneeRequest назначение пользователя PeopleID исполнителем задачи TaskID.
+type assigneeRequest struct {
+	TaskID   int
+	PeopleID int
+}
+
+var assigneeRules = []rule[assigneeRequest]{
+	{"task_id", "is required", func(r assigneeRequest) bool { return positive(r.TaskID) }},
+	{"people_id", "is required", func(r assigneeRequest) bool { return positive(r.PeopleID) }},
+}
+
+// timeRequest параметры запроса к учёту времени.
+// Для записи времени Start - начало запущенного отрезка, End - время запроса,
+// для отчёта - границы периода.
+type timeRequest struct {
+	TaskID    int
+	PeopleID  int
+	ProjectID int
+	Start     time.Time
+	End       time.Time
+}
+
+// Правила для запросов учёта времени
+var (
+	timeEntryRules = []rule[timeRequest]{
+		{"task_id", "is required", func(r timeRequest) bool { return positive(r.TaskID) }},
+		{"people_id", "must not be negative", func(r timeRequest) bool { return notNegative(r.PeopleID) }},
+	}
+
+	timerRules = []rule[timeRequest]{
+		{"people_id", "must not be negative", func(r timeRequest) bool { return notNegative(r.PeopleID) }},
+	}
+
+	// Время завершения или паузы не может быть раньше начала запущенного отрезка.
+	segmentEndRules = []rule[timeRequest]{
+		{"time", "must not be before the start of the running timer", func(r timeRequest) bool { return notBefore(r.Start, r.End) }},
+	}
+
+	estimateReportRules = []rule[timeRequest]{
+		{"people_id", "must not be negative", func(r timeRequest) bool { return notNegative(r.PeopleID) }},
+		{"project_id", "must not be negative", func(r timeRequest) bool { return notNegative(r.ProjectID) }},
+	}
+
+	timeRangeRules = []rule[timeRequest]{
+		{"people_id", "must not be negative", func(r timeRequest) bool { return notNegative(r.PeopleID) }},
+		{"project_id", "must not be negative", func(r timeRequest) bool { return notNegative(r.ProjectID) }},
+		{"start_time", "is required", func(r timeRequest) bool { return !r.Start.IsZero() }},
+		{"end_time", "is required", func(r timeRequest) bool { return !r.End.IsZero() }},
+		{"end_time", "must not be before start_time", func(r timeRequest) bool { return notBefore(r.Start, r.End) }},
+	}
+)
+
+func concat[T any](lists ...[]rule[T]) []rule[T] {
+	var rules []rule[T]
+	for _, l := range lists {
+		rules = append(rules, l...)
+	}
+	return rules
+}
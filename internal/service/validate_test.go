@@ -0,0 +1,109 @@
+package service
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	valid := entities.People{PassportSeries: 1234, PassportNumber: 567890, Surname: "Ivanov", Name: "Ivan", Address: "Moscow"}
+
+	tests := []struct {
+		name   string
+		people entities.People
+		rules  []rule[entities.People]
+		want   []domain.FieldError
+	}{
+		{
+			name:   "valid",
+			people: valid,
+			rules:  peopleCreateRules,
+		},
+		{
+			name:   "no rules",
+			people: entities.People{},
+		},
+		{
+			name:   "all violations at once",
+			people: entities.People{PassportSeries: 12, Surname: "Ivanov1", Address: " ", Role: "owner", ManagerID: -1},
+			rules:  peopleCreateRules,
+			want: []domain.FieldError{
+				{Field: "passport_series", Message: "must be a 4-digit number"},
+				{Field: "passport_number", Message: "must be a 6-digit number"},
+				{Field: "surname", Message: "must contain only letters, spaces, hyphens and apostrophes"},
+				{Field: "name", Message: "is required"},
+				{Field: "address", Message: "is required"},
+				{Field: "role", Message: "must be one of admin, manager, member"},
+				{Field: "manager_id", Message: "must not be negative"},
+			},
+		},
+		{
+			name:   "address required on create",
+			people: func() entities.People { p := valid; p.Address = ""; return p }(),
+			rules:  peopleCreateRules,
+			want:   []domain.FieldError{{Field: "address", Message: "is required"}},
+		},
+		{
+			name:   "address too long",
+			people: func() entities.People { p := valid; p.Address = strings.Repeat("д", maxAddressLength+1); return p }(),
+			rules:  peopleCreateRules,
+			want:   []domain.FieldError{{Field: "address", Message: "must be at most 200 characters"}},
+		},
+		{
+			name:   "address at limit",
+			people: func() entities.People { p := valid; p.Address = strings.Repeat("д", maxAddressLength); return p }(),
+			rules:  peopleCreateRules,
+		},
+		{
+			name:   "update keeps empty address",
+			people: entities.People{ID: 1},
+			rules:  peopleUpdateRules,
+		},
+		{
+			name:   "update with too long address",
+			people: entities.People{ID: 1, Address: strings.Repeat("a", maxAddressLength+1)},
+			rules:  peopleUpdateRules,
+			want:   []domain.FieldError{{Field: "address", Message: "must be at most 200 characters"}},
+		},
+		{
+			name:   "several rules of one field",
+			people: entities.People{ID: 1, Surname: strings.Repeat("1", maxNameLength+1)},
+			rules:  peopleUpdateRules,
+			want: []domain.FieldError{
+				{Field: "surname", Message: "must be at most 50 characters"},
+				{Field: "surname", Message: "must contain only letters, spaces, hyphens and apostrophes"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate(tt.people, tt.rules)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("validate = %v, want nil", err)
+				}
+				return
+			}
+
+			if !errors.Is(err, domain.ErrValidation) {
+				t.Fatalf("validate = %v, want %v", err, domain.ErrValidation)
+			}
+			var validationErr *domain.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("validate = %T, want *domain.ValidationError", err)
+			}
+			if len(validationErr.Fields) != len(tt.want) {
+				t.Fatalf("validate fields = %+v, want %+v", validationErr.Fields, tt.want)
+			}
+			for i := range tt.want {
+				if validationErr.Fields[i] != tt.want[i] {
+					t.Fatalf("validate fields = %+v, want %+v", validationErr.Fields, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"TaskSync/internal/entities"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Workflow таблица допустимых переходов между статусами задачи.
+type Workflow struct {
+	initial     entities.TaskStatus
+	transitions map[entities.TaskStatus][]entities.TaskStatus
+}
+
+// NewWorkflow создает таблицу переходов. Все статусы, встречающиеся в переходах, считаются известными.
+func NewWorkflow(initial entities.TaskStatus, transitions map[entities.TaskStatus][]entities.TaskStatus) (*Workflow, error) {
+	if initial == "" {
+		return nil, fmt.Errorf("initial status is empty")
+	}
+
+	w := &Workflow{
+		initial:     initial,
+		transitions: make(map[entities.TaskStatus][]entities.TaskStatus, len(transitions)),
+	}
+
+	for from, targets := range transitions {
+		w.transitions[from] = slices.Clone(targets)
+		for _, to := range targets {
+			if _, ok := w.transitions[to]; !ok {
+				w.transitions[to] = nil
+			}
+		}
+	}
+
+	if _, ok := w.transitions[initial]; !ok {
+		return nil, fmt.Errorf("initial status %q has no transitions", initial)
+	}
+
+	return w, nil
+}
+
+// DefaultWorkflow возвращает таблицу переходов по умолчанию.
+func DefaultWorkflow() *Workflow {
+	w, _ := NewWorkflow(entities.StatusTodo, map[entities.TaskStatus][]entities.TaskStatus{
+		entities.StatusTodo:       {entities.StatusInProgress, entities.StatusCancelled},
+		entities.StatusInProgress: {entities.StatusReview, entities.StatusDone, entities.StatusTodo, entities.StatusCancelled},
+		entities.StatusReview:     {entities.StatusInProgress, entities.StatusDone, entities.StatusCancelled},
+		entities.StatusDone:       {entities.StatusInProgress},
+		entities.StatusCancelled:  {entities.StatusTodo},
+	})
+	return w
+}
+
+// ParseWorkflow разбирает таблицу переходов из конфигурации в формате
+// "todo:in_progress,cancelled;in_progress:review,done". Начальным считается первый статус.
+// Пустая строка означает таблицу по умолчанию.
+func ParseWorkflow(s string) (*Workflow, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return DefaultWorkflow(), nil
+	}
+
+	var initial entities.TaskStatus
+	transitions := make(map[entities.TaskStatus][]entities.TaskStatus)
+
+	for _, rule := range strings.Split(s, ";") {
+		if strings.TrimSpace(rule) == "" {
+			continue
+		}
+
+		from, to, ok := strings.Cut(rule, ":")
+		from = strings.TrimSpace(from)
+		if !ok || from == "" {
+			return nil, fmt.Errorf("invalid transition rule %q", rule)
+		}
+
+		status := entities.TaskStatus(from)
+		if initial == "" {
+			initial = status
+		}
+
+		for _, target := range strings.Split(to, ",") {
+			if target = strings.TrimSpace(target); target != "" {
+				transitions[status] = append(transitions[status], entities.TaskStatus(target))
+			}
+		}
+	}
+
+	return NewWorkflow(initial, transitions)
+}
+
+// Initial возвращает статус новой задачи.
+func (w *Workflow) Initial() entities.TaskStatus {
+	return w.initial
+}
+
+// Known сообщает, описан ли статус в таблице переходов.
+func (w *Workflow) Known(status entities.TaskStatus) bool {
+	_, ok := w.transitions[status]
+	return ok
+}
+
+// CanTransition сообщает, допустим ли переход между статусами.
+func (w *Workflow) CanTransition(from, to entities.TaskStatus) bool {
+	return slices.Contains(w.transitions[from], to)
+}
@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+type APIKeyManageMemory struct {
+	db *DB
+}
+
+func NewAPIKeyManage(db *DB) *APIKeyManageMemory {
+	return &APIKeyManageMemory{db: db}
+}
+
+// CreateAPIKey сохраняет новый API ключ.
+func (a *APIKeyManageMemory) CreateAPIKey(ctx context.Context, key entities.APIKey) (int, error) {
+	const op = "memory.APIKey.Create"
+
+	a.db.mu.Lock()
+	defer a.db.mu.Unlock()
+
+	if _, ok := a.db.people[key.PeopleID]; !ok {
+		return 0, fmt.Errorf("%w, operation: %s", domain.ErrInputData, op)
+	}
+
+	for _, other := range a.db.apiKeys {
+		if other.Prefix == key.Prefix {
+			return 0, fmt.Errorf("%w: api key prefix already exists, operation: %s", domain.ErrInputData, op)
+		}
+	}
+
+	key.ID = a.db.nextID("api_keys")
+	key.Scopes = slices.Clone(key.Scopes)
+	key.LastUsedAt, key.RevokedAt = time.Time{}, time.Time{}
+	key.Created = time.Now()
+	a.db.apiKeys[key.ID] = &key
+
+	return key.ID, nil
+}
+
+// GetAPIKeyByPrefix возвращает API ключ по его открытому префиксу.
+func (a *APIKeyManageMemory) GetAPIKeyByPrefix(ctx context.Context, prefix string) (entities.APIKey, error) {
+	const op = "memory.APIKey.GetByPrefix"
+
+	a.db.mu.RLock()
+	defer a.db.mu.RUnlock()
+
+	for _, key := range a.db.apiKeys {
+		if key.Prefix == prefix {
+			return copyAPIKey(key), nil
+		}
+	}
+
+	return entities.APIKey{}, fmt.Errorf("%w: api key, operation: %s", domain.ErrNoRecordsFound, op)
+}
+
+// ListAPIKeys возвращает все API ключи пользователя, включая отозванные.
+func (a *APIKeyManageMemory) ListAPIKeys(ctx context.Context, peopleID int) ([]entities.APIKey, error) {
+	a.db.mu.RLock()
+	defer a.db.mu.RUnlock()
+
+	var keys []entities.APIKey
+	for _, id := range sortedIDs(a.db.apiKeys) {
+		if key := a.db.apiKeys[id]; key.PeopleID == peopleID {
+			keys = append(keys, copyAPIKey(key))
+		}
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey отзывает API ключ пользователя. Если peopleID равен 0, владелец ключа не проверяется.
+func (a *APIKeyManageMemory) RevokeAPIKey(ctx context.Context, keyID, peopleID int, revokedAt time.Time) error {
+	const op = "memory.APIKey.Revoke"
+
+	a.db.mu.Lock()
+	defer a.db.mu.Unlock()
+
+	key, ok := a.db.apiKeys[keyID]
+	if !ok || (peopleID != 0 && key.PeopleID != peopleID) || !key.RevokedAt.IsZero() {
+		return fmt.Errorf("%w: active api key ID %d, operation: %s", domain.ErrNoRecordsFound, keyID, op)
+	}
+
+	key.RevokedAt = revokedAt
+
+	return nil
+}
+
+// TouchAPIKey сохраняет время последнего использования API ключа.
+func (a *APIKeyManageMemory) TouchAPIKey(ctx context.Context, keyID int, usedAt time.Time) error {
+	a.db.mu.Lock()
+	defer a.db.mu.Unlock()
+
+	if key, ok := a.db.apiKeys[keyID]; ok {
+		key.LastUsedAt = usedAt
+	}
+
+	return nil
+}
+
+// copyAPIKey возвращает копию ключа, не разделяющую срез областей доступа с хранилищем.
+func copyAPIKey(key *entities.APIKey) entities.APIKey {
+	copied := *key
+	copied.Scopes = slices.Clone(key.Scopes)
+	return copied
+}
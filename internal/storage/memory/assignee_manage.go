@@ -0,0 +1,175 @@
+package memory
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+type AssigneeManageMemory struct {
+	db *DB
+}
+
+func NewAssigneeManage(db *DB) *AssigneeManageMemory {
+	return &AssigneeManageMemory{db: db}
+}
+
+// Assign назначает пользователя исполнителем задачи с момента at.
+// Повторное назначение текущего исполнителя не считается ошибкой и не меняет историю.
+func (a *AssigneeManageMemory) Assign(ctx context.Context, taskID, peopleID int, at time.Time) error {
+	const op = "memory.Assignee.Assign"
+
+	a.db.mu.Lock()
+	defer a.db.mu.Unlock()
+
+	_, taskOK := a.db.tasks[taskID]
+	_, peopleOK := a.db.people[peopleID]
+	if !taskOK || !peopleOK {
+		return fmt.Errorf("%w: task ID %d or people ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, peopleID, op)
+	}
+
+	a.db.assign(taskID, peopleID, at)
+
+	return nil
+}
+
+// Unassign снимает пользователя с задачи в момент at, назначение остаётся в истории.
+func (a *AssigneeManageMemory) Unassign(ctx context.Context, taskID, peopleID int, at time.Time) error {
+	const op = "memory.Assignee.Unassign"
+
+	a.db.mu.Lock()
+	defer a.db.mu.Unlock()
+
+	assignment := a.db.currentAssignment(taskID, peopleID)
+	if assignment == nil {
+		return fmt.Errorf("%w: people ID %d is not assigned to task ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, taskID, op)
+	}
+
+	// Назначение не может закончиться раньше, чем началось
+	assignment.unassignedAt = latest(utc(at), assignment.assignedAt)
+
+	return nil
+}
+
+// History возвращает все назначения задачи, текущие и снятые, в порядке назначения.
+func (a *AssigneeManageMemory) History(ctx context.Context, taskID int) ([]entities.TaskAssignment, error) {
+	a.db.mu.RLock()
+	defer a.db.mu.RUnlock()
+
+	return a.db.taskAssignments(taskID), nil
+}
+
+// TimeByAssignee возвращает время завершённых сессий задачи по каждому пользователю:
+// текущим исполнителям и всем, кто работал над задачей, в порядке убывания времени.
+func (a *AssigneeManageMemory) TimeByAssignee(ctx context.Context, taskID int) ([]entities.AssigneeTimeSpent, error) {
+	a.db.mu.RLock()
+	defer a.db.mu.RUnlock()
+
+	// Текущие исполнители попадают в отчёт и без затраченного времени
+	seconds := make(map[int]float64)
+	for _, assignment := range a.db.assignments {
+		if assignment.taskID == taskID && assignment.unassignedAt.IsZero() {
+			seconds[assignment.peopleID] = 0
+		}
+	}
+	for _, entry := range a.db.entries {
+		if entry.TaskID != taskID || entry.PeopleID == 0 {
+			continue
+		}
+		spent := seconds[entry.PeopleID]
+		// Учитываются только завершённые сессии
+		if !entry.StartTime.IsZero() && !entry.EndTime.IsZero() {
+			spent += entry.EndTime.Sub(entry.StartTime).Seconds()
+		}
+		seconds[entry.PeopleID] = spent
+	}
+
+	entries := make([]entities.AssigneeTimeSpent, 0, len(seconds))
+	for peopleID, sec := range seconds {
+		people := a.db.people[peopleID].People
+		entries = append(entries, entities.AssigneeTimeSpent{
+			PeopleID:   people.ID,
+			Surname:    people.Surname,
+			Name:       people.Name,
+			Patronymic: people.Patronymic,
+			Assigned:   a.db.currentAssignment(taskID, peopleID) != nil,
+			TimeSpent:  formatInterval(sec),
+			Hours:      math.Round(sec/36) / 100,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		x, y := entries[i], entries[j]
+		switch {
+		case seconds[x.PeopleID] != seconds[y.PeopleID]:
+			return seconds[x.PeopleID] > seconds[y.PeopleID]
+		case x.Surname != y.Surname:
+			return x.Surname < y.Surname
+		case x.Name != y.Name:
+			return x.Name < y.Name
+		}
+		return x.PeopleID < y.PeopleID
+	})
+
+	return entries, nil
+}
+
+// assign добавляет текущее назначение, если пользователь ещё не назначен на задачу.
+func (db *DB) assign(taskID, peopleID int, at time.Time) {
+	if db.currentAssignment(taskID, peopleID) != nil {
+		return
+	}
+
+	id := db.nextID("task_assignees")
+	db.assignments[id] = &assignmentRow{id: id, taskID: taskID, peopleID: peopleID, assignedAt: utc(at)}
+}
+
+// currentAssignment возвращает текущее назначение пользователя на задачу или nil.
+func (db *DB) currentAssignment(taskID, peopleID int) *assignmentRow {
+	for _, assignment := range db.assignments {
+		if assignment.taskID == taskID && assignment.peopleID == peopleID && assignment.unassignedAt.IsZero() {
+			return assignment
+		}
+	}
+	return nil
+}
+
+// taskAssignments возвращает все назначения задачи в порядке назначения.
+func (db *DB) taskAssignments(taskID int) []entities.TaskAssignment {
+	assignments := []entities.TaskAssignment{}
+	for _, id := range sortedIDs(db.assignments) {
+		row := db.assignments[id]
+		if row.taskID != taskID {
+			continue
+		}
+		people := db.people[row.peopleID].People
+		assignments = append(assignments, entities.TaskAssignment{
+			ID:           row.id,
+			TaskID:       row.taskID,
+			PeopleID:     row.peopleID,
+			Surname:      people.Surname,
+			Name:         people.Name,
+			Patronymic:   people.Patronymic,
+			AssignedAt:   row.assignedAt,
+			UnassignedAt: row.unassignedAt,
+		})
+	}
+
+	sort.SliceStable(assignments, func(i, j int) bool {
+		return assignments[i].AssignedAt.Before(assignments[j].AssignedAt)
+	})
+
+	return assignments
+}
+
+// latest возвращает более поздний из двух моментов, как GREATEST.
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
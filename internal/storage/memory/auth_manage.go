@@ -0,0 +1,131 @@
+package memory
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"fmt"
+	"time"
+)
+
+type AuthManageMemory struct {
+	db *DB
+}
+
+func NewAuthManage(db *DB) *AuthManageMemory {
+	return &AuthManageMemory{db: db}
+}
+
+// SetPasswordHash сохраняет хеш пароля пользователя.
+func (a *AuthManageMemory) SetPasswordHash(ctx context.Context, peopleID int, hash string) error {
+	const op = "memory.Auth.SetPasswordHash"
+
+	a.db.mu.Lock()
+	defer a.db.mu.Unlock()
+
+	row, ok := a.db.people[peopleID]
+	if !ok {
+		return fmt.Errorf("%w: people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
+	}
+
+	row.passwordHash = hash
+
+	return nil
+}
+
+// GetPasswordHash возвращает хеш пароля пользователя, пустая строка - пароль не задан.
+func (a *AuthManageMemory) GetPasswordHash(ctx context.Context, peopleID int) (string, error) {
+	const op = "memory.Auth.GetPasswordHash"
+
+	a.db.mu.RLock()
+	defer a.db.mu.RUnlock()
+
+	row, ok := a.db.people[peopleID]
+	if !ok {
+		return "", fmt.Errorf("%w: people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
+	}
+
+	return row.passwordHash, nil
+}
+
+// CreateSession сохраняет новую сессию входа.
+func (a *AuthManageMemory) CreateSession(ctx context.Context, session entities.Session) error {
+	const op = "memory.Auth.CreateSession"
+
+	a.db.mu.Lock()
+	defer a.db.mu.Unlock()
+
+	if _, ok := a.db.people[session.PeopleID]; !ok {
+		return fmt.Errorf("%w: people ID %d not found, operation: %s", domain.ErrInputData, session.PeopleID, op)
+	}
+
+	if _, ok := a.db.sessions[session.ID]; ok {
+		return fmt.Errorf("%w: session already exists, operation: %s", domain.ErrInputData, op)
+	}
+
+	session.RevokedAt = time.Time{}
+	session.Created = time.Now()
+	a.db.sessions[session.ID] = &session
+
+	return nil
+}
+
+// GetSession возвращает сессию входа по её ID.
+func (a *AuthManageMemory) GetSession(ctx context.Context, sessionID string) (entities.Session, error) {
+	const op = "memory.Auth.GetSession"
+
+	a.db.mu.RLock()
+	defer a.db.mu.RUnlock()
+
+	session, ok := a.db.sessions[sessionID]
+	if !ok {
+		return entities.Session{}, fmt.Errorf("%w: session, operation: %s", domain.ErrNoRecordsFound, op)
+	}
+
+	return *session, nil
+}
+
+// RotateSession заменяет refresh token действующей сессии.
+// Замена выполняется, только если предъявлен текущий refresh token сессии.
+func (a *AuthManageMemory) RotateSession(ctx context.Context, sessionID, oldRefreshHash, newRefreshHash string, expiresAt time.Time) error {
+	const op = "memory.Auth.RotateSession"
+
+	a.db.mu.Lock()
+	defer a.db.mu.Unlock()
+
+	session, ok := a.db.sessions[sessionID]
+	if !ok || session.RefreshHash != oldRefreshHash || !session.RevokedAt.IsZero() {
+		return fmt.Errorf("%w: active session, operation: %s", domain.ErrNoRecordsFound, op)
+	}
+
+	session.RefreshHash = newRefreshHash
+	session.ExpiresAt = expiresAt
+
+	return nil
+}
+
+// RevokeSession отзывает сессию входа.
+func (a *AuthManageMemory) RevokeSession(ctx context.Context, sessionID string, revokedAt time.Time) error {
+	a.db.mu.Lock()
+	defer a.db.mu.Unlock()
+
+	if session, ok := a.db.sessions[sessionID]; ok && session.RevokedAt.IsZero() {
+		session.RevokedAt = revokedAt
+	}
+
+	return nil
+}
+
+// RevokePeopleSessions отзывает все сессии пользователя, например после смены пароля.
+func (a *AuthManageMemory) RevokePeopleSessions(ctx context.Context, peopleID int, revokedAt time.Time) error {
+	a.db.mu.Lock()
+	defer a.db.mu.Unlock()
+
+	for _, session := range a.db.sessions {
+		if session.PeopleID == peopleID && session.RevokedAt.IsZero() {
+			session.RevokedAt = revokedAt
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type ChecklistManageMemory struct {
+	db *DB
+}
+
+func NewChecklistManage(db *DB) *ChecklistManageMemory {
+	return &ChecklistManageMemory{db: db}
+}
+
+// AddItem добавляет пункт в конец чек-листа задачи.
+func (c *ChecklistManageMemory) AddItem(ctx context.Context, taskID int, title string) (int, error) {
+	const op = "memory.Checklist.AddItem"
+
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	if _, ok := c.db.tasks[taskID]; !ok {
+		return 0, fmt.Errorf("%w: task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
+	}
+
+	position := 0
+	for _, item := range c.db.checklist {
+		if item.TaskID == taskID && item.Position > position {
+			position = item.Position
+		}
+	}
+
+	return c.db.addChecklistItem(taskID, position+1, title), nil
+}
+
+// SetItemDone отмечает пункт чек-листа выполненным в момент doneAt, нулевой doneAt снимает отметку.
+// Повторная отметка сохраняет время первой.
+func (c *ChecklistManageMemory) SetItemDone(ctx context.Context, taskID, itemID int, doneAt time.Time) error {
+	const op = "memory.Checklist.SetItemDone"
+
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	item, ok := c.db.checklist[itemID]
+	if !ok || item.TaskID != taskID {
+		return fmt.Errorf("%w: checklist item ID %d of task ID %d, operation: %s", domain.ErrNoRecordsFound, itemID, taskID, op)
+	}
+
+	switch {
+	case doneAt.IsZero():
+		item.Done, item.DoneAt = false, time.Time{}
+	case !item.Done:
+		item.Done, item.DoneAt = true, utc(doneAt)
+	}
+
+	return nil
+}
+
+// DeleteItem удаляет пункт чек-листа, номера остальных пунктов не меняются.
+func (c *ChecklistManageMemory) DeleteItem(ctx context.Context, taskID, itemID int) error {
+	const op = "memory.Checklist.DeleteItem"
+
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	item, ok := c.db.checklist[itemID]
+	if !ok || item.TaskID != taskID {
+		return fmt.Errorf("%w: checklist item ID %d of task ID %d, operation: %s", domain.ErrNoRecordsFound, itemID, taskID, op)
+	}
+
+	delete(c.db.checklist, itemID)
+
+	return nil
+}
+
+// addChecklistItem сохраняет пункт чек-листа задачи под номером position и возвращает его ID.
+func (db *DB) addChecklistItem(taskID, position int, title string) int {
+	id := db.nextID("task_checklist_items")
+	db.checklist[id] = &entities.ChecklistItem{ID: id, TaskID: taskID, Position: position, Title: title}
+	return id
+}
+
+// taskChecklist возвращает копии пунктов чек-листа задачи по порядку.
+func (db *DB) taskChecklist(taskID int) []entities.ChecklistItem {
+	var items []entities.ChecklistItem
+	for _, item := range db.checklist {
+		if item.TaskID == taskID {
+			items = append(items, *item)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Position != items[j].Position {
+			return items[i].Position < items[j].Position
+		}
+		return items[i].ID < items[j].ID
+	})
+
+	return items
+}
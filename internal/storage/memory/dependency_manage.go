@@ -0,0 +1,141 @@
+package memory
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type DependencyManageMemory struct {
+	db *DB
+}
+
+func NewDependencyManage(db *DB) *DependencyManageMemory {
+	return &DependencyManageMemory{db: db}
+}
+
+// Add добавляет зависимость: задача blockerID блокирует задачу blockedID.
+// Повторное добавление не считается ошибкой, зависимость, замыкающая цепочку задач в цикл, отклоняется.
+func (d *DependencyManageMemory) Add(ctx context.Context, blockerID, blockedID int) error {
+	const op = "memory.Dependency.Add"
+
+	d.db.mu.Lock()
+	defer d.db.mu.Unlock()
+
+	_, blockerOK := d.db.tasks[blockerID]
+	_, blockedOK := d.db.tasks[blockedID]
+	if !blockerOK || !blockedOK {
+		return fmt.Errorf("%w: task ID %d or task ID %d, operation: %s", domain.ErrNoRecordsFound, blockerID, blockedID, op)
+	}
+
+	if blockerID == blockedID {
+		return fmt.Errorf("%w: task cannot block itself, operation: %s", domain.ErrInputData, op)
+	}
+
+	// Цикл возникает, если blockedID уже прямо или через другие задачи блокирует blockerID
+	if path := d.db.dependencyPath(blockedID, blockerID); path != nil {
+		chain := make([]string, 0, len(path)+1)
+		for _, id := range path {
+			chain = append(chain, strconv.Itoa(id))
+		}
+		chain = append(chain, strconv.Itoa(blockedID))
+		return fmt.Errorf("%w: %s, operation: %s", domain.ErrDependencyCycle, strings.Join(chain, " -> "), op)
+	}
+
+	d.db.dependencies[dependencyKey{blockerID, blockedID}] = true
+
+	return nil
+}
+
+// Remove удаляет зависимость между задачами.
+func (d *DependencyManageMemory) Remove(ctx context.Context, blockerID, blockedID int) error {
+	const op = "memory.Dependency.Remove"
+
+	d.db.mu.Lock()
+	defer d.db.mu.Unlock()
+
+	key := dependencyKey{blockerID, blockedID}
+	if !d.db.dependencies[key] {
+		return fmt.Errorf("%w: task ID %d does not block task ID %d, operation: %s", domain.ErrNoRecordsFound, blockerID, blockedID, op)
+	}
+
+	delete(d.db.dependencies, key)
+
+	return nil
+}
+
+// Blockers возвращает задачи, которые блокируют задачу taskID, упорядоченные по ID.
+func (d *DependencyManageMemory) Blockers(ctx context.Context, taskID int) ([]entities.TaskRef, error) {
+	d.db.mu.RLock()
+	defer d.db.mu.RUnlock()
+
+	blockedBy, _ := d.db.taskDependencies(taskID)
+	return blockedBy, nil
+}
+
+// Blocked возвращает задачи, которые блокирует задача taskID, упорядоченные по ID.
+func (d *DependencyManageMemory) Blocked(ctx context.Context, taskID int) ([]entities.TaskRef, error) {
+	d.db.mu.RLock()
+	defer d.db.mu.RUnlock()
+
+	_, blocks := d.db.taskDependencies(taskID)
+	return blocks, nil
+}
+
+// dependencyPath ищет в ширину цепочку блокировок от задачи from до задачи to.
+// Возвращает ID задач цепочки от from до to или nil, если цепочки нет.
+func (db *DB) dependencyPath(from, to int) []int {
+	previous := map[int]int{from: 0}
+	queue := []int{from}
+
+	for len(queue) > 0 {
+		taskID := queue[0]
+		queue = queue[1:]
+
+		if taskID == to {
+			var path []int
+			for id := to; id != 0; id = previous[id] {
+				path = append([]int{id}, path...)
+			}
+			return path
+		}
+
+		_, blocks := db.taskDependencies(taskID)
+		for _, ref := range blocks {
+			if _, seen := previous[ref.ID]; !seen {
+				previous[ref.ID] = taskID
+				queue = append(queue, ref.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// taskDependencies возвращает задачи, которые блокируют задачу taskID, и задачи, которые блокирует она.
+func (db *DB) taskDependencies(taskID int) (blockedBy, blocks []entities.TaskRef) {
+	blockedBy, blocks = []entities.TaskRef{}, []entities.TaskRef{}
+	for key := range db.dependencies {
+		switch taskID {
+		case key.blockedID:
+			blockedBy = append(blockedBy, db.taskRef(key.blockerID))
+		case key.blockerID:
+			blocks = append(blocks, db.taskRef(key.blockedID))
+		}
+	}
+
+	sort.Slice(blockedBy, func(i, j int) bool { return blockedBy[i].ID < blockedBy[j].ID })
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].ID < blocks[j].ID })
+
+	return blockedBy, blocks
+}
+
+// taskRef возвращает краткие данные задачи.
+func (db *DB) taskRef(taskID int) entities.TaskRef {
+	row := db.tasks[taskID]
+	return entities.TaskRef{ID: row.id, Title: row.title, Status: row.status}
+}
@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"TaskSync/internal/entities"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DB хранилище в памяти, общее для всех реализаций пакета, аналог *sql.DB.
+// Повторяет ограничения схемы PostgreSQL: уникальность, внешние ключи и каскадное удаление.
+// Ошибки совпадают с ошибками пакета postgres, чтобы сервисы обрабатывали оба хранилища одинаково.
+type DB struct {
+	mu sync.RWMutex
+
+	people     map[int]*personRow
+	tasks      map[int]*taskRow
+	projects   map[int]*entities.Project
+	tags       map[int]*entities.Tag
+	entries    map[int]*entryRow
+	sessions   map[string]*entities.Session
+	apiKeys    map[int]*entities.APIKey
+	timesheets map[timesheetKey]*entities.Timesheet
+	// строки task_dependencies
+	dependencies map[dependencyKey]bool
+	assignments  map[int]*assignmentRow
+	recurrences  map[int]*recurrenceRow
+	checklist    map[int]*entities.ChecklistItem
+	templates    map[int]*entities.TaskTemplate
+
+	lastID map[string]int
+}
+
+// NewDB создает пустое хранилище в памяти.
+func NewDB() *DB {
+	return &DB{
+		people:       make(map[int]*personRow),
+		tasks:        make(map[int]*taskRow),
+		projects:     make(map[int]*entities.Project),
+		tags:         make(map[int]*entities.Tag),
+		entries:      make(map[int]*entryRow),
+		sessions:     make(map[string]*entities.Session),
+		apiKeys:      make(map[int]*entities.APIKey),
+		timesheets:   make(map[timesheetKey]*entities.Timesheet),
+		dependencies: make(map[dependencyKey]bool),
+		assignments:  make(map[int]*assignmentRow),
+		recurrences:  make(map[int]*recurrenceRow),
+		checklist:    make(map[int]*entities.ChecklistItem),
+		templates:    make(map[int]*entities.TaskTemplate),
+		lastID:       make(map[string]int),
+	}
+}
+
+// personRow строка people_info.
+type personRow struct {
+	entities.People
+	passwordHash string
+}
+
+// taskRow строка tasks. tagIDs - метки задачи, строки task_tags.
+type taskRow struct {
+	id          int
+	title       string
+	description string
+	status      entities.TaskStatus
+	projectID   int
+	parentID    int
+	dueDate     time.Time
+	estimate    time.Duration
+	tagIDs      map[int]bool
+}
+
+// entryRow строка time_entries. sessionID - первый отрезок сессии, 0 у самого первого отрезка.
+type entryRow struct {
+	entities.TimeEntry
+	sessionID int
+	paused    bool
+}
+
+// assignmentRow строка task_assignees. Нулевой unassignedAt у текущего назначения.
+type assignmentRow struct {
+	id           int
+	taskID       int
+	peopleID     int
+	assignedAt   time.Time
+	unassignedAt time.Time
+}
+
+// recurrenceRow строка recurrences вместе с исполнителями из recurrence_assignees.
+// runs - созданные повторения из recurrence_runs по времени в Unix-секундах.
+type recurrenceRow struct {
+	entities.Recurrence
+	runs map[int64]bool
+}
+
+type timesheetKey struct {
+	peopleID  int
+	weekStart string
+}
+
+type dependencyKey struct {
+	blockerID int
+	blockedID int
+}
+
+// nextID возвращает следующий ID последовательности таблицы, как SERIAL.
+func (db *DB) nextID(table string) int {
+	db.lastID[table]++
+	return db.lastID[table]
+}
+
+// sortedIDs возвращает ключи таблицы по возрастанию.
+func sortedIDs[T any](rows map[int]T) []int {
+	ids := make([]int, 0, len(rows))
+	for id := range rows {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// overlaps повторяет оператор && для tsrange(start, end): нулевой end - интервал без верхней границы,
+// интервал с совпадающими границами пуст и ни с чем не пересекается.
+func overlaps(aStart, aEnd, bStart, bEnd time.Time) bool {
+	if (!aEnd.IsZero() && !aStart.Before(aEnd)) || (!bEnd.IsZero() && !bStart.Before(bEnd)) {
+		return false
+	}
+	return (aEnd.IsZero() || bStart.Before(aEnd)) && (bEnd.IsZero() || aStart.Before(bEnd))
+}
+
+// utc приводит время к UTC, как TIMESTAMP без часового пояса.
+func utc(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return t.UTC()
+}
@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// paginate повторяет пагинацию по курсору хранилища PostgreSQL: сортирует записи по page.Sort,
+// пропускает записи до page.After включительно и ограничивает страницу page.Limit.
+// Возвращает страницу и общее число записей. fields - значения полей сортировки записи.
+func paginate[T any](items []T, page entities.PageRequest, fields map[string]func(T) any) ([]T, int, error) {
+	sort := page.Sort
+	if len(sort) == 0 {
+		sort = []entities.SortField{{Field: "id"}}
+	}
+
+	for _, field := range sort {
+		if _, ok := fields[field.Field]; !ok {
+			return nil, 0, fmt.Errorf("%w: unknown sort field %q", domain.ErrInputData, field.Field)
+		}
+	}
+	if len(page.After) != 0 && len(page.After) != len(sort) {
+		return nil, 0, fmt.Errorf("%w: cursor does not match sort", domain.ErrInputData)
+	}
+
+	// compareTo сравнивает запись со значениями полей сортировки в порядке страницы.
+	compareTo := func(item T, values func(i int) any) int {
+		for i, field := range sort {
+			c := compareValues(fields[field.Field](item), values(i))
+			if field.Desc {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	}
+
+	slices.SortStableFunc(items, func(a, b T) int {
+		return compareTo(a, func(i int) any { return fields[sort[i].Field](b) })
+	})
+
+	total := len(items)
+
+	if len(page.After) != 0 {
+		start, _ := slices.BinarySearchFunc(items, page.After, func(item T, after []any) int {
+			if compareTo(item, func(i int) any { return after[i] }) <= 0 {
+				return -1
+			}
+			return 1
+		})
+		items = items[start:]
+	}
+
+	if page.Limit > 0 && page.Limit < len(items) {
+		items = items[:page.Limit]
+	}
+
+	return items, total, nil
+}
+
+// compareValues сравнивает значения полей сортировки одного типа.
+func compareValues(a, b any) int {
+	switch a := a.(type) {
+	case int:
+		b, _ := b.(int)
+		return cmp.Compare(a, b)
+	case string:
+		b, _ := b.(string)
+		return cmp.Compare(a, b)
+	}
+	return 0
+}
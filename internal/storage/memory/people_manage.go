@@ -0,0 +1,270 @@
+package memory
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type PeopleManageMemory struct {
+	db *DB
+}
+
+func NewPeopleManage(db *DB) *PeopleManageMemory {
+	return &PeopleManageMemory{db: db}
+}
+
+func (p *PeopleManageMemory) Create(ctx context.Context, people entities.People) (int, error) {
+	const op = "memory.People.Create"
+
+	p.db.mu.Lock()
+	defer p.db.mu.Unlock()
+
+	if people.Role == "" {
+		people.Role = entities.RoleMember
+	}
+
+	if err := p.db.checkPeople(people, 0); err != nil {
+		return 0, fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	people.ID = p.db.nextID("people_info")
+	p.db.people[people.ID] = &personRow{People: people}
+
+	return people.ID, nil
+}
+
+func (p *PeopleManageMemory) GetByID(ctx context.Context, peopleID int) (entities.People, error) {
+	const op = "memory.People.Get"
+
+	p.db.mu.RLock()
+	defer p.db.mu.RUnlock()
+
+	row, ok := p.db.people[peopleID]
+	if !ok {
+		return entities.People{}, fmt.Errorf("%w: people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
+	}
+
+	return row.People, nil
+}
+
+// peopleSortFields поля сортировки списка пользователей.
+var peopleSortFields = map[string]func(entities.People) any{
+	"id":         func(p entities.People) any { return p.ID },
+	"surname":    func(p entities.People) any { return p.Surname },
+	"name":       func(p entities.People) any { return p.Name },
+	"patronymic": func(p entities.People) any { return p.Patronymic },
+}
+
+// GetByFilter возвращает страницу пользователей, у которых совпадают все заданные поля фильтра,
+// и общее число таких пользователей.
+func (p *PeopleManageMemory) GetByFilter(ctx context.Context, filterPeople entities.PeopleFilter, page entities.PageRequest) ([]entities.People, int, error) {
+	const op = "memory.People.GetByFilter"
+
+	p.db.mu.RLock()
+	defer p.db.mu.RUnlock()
+
+	var peopleList []entities.People
+
+	for _, id := range sortedIDs(p.db.people) {
+		people := p.db.people[id].People
+		if !matchPeople(people, filterPeople) {
+			continue
+		}
+		peopleList = append(peopleList, people)
+	}
+
+	peopleList, total, err := paginate(peopleList, page, peopleSortFields)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	return peopleList, total, nil
+}
+
+func matchPeople(people entities.People, filter entities.PeopleFilter) bool {
+	match := func(field, value, want string) bool {
+		return want == "" || matchString(filter.Match[field], value, want)
+	}
+
+	switch {
+	case filter.ID != 0 && people.ID != filter.ID,
+		filter.PassportSeries != 0 && people.PassportSeries != filter.PassportSeries,
+		filter.PassportNumber != 0 && people.PassportNumber != filter.PassportNumber,
+		!match("surname", people.Surname, filter.Surname),
+		!match("name", people.Name, filter.Name),
+		!match("patronymic", people.Patronymic, filter.Patronymic),
+		!match("address", people.Address, filter.Address),
+		filter.Role != "" && people.Role != filter.Role,
+		filter.ManagerID != 0 && people.ManagerID != filter.ManagerID:
+		return false
+	}
+	return true
+}
+
+// matchString сравнивает value с want способом op, как ILIKE в PostgreSQL.
+func matchString(op entities.MatchOp, value, want string) bool {
+	switch op {
+	case entities.MatchIExact:
+		return strings.EqualFold(value, want)
+	case entities.MatchPrefix:
+		return strings.HasPrefix(strings.ToLower(value), strings.ToLower(want))
+	case entities.MatchContains:
+		return strings.Contains(strings.ToLower(value), strings.ToLower(want))
+	}
+	return value == want
+}
+
+func (p *PeopleManageMemory) Update(ctx context.Context, people entities.People) error {
+	const op = "memory.People.Update"
+
+	if people.ID == 0 {
+		return fmt.Errorf("%w: missing ID, operation: %s", domain.ErrInputData, op)
+	}
+	if people.PassportSeries == 0 && people.PassportNumber == 0 && people.Surname == "" &&
+		people.Name == "" && people.Patronymic == "" && people.Address == "" &&
+		people.Role == "" && people.ManagerID == 0 {
+		return fmt.Errorf("%w: no values to update, operation: %s", domain.ErrInputData, op)
+	}
+
+	p.db.mu.Lock()
+	defer p.db.mu.Unlock()
+
+	row, ok := p.db.people[people.ID]
+	if !ok {
+		return fmt.Errorf("%w: people ID %d, operation: %s", domain.ErrNoRecordsFound, people.ID, op)
+	}
+
+	updated := row.People
+	if people.PassportSeries != 0 {
+		updated.PassportSeries = people.PassportSeries
+	}
+	if people.PassportNumber != 0 {
+		updated.PassportNumber = people.PassportNumber
+	}
+	if people.Surname != "" {
+		updated.Surname = people.Surname
+	}
+	if people.Name != "" {
+		updated.Name = people.Name
+	}
+	if people.Patronymic != "" {
+		updated.Patronymic = people.Patronymic
+	}
+	if people.Address != "" {
+		updated.Address = people.Address
+	}
+	if people.Role != "" {
+		updated.Role = people.Role
+	}
+	if people.ManagerID != 0 {
+		updated.ManagerID = people.ManagerID
+	}
+
+	if err := p.db.checkPeople(updated, updated.ID); err != nil {
+		return fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	row.People = updated
+
+	return nil
+}
+
+// List возвращает страницу всех пользователей и их общее число.
+func (p *PeopleManageMemory) List(ctx context.Context, page entities.PageRequest) ([]entities.People, int, error) {
+	return p.GetByFilter(ctx, entities.PeopleFilter{}, page)
+}
+
+// Delete удаляет пользователя. Как и в PostgreSQL, записи времени сохраняются без пользователя,
+// сессии, API ключи и табели пользователя удаляются, ссылки на него как на руководителя очищаются.
+func (p *PeopleManageMemory) Delete(ctx context.Context, peopleID int) error {
+	const op = "memory.People.Delete"
+
+	p.db.mu.Lock()
+	defer p.db.mu.Unlock()
+
+	if _, ok := p.db.people[peopleID]; !ok {
+		return fmt.Errorf("%w: people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
+	}
+
+	delete(p.db.people, peopleID)
+
+	for _, row := range p.db.people {
+		if row.ManagerID == peopleID {
+			row.ManagerID = 0
+		}
+	}
+	for _, entry := range p.db.entries {
+		if entry.PeopleID == peopleID {
+			entry.PeopleID = 0
+		}
+	}
+	for id, session := range p.db.sessions {
+		if session.PeopleID == peopleID {
+			delete(p.db.sessions, id)
+		}
+	}
+	for id, assignment := range p.db.assignments {
+		if assignment.peopleID == peopleID {
+			delete(p.db.assignments, id)
+		}
+	}
+	for _, rec := range p.db.recurrences {
+		rec.AssigneeIDs = slices.DeleteFunc(rec.AssigneeIDs, func(id int) bool { return id == peopleID })
+	}
+	for _, tpl := range p.db.templates {
+		if tpl.AssigneeID == peopleID {
+			tpl.AssigneeID = 0
+		}
+	}
+	for id, key := range p.db.apiKeys {
+		if key.PeopleID == peopleID {
+			delete(p.db.apiKeys, id)
+		}
+	}
+	for k, sheet := range p.db.timesheets {
+		if k.peopleID == peopleID {
+			delete(p.db.timesheets, k)
+			continue
+		}
+		if sheet.ReviewedBy == peopleID {
+			sheet.ReviewedBy = 0
+		}
+	}
+
+	return nil
+}
+
+// checkPeople повторяет ограничения people_info: длину паспортных данных,
+// уникальность паспорта, допустимую роль и существование руководителя.
+func (db *DB) checkPeople(people entities.People, selfID int) error {
+	if people.PassportSeries < 1000 || people.PassportSeries > 9999 {
+		return fmt.Errorf("%w: %w", domain.ErrInputData, domain.NewFieldError("passport_series", "must be a 4-digit number"))
+	}
+	if people.PassportNumber < 100000 || people.PassportNumber > 999999 {
+		return fmt.Errorf("%w: %w", domain.ErrInputData, domain.NewFieldError("passport_number", "must be a 6-digit number"))
+	}
+
+	switch people.Role {
+	case entities.RoleAdmin, entities.RoleManager, entities.RoleMember:
+	default:
+		return fmt.Errorf("%w: %w", domain.ErrInputData, domain.NewFieldError("role", fmt.Sprintf("unknown role %q", people.Role)))
+	}
+
+	if people.ManagerID != 0 {
+		if _, ok := db.people[people.ManagerID]; !ok {
+			return fmt.Errorf("%w: %w", domain.ErrInputData, domain.NewFieldError("manager_id", fmt.Sprintf("manager ID %d not found", people.ManagerID)))
+		}
+	}
+
+	for id, row := range db.people {
+		if id != selfID && row.PassportSeries == people.PassportSeries && row.PassportNumber == people.PassportNumber {
+			return fmt.Errorf("%w: passport %d %d", domain.ErrAlreadyExists, people.PassportSeries, people.PassportNumber)
+		}
+	}
+
+	return nil
+}
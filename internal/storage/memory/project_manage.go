@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"fmt"
+)
+
+type ProjectManageMemory struct {
+	db *DB
+}
+
+func NewProjectManage(db *DB) *ProjectManageMemory {
+	return &ProjectManageMemory{db: db}
+}
+
+func (p *ProjectManageMemory) Create(ctx context.Context, project entities.Project) (int, error) {
+	const op = "memory.Project.Create"
+
+	p.db.mu.Lock()
+	defer p.db.mu.Unlock()
+
+	if p.db.projectNameTaken(project.Name, 0) {
+		return 0, fmt.Errorf("%w: project %q, operation: %s", domain.ErrAlreadyExists, project.Name, op)
+	}
+
+	project.ID = p.db.nextID("projects")
+	p.db.projects[project.ID] = &project
+
+	return project.ID, nil
+}
+
+func (p *ProjectManageMemory) GetByID(ctx context.Context, projectID int) (entities.Project, error) {
+	const op = "memory.Project.GetByID"
+
+	p.db.mu.RLock()
+	defer p.db.mu.RUnlock()
+
+	project, ok := p.db.projects[projectID]
+	if !ok {
+		return entities.Project{}, fmt.Errorf("%w: project ID %d, operation: %s", domain.ErrNoRecordsFound, projectID, op)
+	}
+
+	return *project, nil
+}
+
+func (p *ProjectManageMemory) List(ctx context.Context) ([]entities.Project, error) {
+	p.db.mu.RLock()
+	defer p.db.mu.RUnlock()
+
+	var projects []entities.Project
+	for _, id := range sortedIDs(p.db.projects) {
+		projects = append(projects, *p.db.projects[id])
+	}
+
+	return projects, nil
+}
+
+func (p *ProjectManageMemory) Update(ctx context.Context, project entities.Project) error {
+	const op = "memory.Project.Update"
+
+	if project.ID == 0 {
+		return fmt.Errorf("%w: missing ID, operation: %s", domain.ErrInputData, op)
+	}
+	if project.Name == "" && project.Description == "" {
+		return fmt.Errorf("%w: no values to update, operation: %s", domain.ErrInputData, op)
+	}
+
+	p.db.mu.Lock()
+	defer p.db.mu.Unlock()
+
+	stored, ok := p.db.projects[project.ID]
+	if !ok {
+		return fmt.Errorf("%w: project ID %d, operation: %s", domain.ErrNoRecordsFound, project.ID, op)
+	}
+
+	if project.Name != "" {
+		if p.db.projectNameTaken(project.Name, project.ID) {
+			return fmt.Errorf("%w: project %q, operation: %s", domain.ErrAlreadyExists, project.Name, op)
+		}
+		stored.Name = project.Name
+	}
+	if project.Description != "" {
+		stored.Description = project.Description
+	}
+
+	return nil
+}
+
+// Delete удаляет проект, задачи проекта сохраняются без проекта.
+func (p *ProjectManageMemory) Delete(ctx context.Context, projectID int) error {
+	const op = "memory.Project.Delete"
+
+	p.db.mu.Lock()
+	defer p.db.mu.Unlock()
+
+	if _, ok := p.db.projects[projectID]; !ok {
+		return fmt.Errorf("%w: project ID %d, operation: %s", domain.ErrNoRecordsFound, projectID, op)
+	}
+
+	delete(p.db.projects, projectID)
+
+	for _, task := range p.db.tasks {
+		if task.projectID == projectID {
+			task.projectID = 0
+		}
+	}
+	for _, rec := range p.db.recurrences {
+		if rec.ProjectID == projectID {
+			rec.ProjectID = 0
+		}
+	}
+	for _, tpl := range p.db.templates {
+		if tpl.ProjectID == projectID {
+			tpl.ProjectID = 0
+		}
+	}
+
+	return nil
+}
+
+func (db *DB) projectNameTaken(name string, selfID int) bool {
+	for id, project := range db.projects {
+		if id != selfID && project.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package memory
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+type RecurrenceManageMemory struct {
+	db *DB
+}
+
+func NewRecurrenceManage(db *DB) *RecurrenceManageMemory {
+	return &RecurrenceManageMemory{db: db}
+}
+
+// Create сохраняет повторяющуюся задачу вместе с её исполнителями.
+func (r *RecurrenceManageMemory) Create(ctx context.Context, rec entities.Recurrence) (int, error) {
+	const op = "memory.Recurrence.Create"
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if rec.ProjectID != 0 {
+		if _, ok := r.db.projects[rec.ProjectID]; !ok {
+			return 0, fmt.Errorf("%w: project ID %d not found, operation: %s", domain.ErrInputData, rec.ProjectID, op)
+		}
+	}
+
+	var assignees []int
+	for _, peopleID := range rec.AssigneeIDs {
+		if _, ok := r.db.people[peopleID]; !ok {
+			return 0, fmt.Errorf("%w: people ID %d not found, operation: %s", domain.ErrInputData, peopleID, op)
+		}
+		if !slices.Contains(assignees, peopleID) {
+			assignees = append(assignees, peopleID)
+		}
+	}
+	sort.Ints(assignees)
+
+	rec.ID = r.db.nextID("recurrences")
+	rec.AssigneeIDs = assignees
+	rec.Start = utc(rec.Start)
+	rec.NextRun = utc(rec.NextRun)
+	rec.LastRun = time.Time{}
+	rec.SetEstimate(time.Duration(rec.EstimateSeconds) * time.Second)
+	r.db.recurrences[rec.ID] = &recurrenceRow{Recurrence: rec, runs: make(map[int64]bool)}
+
+	return rec.ID, nil
+}
+
+func (r *RecurrenceManageMemory) GetByID(ctx context.Context, recurrenceID int) (entities.Recurrence, error) {
+	const op = "memory.Recurrence.GetByID"
+
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	row, ok := r.db.recurrences[recurrenceID]
+	if !ok {
+		return entities.Recurrence{}, fmt.Errorf("%w: recurrence ID %d, operation: %s", domain.ErrNoRecordsFound, recurrenceID, op)
+	}
+
+	return row.recurrence(), nil
+}
+
+// List возвращает все повторяющиеся задачи в порядке создания.
+func (r *RecurrenceManageMemory) List(ctx context.Context) ([]entities.Recurrence, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	recs := []entities.Recurrence{}
+	for _, id := range sortedIDs(r.db.recurrences) {
+		recs = append(recs, r.db.recurrences[id].recurrence())
+	}
+
+	return recs, nil
+}
+
+// Due возвращает повторяющиеся задачи, следующее повторение которых наступило к моменту at.
+func (r *RecurrenceManageMemory) Due(ctx context.Context, at time.Time) ([]entities.Recurrence, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	recs := []entities.Recurrence{}
+	for _, id := range sortedIDs(r.db.recurrences) {
+		row := r.db.recurrences[id]
+		if !row.NextRun.IsZero() && !row.NextRun.After(at) {
+			recs = append(recs, row.recurrence())
+		}
+	}
+
+	sort.SliceStable(recs, func(i, j int) bool { return recs[i].NextRun.Before(recs[j].NextRun) })
+
+	return recs, nil
+}
+
+// Delete удаляет повторяющуюся задачу, уже созданные задачи остаются.
+func (r *RecurrenceManageMemory) Delete(ctx context.Context, recurrenceID int) error {
+	const op = "memory.Recurrence.Delete"
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.recurrences[recurrenceID]; !ok {
+		return fmt.Errorf("%w: recurrence ID %d, operation: %s", domain.ErrNoRecordsFound, recurrenceID, op)
+	}
+
+	delete(r.db.recurrences, recurrenceID)
+
+	return nil
+}
+
+// Materialize создаёт задачу повторения occurrence вместе с исполнителями повторяющейся задачи
+// и переносит следующее повторение на nextRun, нулевой nextRun завершает расписание.
+// Повторение создаётся не более одного раза: если оно уже создано, возвращается 0 без ошибки.
+func (r *RecurrenceManageMemory) Materialize(ctx context.Context, recurrenceID int, occurrence time.Time, task entities.Task, nextRun time.Time) (int, error) {
+	const op = "memory.Recurrence.Materialize"
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	row, ok := r.db.recurrences[recurrenceID]
+	if !ok {
+		return 0, fmt.Errorf("%w: recurrence ID %d, operation: %s", domain.ErrNoRecordsFound, recurrenceID, op)
+	}
+
+	occurrence = utc(occurrence)
+	if row.runs[occurrence.Unix()] {
+		return 0, nil
+	}
+
+	if task.ProjectID != 0 {
+		if _, ok := r.db.projects[task.ProjectID]; !ok {
+			return 0, fmt.Errorf("%w: project ID %d not found, operation: %s", domain.ErrInputData, task.ProjectID, op)
+		}
+	}
+
+	if task.Status == "" {
+		task.Status = entities.StatusTodo
+	}
+
+	id := r.db.nextID("tasks")
+	r.db.tasks[id] = &taskRow{id: id, title: task.Title, description: task.Description, status: task.Status, projectID: task.ProjectID,
+		dueDate: utc(task.DueDate), estimate: time.Duration(task.EstimateSeconds) * time.Second, tagIDs: make(map[int]bool)}
+
+	now := time.Now()
+	for _, peopleID := range row.AssigneeIDs {
+		r.db.assign(id, peopleID, now)
+	}
+
+	row.runs[occurrence.Unix()] = true
+	row.NextRun = utc(nextRun)
+	row.LastRun = occurrence
+
+	return id, nil
+}
+
+// recurrence возвращает копию повторяющейся задачи, не связанную с хранилищем.
+func (row *recurrenceRow) recurrence() entities.Recurrence {
+	rec := row.Recurrence
+	rec.AssigneeIDs = append([]int{}, row.AssigneeIDs...)
+	return rec
+}
@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"TaskSync/internal/entities"
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+type SearchManageMemory struct {
+	db *DB
+}
+
+func NewSearchManage(db *DB) *SearchManageMemory {
+	return &SearchManageMemory{db: db}
+}
+
+// Search ищет пользователей по ФИО и задачи по заголовку и описанию.
+// Как и в PostgreSQL, каждый из terms должен совпадать с началом какого-либо слова записи.
+// Релевантность - доля совпавших слов, слова заголовка задачи весят больше слов описания.
+func (s *SearchManageMemory) Search(ctx context.Context, terms []string, limit int) ([]entities.SearchResult, error) {
+	s.db.mu.RLock()
+	defer s.db.mu.RUnlock()
+
+	var results []entities.SearchResult
+
+	for _, id := range sortedIDs(s.db.people) {
+		people := s.db.people[id].People
+		title := strings.Join(strings.Fields(people.Surname+" "+people.Name+" "+people.Patronymic), " ")
+		if rank, ok := searchRank(terms, searchField{title, 1}); ok {
+			results = append(results, entities.SearchResult{Type: entities.SearchPeople, ID: id, Title: title, Rank: rank})
+		}
+	}
+
+	for _, id := range sortedIDs(s.db.tasks) {
+		task := s.db.tasks[id]
+		if rank, ok := searchRank(terms, searchField{task.title, 1}, searchField{task.description, 0.4}); ok {
+			results = append(results, entities.SearchResult{Type: entities.SearchTask, ID: id, Title: task.title, Rank: rank})
+		}
+	}
+
+	slices.SortStableFunc(results, func(a, b entities.SearchResult) int {
+		switch {
+		case a.Rank > b.Rank:
+			return -1
+		case a.Rank < b.Rank:
+			return 1
+		}
+		return strings.Compare(string(a.Type), string(b.Type))
+	})
+
+	if limit > 0 && limit < len(results) {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+// searchField текст поля записи и вес его слов в релевантности.
+type searchField struct {
+	text   string
+	weight float64
+}
+
+// searchRank возвращает долю совпавших слов полей с учётом весов.
+// false, если какой-либо из terms не совпал с началом ни одного слова.
+func searchRank(terms []string, fields ...searchField) (float64, bool) {
+	matched := make([]bool, len(terms))
+	var score, total float64
+
+	for _, field := range fields {
+		words := strings.FieldsFunc(strings.ToLower(field.text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+
+		for _, word := range words {
+			total += field.weight
+			for i, term := range terms {
+				if strings.HasPrefix(word, term) {
+					matched[i] = true
+					score += field.weight
+					break
+				}
+			}
+		}
+	}
+
+	if total == 0 || slices.Contains(matched, false) {
+		return 0, false
+	}
+
+	return score / total, true
+}
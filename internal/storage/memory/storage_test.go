@@ -0,0 +1,13 @@
+package memory_test
+
+import (
+	"TaskSync/internal/storage"
+	"TaskSync/internal/storage/storagetest"
+	"testing"
+)
+
+func TestStorage(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) *storage.Storage {
+		return storage.NewMemoryStorage()
+	})
+}
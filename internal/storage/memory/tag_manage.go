@@ -0,0 +1,166 @@
+package memory
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+)
+
+type TagManageMemory struct {
+	db *DB
+}
+
+func NewTagManage(db *DB) *TagManageMemory {
+	return &TagManageMemory{db: db}
+}
+
+func (t *TagManageMemory) Create(ctx context.Context, tag entities.Tag) (int, error) {
+	const op = "memory.Tag.Create"
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	if t.db.tagNameTaken(tag.Name, 0) {
+		return 0, fmt.Errorf("%w: tag %q, operation: %s", domain.ErrAlreadyExists, tag.Name, op)
+	}
+
+	tag.ID = t.db.nextID("tags")
+	t.db.tags[tag.ID] = &tag
+
+	return tag.ID, nil
+}
+
+func (t *TagManageMemory) GetByID(ctx context.Context, tagID int) (entities.Tag, error) {
+	const op = "memory.Tag.GetByID"
+
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	tag, ok := t.db.tags[tagID]
+	if !ok {
+		return entities.Tag{}, fmt.Errorf("%w: tag ID %d, operation: %s", domain.ErrNoRecordsFound, tagID, op)
+	}
+
+	return *tag, nil
+}
+
+// List возвращает все метки в порядке имени.
+func (t *TagManageMemory) List(ctx context.Context) ([]entities.Tag, error) {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	var tags []entities.Tag
+	for _, tag := range t.db.tags {
+		tags = append(tags, *tag)
+	}
+	sortTags(tags)
+
+	return tags, nil
+}
+
+// Update переименовывает метку, новое имя применяется ко всем задачам с этой меткой.
+func (t *TagManageMemory) Update(ctx context.Context, tag entities.Tag) error {
+	const op = "memory.Tag.Update"
+
+	if tag.ID == 0 || tag.Name == "" {
+		return fmt.Errorf("%w: missing ID or name, operation: %s", domain.ErrInputData, op)
+	}
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	stored, ok := t.db.tags[tag.ID]
+	if !ok {
+		return fmt.Errorf("%w: tag ID %d, operation: %s", domain.ErrNoRecordsFound, tag.ID, op)
+	}
+
+	if t.db.tagNameTaken(tag.Name, tag.ID) {
+		return fmt.Errorf("%w: tag %q, operation: %s", domain.ErrAlreadyExists, tag.Name, op)
+	}
+	stored.Name = tag.Name
+
+	return nil
+}
+
+// Delete удаляет метку и снимает её со всех задач.
+func (t *TagManageMemory) Delete(ctx context.Context, tagID int) error {
+	const op = "memory.Tag.Delete"
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	if _, ok := t.db.tags[tagID]; !ok {
+		return fmt.Errorf("%w: tag ID %d, operation: %s", domain.ErrNoRecordsFound, tagID, op)
+	}
+
+	delete(t.db.tags, tagID)
+
+	for _, task := range t.db.tasks {
+		delete(task.tagIDs, tagID)
+	}
+
+	return nil
+}
+
+// AttachTag назначает метку задаче, повторное назначение не считается ошибкой.
+func (t *TagManageMemory) AttachTag(ctx context.Context, taskID, tagID int) error {
+	const op = "memory.Tag.AttachTag"
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	task, ok := t.db.tasks[taskID]
+	if _, tagOK := t.db.tags[tagID]; !ok || !tagOK {
+		return fmt.Errorf("%w: task ID %d or tag ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, tagID, op)
+	}
+
+	task.tagIDs[tagID] = true
+
+	return nil
+}
+
+// DetachTag снимает метку с задачи.
+func (t *TagManageMemory) DetachTag(ctx context.Context, taskID, tagID int) error {
+	const op = "memory.Tag.DetachTag"
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	task, ok := t.db.tasks[taskID]
+	if !ok || !task.tagIDs[tagID] {
+		return fmt.Errorf("%w: tag ID %d on task ID %d, operation: %s", domain.ErrNoRecordsFound, tagID, taskID, op)
+	}
+
+	delete(task.tagIDs, tagID)
+
+	return nil
+}
+
+func (db *DB) tagNameTaken(name string, selfID int) bool {
+	for id, tag := range db.tags {
+		if id != selfID && tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTag сообщает, назначена ли задаче метка с именем name.
+func (db *DB) hasTag(task *taskRow, name string) bool {
+	for tagID := range task.tagIDs {
+		if db.tags[tagID].Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// sortTags упорядочивает метки по имени, как ORDER BY name, id.
+func sortTags(tags []entities.Tag) {
+	slices.SortFunc(tags, func(a, b entities.Tag) int {
+		return cmp.Or(cmp.Compare(a.Name, b.Name), cmp.Compare(a.ID, b.ID))
+	})
+}
@@ -0,0 +1,443 @@
+package memory
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"fmt"
+	"time"
+)
+
+type TaskManageMemory struct {
+	db *DB
+}
+
+func NewTaskManage(db *DB) *TaskManageMemory {
+	return &TaskManageMemory{db: db}
+}
+
+// Create создает задачу и, если задан исполнитель, запись о времени по ней.
+// Изменения записей, пересекающихся с записью о времени, применяются вместе с ней.
+func (t *TaskManageMemory) Create(ctx context.Context, task entities.Task, overlaps entities.OverlapChanges) (int, error) {
+	const op = "memory.Task.Create"
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	if task.ProjectID != 0 {
+		if _, ok := t.db.projects[task.ProjectID]; !ok {
+			return 0, fmt.Errorf("%w: project ID %d not found, operation: %s", domain.ErrInputData, task.ProjectID, op)
+		}
+	}
+
+	if task.ParentID != 0 {
+		if _, ok := t.db.tasks[task.ParentID]; !ok {
+			return 0, fmt.Errorf("%w: parent task ID %d not found, operation: %s", domain.ErrInputData, task.ParentID, op)
+		}
+	}
+
+	if task.Status == "" {
+		task.Status = entities.StatusTodo
+	}
+
+	// Без исполнителя запись о времени не создаётся
+	var entry *entryRow
+	if task.TimeEntry.PeopleID != 0 {
+		entry = &entryRow{TimeEntry: entities.TimeEntry{
+			PeopleID:  task.TimeEntry.PeopleID,
+			StartTime: utc(task.TimeEntry.StartTime),
+			EndTime:   utc(task.TimeEntry.EndTime),
+			Overlaps:  len(overlaps.Flag) > 0,
+		}}
+
+		rollback, err := t.db.applyOverlaps(overlaps, task.TimeEntry.StartTime)
+		if err != nil {
+			return 0, fmt.Errorf("overlaps error: %w, operation: %s", err, op)
+		}
+
+		if err := t.db.checkEntry(entry); err != nil {
+			rollback()
+			return 0, fmt.Errorf("%w, operation: %s", err, op)
+		}
+	}
+
+	id := t.db.nextID("tasks")
+	t.db.tasks[id] = &taskRow{id: id, title: task.Title, description: task.Description, status: task.Status, projectID: task.ProjectID, parentID: task.ParentID,
+		dueDate: utc(task.DueDate), estimate: time.Duration(task.EstimateSeconds) * time.Second, tagIDs: make(map[int]bool)}
+
+	for i, item := range task.Checklist {
+		t.db.addChecklistItem(id, i+1, item.Title)
+	}
+
+	if entry != nil {
+		entry.TaskID = id
+		t.db.insertEntry(entry)
+		// Пользователь из записи о времени становится исполнителем задачи
+		t.db.assign(id, entry.PeopleID, time.Now())
+	}
+
+	return id, nil
+}
+
+// GetByID возвращает задачу, с subtree - вместе со всеми подзадачами и временем по поддереву.
+func (t *TaskManageMemory) GetByID(ctx context.Context, taskID int, subtree bool) (entities.Task, error) {
+	const op = "memory.Task.GetByID"
+
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	row, ok := t.db.tasks[taskID]
+	if !ok {
+		return entities.Task{}, fmt.Errorf("%w: task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
+	}
+
+	task := t.db.task(row)
+	task.SetChecklist(t.db.taskChecklist(taskID))
+	if subtree {
+		t.db.buildSubtree(&task)
+	}
+
+	return task, nil
+}
+
+// buildSubtree собирает поддерево задачи и время завершённых сессий по каждой задаче поддерева.
+func (db *DB) buildSubtree(task *entities.Task) {
+	inSubtree := map[int]bool{task.ID: true}
+	var descendants []entities.Task
+	// Родитель может иметь больший ID, чем подзадача, поэтому обход повторяется, пока находятся новые потомки
+	for found := true; found; {
+		found = false
+		for _, id := range sortedIDs(db.tasks) {
+			row := db.tasks[id]
+			if !inSubtree[id] && inSubtree[row.parentID] {
+				inSubtree[id] = true
+				found = true
+			}
+		}
+	}
+	for _, id := range sortedIDs(db.tasks) {
+		if id != task.ID && inSubtree[id] {
+			descendants = append(descendants, db.task(db.tasks[id]))
+		}
+	}
+
+	spent := make(map[int]time.Duration)
+	for _, entry := range db.entries {
+		if inSubtree[entry.TaskID] && !entry.StartTime.IsZero() && !entry.EndTime.IsZero() {
+			spent[entry.TaskID] += entry.Duration()
+		}
+	}
+
+	task.BuildSubtree(descendants, spent)
+}
+
+// taskSortFields поля сортировки списка задач.
+var taskSortFields = map[string]func(entities.Task) any{
+	"id":         func(t entities.Task) any { return t.ID },
+	"title":      func(t entities.Task) any { return t.Title },
+	"status":     func(t entities.Task) any { return string(t.Status) },
+	"project_id": func(t entities.Task) any { return t.ProjectID },
+}
+
+// List возвращает страницу задач, удовлетворяющих фильтру, и общее число таких задач.
+func (t *TaskManageMemory) List(ctx context.Context, filter entities.TaskFilter, page entities.PageRequest) ([]entities.Task, int, error) {
+	const op = "memory.Task.List"
+
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	var taskList []entities.Task
+	for _, id := range sortedIDs(t.db.tasks) {
+		row := t.db.tasks[id]
+		if filter.ProjectID != 0 && row.projectID != filter.ProjectID {
+			continue
+		}
+		if filter.ParentID != 0 && row.parentID != filter.ParentID {
+			continue
+		}
+		if filter.Tag != "" && !t.db.hasTag(row, filter.Tag) {
+			continue
+		}
+		taskList = append(taskList, t.db.task(row))
+	}
+
+	taskList, total, err := paginate(taskList, page, taskSortFields)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	return taskList, total, nil
+}
+
+func (t *TaskManageMemory) Update(ctx context.Context, taskID int, title string, description string) error {
+	const op = "memory.Task.Update"
+
+	if title == "" && description == "" {
+		return fmt.Errorf("%w: no values to update, operation: %s", domain.ErrInputData, op)
+	}
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	row, ok := t.db.tasks[taskID]
+	if !ok {
+		return fmt.Errorf("%w: task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
+	}
+
+	if title != "" {
+		row.title = title
+	}
+	if description != "" {
+		row.description = description
+	}
+
+	return nil
+}
+
+// UpdatePeople назначает исполнителя во всех записях времени задачи.
+// UpdatePeople делает пользователя единственным исполнителем задачи: остальные назначения снимаются
+// и остаются в истории. Заготовки сессий без начала переходят к новому исполнителю,
+// начатые сессии остаются за тем, кто над ними работал.
+func (t *TaskManageMemory) UpdatePeople(ctx context.Context, peopleID, taskID int) error {
+	const op = "memory.Task.UpdatePeople"
+
+	if peopleID <= 0 || taskID <= 0 {
+		return fmt.Errorf("%w: incorrect values or their absence, operation: %s", domain.ErrInputData, op)
+	}
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	if _, ok := t.db.tasks[taskID]; !ok {
+		return fmt.Errorf("%w: task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
+	}
+
+	if _, ok := t.db.people[peopleID]; !ok {
+		return fmt.Errorf("%w: people ID %d not found, operation: %s", domain.ErrInputData, peopleID, op)
+	}
+
+	now := time.Now()
+	for _, assignment := range t.db.assignments {
+		if assignment.taskID == taskID && assignment.peopleID != peopleID && assignment.unassignedAt.IsZero() {
+			assignment.unassignedAt = latest(utc(now), assignment.assignedAt)
+		}
+	}
+	t.db.assign(taskID, peopleID, now)
+
+	for _, entry := range t.db.entries {
+		if entry.TaskID == taskID && entry.StartTime.IsZero() {
+			entry.PeopleID = peopleID
+		}
+	}
+
+	return nil
+}
+
+// UpdateProject переносит задачу в проект, нулевой projectID убирает задачу из проекта.
+func (t *TaskManageMemory) UpdateProject(ctx context.Context, projectID, taskID int) error {
+	const op = "memory.Task.UpdateProject"
+
+	if projectID < 0 || taskID <= 0 {
+		return fmt.Errorf("%w: incorrect values or their absence, operation: %s", domain.ErrInputData, op)
+	}
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	if projectID != 0 {
+		if _, ok := t.db.projects[projectID]; !ok {
+			return fmt.Errorf("%w: project ID %d not found, operation: %s", domain.ErrInputData, projectID, op)
+		}
+	}
+
+	row, ok := t.db.tasks[taskID]
+	if !ok {
+		return fmt.Errorf("%w: task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
+	}
+
+	row.projectID = projectID
+
+	return nil
+}
+
+// UpdateSchedule задаёт срок выполнения и оценку задачи, нулевые значения их снимают.
+func (t *TaskManageMemory) UpdateSchedule(ctx context.Context, taskID int, dueDate time.Time, estimate time.Duration) error {
+	const op = "memory.Task.UpdateSchedule"
+
+	if taskID <= 0 || estimate < 0 {
+		return fmt.Errorf("%w: incorrect values or their absence, operation: %s", domain.ErrInputData, op)
+	}
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	row, ok := t.db.tasks[taskID]
+	if !ok {
+		return fmt.Errorf("%w: task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
+	}
+
+	// Оценка хранится с точностью до секунды, как estimate_seconds
+	row.dueDate = utc(dueDate)
+	row.estimate = estimate.Truncate(time.Second)
+
+	return nil
+}
+
+// UpdateParent переносит задачу под задачу parentID, нулевой parentID делает её задачей верхнего уровня.
+// Перенос под саму задачу или любую её подзадачу образовал бы цикл и отклоняется.
+func (t *TaskManageMemory) UpdateParent(ctx context.Context, parentID, taskID int) error {
+	const op = "memory.Task.UpdateParent"
+
+	if parentID < 0 || taskID <= 0 {
+		return fmt.Errorf("%w: incorrect values or their absence, operation: %s", domain.ErrInputData, op)
+	}
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	// Цикл возникает, если переносимая задача - предок нового родителя или сам родитель
+	for id := parentID; id != 0; id = t.db.tasks[id].parentID {
+		if id == taskID {
+			return fmt.Errorf("%w: task ID %d under task ID %d, operation: %s", domain.ErrTaskCycle, taskID, parentID, op)
+		}
+		if _, ok := t.db.tasks[id]; !ok {
+			return fmt.Errorf("%w: parent task ID %d not found, operation: %s", domain.ErrInputData, parentID, op)
+		}
+	}
+
+	row, ok := t.db.tasks[taskID]
+	if !ok {
+		return fmt.Errorf("%w: task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
+	}
+
+	row.parentID = parentID
+
+	return nil
+}
+
+// UpdateStatus переводит задачу из статуса from в статус to.
+// Если статус задачи уже изменился, обновление не выполняется.
+// При ненулевом timer.PeopleID под той же блокировкой запускается таймер пользователя по задаче,
+// так что при отказе не остаётся ни новой сессии, ни завершённой приостановленной.
+func (t *TaskManageMemory) UpdateStatus(ctx context.Context, taskID int, from, to entities.TaskStatus, timer entities.TimerStart) error {
+	const op = "memory.Task.UpdateStatus"
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	row, ok := t.db.tasks[taskID]
+	if !ok || row.status != from {
+		return fmt.Errorf("%w: task ID %d with status %q, operation: %s", domain.ErrNoRecordsFound, taskID, from, op)
+	}
+
+	if timer.PeopleID != 0 {
+		if _, err := t.db.startEntry(taskID, timer.PeopleID, timer.StartTime, timer.Overlaps); err != nil {
+			return fmt.Errorf("%w, operation: %s", err, op)
+		}
+	}
+
+	row.status = to
+
+	return nil
+}
+
+// Delete удаляет задачу вместе с её записями времени, подзадачи остаются без родителя.
+func (t *TaskManageMemory) Delete(ctx context.Context, taskID int) error {
+	const op = "memory.Task.Delete"
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	if _, ok := t.db.tasks[taskID]; !ok {
+		return fmt.Errorf("%w: task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
+	}
+
+	delete(t.db.tasks, taskID)
+
+	for key := range t.db.dependencies {
+		if key.blockerID == taskID || key.blockedID == taskID {
+			delete(t.db.dependencies, key)
+		}
+	}
+
+	for id, assignment := range t.db.assignments {
+		if assignment.taskID == taskID {
+			delete(t.db.assignments, id)
+		}
+	}
+
+	for id, item := range t.db.checklist {
+		if item.TaskID == taskID {
+			delete(t.db.checklist, id)
+		}
+	}
+
+	// Подзадачи становятся задачами верхнего уровня
+	for _, row := range t.db.tasks {
+		if row.parentID == taskID {
+			row.parentID = 0
+		}
+	}
+
+	for id, entry := range t.db.entries {
+		if entry.TaskID == taskID {
+			delete(t.db.entries, id)
+		}
+	}
+
+	return nil
+}
+
+// task собирает задачу вместе с её метками, зависимостями, исполнителями и последней сессией.
+func (db *DB) task(row *taskRow) entities.Task {
+	task := entities.Task{
+		ID:          row.id,
+		Title:       row.title,
+		Description: row.description,
+		Status:      row.status,
+		ProjectID:   row.projectID,
+		ParentID:    row.parentID,
+		DueDate:     row.dueDate,
+		Tags:        []entities.Tag{},
+	}
+	task.SetEstimate(row.estimate)
+
+	for tagID := range row.tagIDs {
+		task.Tags = append(task.Tags, *db.tags[tagID])
+	}
+	sortTags(task.Tags)
+
+	task.BlockedBy, task.Blocks = db.taskDependencies(row.id)
+
+	task.Assignees = []entities.TaskAssignment{}
+	for _, assignment := range db.taskAssignments(row.id) {
+		if assignment.Current() {
+			task.Assignees = append(task.Assignees, assignment)
+		}
+	}
+
+	var latest *entryRow
+	for _, entry := range db.entries {
+		if entry.TaskID != row.id {
+			continue
+		}
+		if latest == nil || entry.Created.After(latest.Created) ||
+			(entry.Created.Equal(latest.Created) && entry.ID > latest.ID) {
+			latest = entry
+		}
+	}
+
+	if latest != nil {
+		task.TimeEntry = latest.TimeEntry
+		task.TimeEntry.Overlaps = false
+	}
+
+	return task
+}
+
+// insertEntry сохраняет новую запись времени с очередным ID.
+func (db *DB) insertEntry(entry *entryRow) {
+	entry.ID = db.nextID("time_entries")
+	entry.Created = time.Now().UTC()
+	db.entries[entry.ID] = entry
+}
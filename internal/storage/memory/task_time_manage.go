@@ -0,0 +1,638 @@
+package memory
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+type TimeManageMemory struct {
+	db *DB
+}
+
+func NewTimeManage(db *DB) *TimeManageMemory {
+	return &TimeManageMemory{db: db}
+}
+
+// StartTimeEntry открывает новую сессию работы пользователя над задачей и возвращает её ID.
+// Если у пользователя есть запись по задаче без start_time (создана вместе с задачей), сессия открывается в ней.
+// Приостановленная сессия пользователя при этом считается завершённой.
+// Изменения пересекающихся записей применяются вместе с новой записью, при пометке помечается и новая запись.
+func (t *TimeManageMemory) StartTimeEntry(ctx context.Context, taskID, peopleID int, startTime time.Time, overlaps entities.OverlapChanges) (int, error) {
+	const op = "memory.Time.StartTimeEntry"
+
+	if peopleID <= 0 || taskID <= 0 {
+		return 0, fmt.Errorf("%w, operation: %s", domain.ErrInputData, op)
+	}
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	if _, ok := t.db.tasks[taskID]; !ok {
+		return 0, fmt.Errorf("%w: task ID %d not found, operation: %s", domain.ErrInputData, taskID, op)
+	}
+
+	id, err := t.db.startEntry(taskID, peopleID, startTime, overlaps)
+	if err != nil {
+		return 0, fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	return id, nil
+}
+
+// startEntry открывает сессию под блокировкой db.mu: применяет изменения пересекающихся записей,
+// завершает приостановленную сессию пользователя и заполняет пустую запись по задаче или создаёт новую.
+func (db *DB) startEntry(taskID, peopleID int, startTime time.Time, overlaps entities.OverlapChanges) (int, error) {
+	// Пустая запись, созданная вместе с задачей
+	var empty *entryRow
+	for _, id := range sortedIDs(db.entries) {
+		entry := db.entries[id]
+		if entry.TaskID == taskID && entry.PeopleID == peopleID && entry.StartTime.IsZero() {
+			empty = entry
+			break
+		}
+	}
+
+	entry := &entryRow{TimeEntry: entities.TimeEntry{TaskID: taskID, PeopleID: peopleID}}
+	if empty != nil {
+		copied := *empty
+		entry = &copied
+	}
+	entry.StartTime = utc(startTime)
+	entry.Overlaps = len(overlaps.Flag) > 0
+
+	rollback, err := db.applyOverlaps(overlaps, startTime)
+	if err != nil {
+		return 0, fmt.Errorf("overlaps error: %w", err)
+	}
+
+	if err := db.checkEntry(entry); err != nil {
+		rollback()
+		return 0, fmt.Errorf("%w for task ID %d", err, taskID)
+	}
+
+	// Завершение приостановленной сессии
+	for _, e := range db.entries {
+		if e.PeopleID == peopleID && e.paused {
+			e.paused = false
+		}
+	}
+
+	if empty != nil {
+		*empty = *entry
+		return empty.ID, nil
+	}
+
+	db.insertEntry(entry)
+
+	return entry.ID, nil
+}
+
+// EndTimeEntry закрывает открытую или приостановленную сессию пользователя по задаче.
+// У приостановленной сессии время последнего отрезка не меняется.
+func (t *TimeManageMemory) EndTimeEntry(ctx context.Context, taskID, peopleID int, endTime time.Time) error {
+	const op = "memory.Time.EndTimeEntry"
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	endTime = utc(endTime)
+
+	var open []*entryRow
+	for _, entry := range t.db.entries {
+		if entry.TaskID == taskID && entry.PeopleID == peopleID && !entry.StartTime.IsZero() &&
+			(entry.EndTime.IsZero() || entry.paused) {
+			if entry.EndTime.IsZero() && endTime.Before(entry.StartTime) {
+				return fmt.Errorf("%w: end time before start time, operation: %s", domain.ErrInputData, op)
+			}
+			open = append(open, entry)
+		}
+	}
+
+	if len(open) == 0 {
+		return fmt.Errorf("%w: no open time entry for task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
+	}
+
+	for _, entry := range open {
+		if entry.EndTime.IsZero() {
+			entry.EndTime = endTime
+		}
+		entry.paused = false
+	}
+
+	return nil
+}
+
+// EndTaskTimeEntries закрывает все открытые и приостановленные сессии по задаче.
+func (t *TimeManageMemory) EndTaskTimeEntries(ctx context.Context, taskID int, endTime time.Time) error {
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	endTime = utc(endTime)
+
+	for _, entry := range t.db.entries {
+		if entry.TaskID != taskID || entry.StartTime.IsZero() || !(entry.EndTime.IsZero() || entry.paused) {
+			continue
+		}
+		if entry.EndTime.IsZero() {
+			entry.EndTime = endTime
+			if endTime.Before(entry.StartTime) {
+				entry.EndTime = entry.StartTime
+			}
+		}
+		entry.paused = false
+	}
+
+	return nil
+}
+
+// PauseTimeEntry приостанавливает запущенную сессию пользователя: текущий отрезок закрывается и помечается паузой.
+func (t *TimeManageMemory) PauseTimeEntry(ctx context.Context, peopleID int, pauseTime time.Time) error {
+	const op = "memory.Time.PauseTimeEntry"
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	pauseTime = utc(pauseTime)
+
+	running := t.db.runningEntry(peopleID)
+	if running == nil {
+		return fmt.Errorf("%w: no running time entry for people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
+	}
+
+	if pauseTime.Before(running.StartTime) {
+		return fmt.Errorf("%w: pause time before start time, operation: %s", domain.ErrInputData, op)
+	}
+
+	running.EndTime = pauseTime
+	running.paused = true
+
+	return nil
+}
+
+// ResumeTimeEntry продолжает приостановленную сессию пользователя новым отрезком и возвращает его ID.
+// Изменения пересекающихся записей применяются вместе с новым отрезком, при пометке помечается и новый отрезок.
+func (t *TimeManageMemory) ResumeTimeEntry(ctx context.Context, peopleID int, resumeTime time.Time, overlaps entities.OverlapChanges) (int, error) {
+	const op = "memory.Time.ResumeTimeEntry"
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	var paused *entryRow
+	for _, entry := range t.db.entries {
+		if entry.PeopleID == peopleID && entry.paused {
+			paused = entry
+			break
+		}
+	}
+
+	if paused == nil {
+		return 0, fmt.Errorf("%w: no paused time entry for people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
+	}
+
+	sessionID := paused.sessionID
+	if sessionID == 0 {
+		sessionID = paused.ID
+	}
+
+	// Новый отрезок той же сессии
+	entry := &entryRow{
+		TimeEntry: entities.TimeEntry{TaskID: paused.TaskID, PeopleID: peopleID, StartTime: utc(resumeTime), Overlaps: len(overlaps.Flag) > 0},
+		sessionID: sessionID,
+	}
+
+	rollback, err := t.db.applyOverlaps(overlaps, resumeTime)
+	if err != nil {
+		return 0, fmt.Errorf("overlaps error: %w, operation: %s", err, op)
+	}
+
+	if err := t.db.checkEntry(entry); err != nil {
+		rollback()
+		return 0, fmt.Errorf("%w for people ID %d, operation: %s", err, peopleID, op)
+	}
+
+	paused.paused = false
+	t.db.insertEntry(entry)
+
+	return entry.ID, nil
+}
+
+// ActiveTimeEntry возвращает запущенную или приостановленную сессию пользователя.
+// ElapsedSeconds содержит время только закрытых отрезков сессии, время текущего отрезка досчитывает вызывающий.
+// Если активной сессии нет, возвращается пустой ActiveTimer.
+func (t *TimeManageMemory) ActiveTimeEntry(ctx context.Context, peopleID int) (entities.ActiveTimer, error) {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	var active *entryRow
+	for _, entry := range t.db.entries {
+		if entry.PeopleID != peopleID || entry.StartTime.IsZero() || !(entry.EndTime.IsZero() || entry.paused) {
+			continue
+		}
+		if active == nil || entry.StartTime.After(active.StartTime) {
+			active = entry
+		}
+	}
+
+	if active == nil {
+		return entities.ActiveTimer{}, nil
+	}
+
+	timer := entities.ActiveTimer{
+		TimeEntryID:  active.ID,
+		TaskID:       active.TaskID,
+		TaskTitle:    t.db.tasks[active.TaskID].title,
+		PeopleID:     active.PeopleID,
+		SegmentStart: active.StartTime,
+		Paused:       active.paused,
+	}
+
+	session := active.session()
+	for _, entry := range t.db.entries {
+		if entry.session() != session {
+			continue
+		}
+		if timer.SessionStart.IsZero() || entry.StartTime.Before(timer.SessionStart) {
+			timer.SessionStart = entry.StartTime
+		}
+		if !entry.EndTime.IsZero() {
+			timer.ElapsedSeconds += int64(math.Round(entry.EndTime.Sub(entry.StartTime).Seconds()))
+		}
+	}
+
+	return timer, nil
+}
+
+// OverlappingTimeEntries возвращает записи пользователя, пересекающиеся с интервалом [startTime, endTime).
+// Нулевой endTime означает интервал без верхней границы, как у открытой сессии.
+// Запись excludeID в проверке не участвует.
+func (t *TimeManageMemory) OverlappingTimeEntries(ctx context.Context, peopleID int, startTime, endTime time.Time, excludeID int) ([]entities.TimeEntry, error) {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	var entries []entities.TimeEntry
+	for _, entry := range t.db.entries {
+		if entry.PeopleID == peopleID && entry.ID != excludeID && !entry.StartTime.IsZero() &&
+			overlaps(entry.StartTime, entry.EndTime, utc(startTime), utc(endTime)) {
+			entries = append(entries, entry.TimeEntry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].StartTime.Equal(entries[j].StartTime) {
+			return entries[i].StartTime.Before(entries[j].StartTime)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+
+	return entries, nil
+}
+
+// applyOverlaps применяет изменения записей, пересекающихся с новой записью, начатой в at,
+// и возвращает функцию отката, если новую запись сохранить не удалось, как при откате транзакции.
+func (db *DB) applyOverlaps(changes entities.OverlapChanges, at time.Time) (func(), error) {
+	at = utc(at)
+
+	for _, id := range changes.Trim {
+		entry, ok := db.entries[id]
+		if !ok || entry.StartTime.IsZero() || entry.StartTime.After(at) {
+			return nil, fmt.Errorf("%w: time entry ID %d", domain.ErrNoRecordsFound, id)
+		}
+	}
+
+	saved := make(map[int]entryRow)
+	for _, ids := range [][]int{changes.Trim, changes.Flag} {
+		for _, id := range ids {
+			if entry, ok := db.entries[id]; ok {
+				saved[id] = *entry
+			}
+		}
+	}
+
+	for _, id := range changes.Trim {
+		db.entries[id].EndTime = at
+	}
+
+	for _, id := range changes.Flag {
+		if entry, ok := db.entries[id]; ok {
+			entry.Overlaps = true
+		}
+	}
+
+	rollback := func() {
+		for id, entry := range saved {
+			*db.entries[id] = entry
+		}
+	}
+
+	return rollback, nil
+}
+
+// ListTimeEntries возвращает все сессии работы над задачей в порядке их начала.
+func (t *TimeManageMemory) ListTimeEntries(ctx context.Context, taskID int) ([]entities.TimeEntry, error) {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	var entries []entities.TimeEntry
+	for _, entry := range t.db.entries {
+		if entry.TaskID == taskID {
+			entries = append(entries, entry.TimeEntry)
+		}
+	}
+
+	// Записи без start_time идут последними, как NULLS LAST
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		switch {
+		case a.StartTime.IsZero() != b.StartTime.IsZero():
+			return !a.StartTime.IsZero()
+		case !a.StartTime.Equal(b.StartTime):
+			return a.StartTime.Before(b.StartTime)
+		}
+		return a.ID < b.ID
+	})
+
+	return entries, nil
+}
+
+// TasksTimeSpent возвращает время, затраченное пользователем на задачи за период, по завершённым сессиям.
+// Нулевой peopleID означает всех пользователей, ненулевой projectID ограничивает выборку задачами проекта.
+func (t *TimeManageMemory) TasksTimeSpent(ctx context.Context, peopleID, projectID int, startTime, endTime time.Time) ([]entities.TaskTimeSpent, error) {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	type key struct{ peopleID, taskID int }
+	seconds := make(map[key]float64)
+
+	for _, entry := range t.db.entries {
+		if !t.db.spentInRange(entry, startTime, endTime) || entry.PeopleID == 0 ||
+			(peopleID != 0 && entry.PeopleID != peopleID) ||
+			(projectID != 0 && t.db.tasks[entry.TaskID].projectID != projectID) {
+			continue
+		}
+		seconds[key{entry.PeopleID, entry.TaskID}] += entry.EndTime.Sub(entry.StartTime).Seconds()
+	}
+
+	entries := make([]entities.TaskTimeSpent, 0, len(seconds))
+	for k, sec := range seconds {
+		people := t.db.people[k.peopleID].People
+		entries = append(entries, entities.TaskTimeSpent{
+			PeopleID:   people.ID,
+			Surname:    people.Surname,
+			Name:       people.Name,
+			Patronymic: people.Patronymic,
+			TaskID:     k.taskID,
+			TaskTitle:  t.db.tasks[k.taskID].title,
+			TimeSpent:  formatInterval(sec),
+			Hours:      math.Round(sec/36) / 100,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		switch {
+		case a.Surname != b.Surname:
+			return a.Surname < b.Surname
+		case a.Name != b.Name:
+			return a.Name < b.Name
+		case a.PeopleID != b.PeopleID:
+			return a.PeopleID < b.PeopleID
+		}
+		return seconds[key{a.PeopleID, a.TaskID}] > seconds[key{b.PeopleID, b.TaskID}]
+	})
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	return entries, nil
+}
+
+// ProjectsTimeSpent возвращает время, затраченное на задачи каждого проекта за определённый период.
+// Нулевой peopleID означает всех пользователей.
+func (t *TimeManageMemory) ProjectsTimeSpent(ctx context.Context, peopleID int, startTime, endTime time.Time) ([]entities.ProjectTimeSpent, error) {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	seconds := make(map[int]float64)
+
+	for _, entry := range t.db.entries {
+		projectID := t.db.tasks[entry.TaskID].projectID
+		if !t.db.spentInRange(entry, startTime, endTime) || projectID == 0 ||
+			(peopleID != 0 && entry.PeopleID != peopleID) {
+			continue
+		}
+		seconds[projectID] += entry.EndTime.Sub(entry.StartTime).Seconds()
+	}
+
+	var entries []entities.ProjectTimeSpent
+	for projectID, sec := range seconds {
+		entries = append(entries, entities.ProjectTimeSpent{
+			ProjectID:   projectID,
+			ProjectName: t.db.projects[projectID].Name,
+			TimeSpent:   formatInterval(sec),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return seconds[entries[i].ProjectID] > seconds[entries[j].ProjectID]
+	})
+
+	return entries, nil
+}
+
+// TagsTimeSpent возвращает время, затраченное на задачи с каждой меткой за определённый период.
+// Время задачи с несколькими метками учитывается в каждой из них, задачи без меток не учитываются.
+// Нулевой peopleID означает всех пользователей, ненулевой projectID ограничивает выборку задачами проекта.
+func (t *TimeManageMemory) TagsTimeSpent(ctx context.Context, peopleID, projectID int, startTime, endTime time.Time) ([]entities.TagTimeSpent, error) {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	seconds := make(map[int]float64)
+
+	for _, entry := range t.db.entries {
+		task := t.db.tasks[entry.TaskID]
+		if !t.db.spentInRange(entry, startTime, endTime) ||
+			(peopleID != 0 && entry.PeopleID != peopleID) ||
+			(projectID != 0 && task.projectID != projectID) {
+			continue
+		}
+		for tagID := range task.tagIDs {
+			seconds[tagID] += entry.EndTime.Sub(entry.StartTime).Seconds()
+		}
+	}
+
+	var entries []entities.TagTimeSpent
+	for tagID, sec := range seconds {
+		entries = append(entries, entities.TagTimeSpent{
+			TagID:     tagID,
+			TagName:   t.db.tags[tagID].Name,
+			TimeSpent: formatInterval(sec),
+			Hours:     math.Round(sec/36) / 100,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if seconds[a.TagID] != seconds[b.TagID] {
+			return seconds[a.TagID] > seconds[b.TagID]
+		}
+		return a.TagName < b.TagName
+	})
+
+	return entries, nil
+}
+
+// spentInRange сообщает, учитывается ли завершённая сессия в отчёте за период [start, end].
+func (db *DB) spentInRange(entry *entryRow, start, end time.Time) bool {
+	return !entry.StartTime.IsZero() && !entry.EndTime.IsZero() &&
+		!entry.StartTime.Before(start) && !entry.EndTime.After(end)
+}
+
+// runningEntry возвращает запущенный отрезок пользователя.
+func (db *DB) runningEntry(peopleID int) *entryRow {
+	for _, entry := range db.entries {
+		if entry.PeopleID == peopleID && !entry.StartTime.IsZero() && entry.EndTime.IsZero() {
+			return entry
+		}
+	}
+	return nil
+}
+
+// checkEntry повторяет ограничения time_entries для новой или изменённой записи:
+// существование пользователя, окончание не раньше начала, один запущенный таймер
+// на пользователя и отсутствие пересечений с записями без флага overlaps.
+func (db *DB) checkEntry(entry *entryRow) error {
+	if entry.PeopleID != 0 {
+		if _, ok := db.people[entry.PeopleID]; !ok {
+			return fmt.Errorf("%w: people ID %d not found", domain.ErrInputData, entry.PeopleID)
+		}
+	}
+
+	if entry.StartTime.IsZero() {
+		return nil
+	}
+
+	if !entry.EndTime.IsZero() && entry.EndTime.Before(entry.StartTime) {
+		return fmt.Errorf("%w: end time before start time", domain.ErrInputData)
+	}
+
+	if entry.PeopleID == 0 {
+		return nil
+	}
+
+	for _, other := range db.entries {
+		if other.ID == entry.ID || other.PeopleID != entry.PeopleID || other.StartTime.IsZero() {
+			continue
+		}
+		if entry.EndTime.IsZero() && other.EndTime.IsZero() {
+			return domain.ErrTimeEntryStarted
+		}
+		if !entry.Overlaps && !other.Overlaps && overlaps(entry.StartTime, entry.EndTime, other.StartTime, other.EndTime) {
+			return domain.ErrTimeEntryOverlap
+		}
+	}
+
+	return nil
+}
+
+// session возвращает ID первого отрезка сессии.
+func (e *entryRow) session() int {
+	if e.sessionID != 0 {
+		return e.sessionID
+	}
+	return e.ID
+}
+
+// formatInterval форматирует длительность как интервал PostgreSQL в часах, например 26:30:00.
+func formatInterval(seconds float64) string {
+	d := time.Duration(math.Round(seconds*1e6)) * time.Microsecond
+	h, m, s := int(d/time.Hour), int(d%time.Hour/time.Minute), d%time.Minute
+	if s%time.Second == 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", h, m, int(s/time.Second))
+	}
+	return fmt.Sprintf("%02d:%02d:%09.6f", h, m, s.Seconds())
+}
+
+// TaskEstimates возвращает задачи с оценкой или сроком и время их завершённых сессий, всего и по пользователям.
+// Ненулевой peopleID оставляет задачи, на которые пользователь назначен или над которыми работал,
+// и из времени по пользователям только его время, общее время задачи учитывает всех.
+// Ненулевой projectID оставляет задачи проекта. Задачи упорядочены по ID.
+func (t *TimeManageMemory) TaskEstimates(ctx context.Context, peopleID, projectID int) ([]entities.TaskEstimate, error) {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	estimates := []entities.TaskEstimate{}
+	for _, id := range sortedIDs(t.db.tasks) {
+		row := t.db.tasks[id]
+		if (row.estimate == 0 && row.dueDate.IsZero()) || (projectID != 0 && row.projectID != projectID) {
+			continue
+		}
+		if peopleID != 0 && t.db.currentAssignment(id, peopleID) == nil && !t.db.workedOn(id, peopleID) {
+			continue
+		}
+
+		task := entities.TaskEstimate{
+			TaskID:    row.id,
+			TaskTitle: row.title,
+			Status:    row.status,
+			ProjectID: row.projectID,
+			DueDate:   row.dueDate,
+			People:    []entities.PeopleTimeActual{},
+		}
+		if project, ok := t.db.projects[row.projectID]; ok {
+			task.ProjectName = project.Name
+		}
+		task.SetEstimate(row.estimate)
+
+		// Учитываются только завершённые сессии, время записей удалённых пользователей
+		// и других пользователей в отчёте пользователя входит только в общее
+		var total time.Duration
+		spent := make(map[int]time.Duration)
+		for _, entry := range t.db.entries {
+			if entry.TaskID != id || entry.StartTime.IsZero() || entry.EndTime.IsZero() {
+				continue
+			}
+			total += entry.Duration()
+			if entry.PeopleID != 0 && (peopleID == 0 || entry.PeopleID == peopleID) {
+				spent[entry.PeopleID] += entry.Duration()
+			}
+		}
+
+		for personID, d := range spent {
+			people := t.db.people[personID].People
+			actual := entities.PeopleTimeActual{PeopleID: personID, Surname: people.Surname, Name: people.Name, Patronymic: people.Patronymic}
+			actual.SetActual(d)
+			task.People = append(task.People, actual)
+		}
+		sort.Slice(task.People, func(i, j int) bool {
+			a, b := task.People[i], task.People[j]
+			switch {
+			case a.Surname != b.Surname:
+				return a.Surname < b.Surname
+			case a.Name != b.Name:
+				return a.Name < b.Name
+			}
+			return a.PeopleID < b.PeopleID
+		})
+
+		task.SetActual(total)
+		estimates = append(estimates, task)
+	}
+
+	return estimates, nil
+}
+
+// workedOn сообщает, есть ли у пользователя записи времени по задаче.
+func (db *DB) workedOn(taskID, peopleID int) bool {
+	for _, entry := range db.entries {
+		if entry.TaskID == taskID && entry.PeopleID == peopleID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type TemplateManageMemory struct {
+	db *DB
+}
+
+func NewTemplateManage(db *DB) *TemplateManageMemory {
+	return &TemplateManageMemory{db: db}
+}
+
+// Create сохраняет шаблон задачи вместе с пунктами чек-листа.
+func (t *TemplateManageMemory) Create(ctx context.Context, tpl entities.TaskTemplate) (int, error) {
+	const op = "memory.Template.Create"
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	if err := t.db.checkTemplate(tpl); err != nil {
+		return 0, fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	tpl.ID = t.db.nextID("task_templates")
+	t.db.templates[tpl.ID] = storedTemplate(tpl)
+
+	return tpl.ID, nil
+}
+
+func (t *TemplateManageMemory) GetByID(ctx context.Context, templateID int) (entities.TaskTemplate, error) {
+	const op = "memory.Template.GetByID"
+
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	tpl, ok := t.db.templates[templateID]
+	if !ok {
+		return entities.TaskTemplate{}, fmt.Errorf("%w: template ID %d, operation: %s", domain.ErrNoRecordsFound, templateID, op)
+	}
+
+	return *storedTemplate(*tpl), nil
+}
+
+// List возвращает все шаблоны в порядке имени.
+func (t *TemplateManageMemory) List(ctx context.Context) ([]entities.TaskTemplate, error) {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	templates := []entities.TaskTemplate{}
+	for _, id := range sortedIDs(t.db.templates) {
+		templates = append(templates, *storedTemplate(*t.db.templates[id]))
+	}
+
+	sort.SliceStable(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+
+	return templates, nil
+}
+
+// Update заменяет данные и чек-лист шаблона, задачи, уже созданные по шаблону, не меняются.
+func (t *TemplateManageMemory) Update(ctx context.Context, tpl entities.TaskTemplate) error {
+	const op = "memory.Template.Update"
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	if _, ok := t.db.templates[tpl.ID]; !ok {
+		return fmt.Errorf("%w: template ID %d, operation: %s", domain.ErrNoRecordsFound, tpl.ID, op)
+	}
+
+	if err := t.db.checkTemplate(tpl); err != nil {
+		return fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	t.db.templates[tpl.ID] = storedTemplate(tpl)
+
+	return nil
+}
+
+// Delete удаляет шаблон, задачи, созданные по шаблону, остаются.
+func (t *TemplateManageMemory) Delete(ctx context.Context, templateID int) error {
+	const op = "memory.Template.Delete"
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	if _, ok := t.db.templates[templateID]; !ok {
+		return fmt.Errorf("%w: template ID %d, operation: %s", domain.ErrNoRecordsFound, templateID, op)
+	}
+
+	delete(t.db.templates, templateID)
+
+	return nil
+}
+
+// checkTemplate проверяет ограничения таблицы task_templates: уникальное имя и внешние ключи.
+func (db *DB) checkTemplate(tpl entities.TaskTemplate) error {
+	for id, other := range db.templates {
+		if id != tpl.ID && other.Name == tpl.Name {
+			return fmt.Errorf("%w: template %q", domain.ErrAlreadyExists, tpl.Name)
+		}
+	}
+
+	if tpl.ProjectID != 0 {
+		if _, ok := db.projects[tpl.ProjectID]; !ok {
+			return fmt.Errorf("%w: project ID %d not found", domain.ErrInputData, tpl.ProjectID)
+		}
+	}
+
+	if tpl.AssigneeID != 0 {
+		if _, ok := db.people[tpl.AssigneeID]; !ok {
+			return fmt.Errorf("%w: people ID %d not found", domain.ErrInputData, tpl.AssigneeID)
+		}
+	}
+
+	return nil
+}
+
+// storedTemplate возвращает копию шаблона, не разделяющую чек-лист с исходным.
+func storedTemplate(tpl entities.TaskTemplate) *entities.TaskTemplate {
+	tpl.SetEstimate(time.Duration(tpl.EstimateSeconds) * time.Second)
+	tpl.Checklist = append([]string{}, tpl.Checklist...)
+	return &tpl
+}
@@ -0,0 +1,157 @@
+package memory
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type TimesheetManageMemory struct {
+	db *DB
+}
+
+func NewTimesheetManage(db *DB) *TimesheetManageMemory {
+	return &TimesheetManageMemory{db: db}
+}
+
+func weekKey(peopleID int, weekStart time.Time) timesheetKey {
+	return timesheetKey{peopleID: peopleID, weekStart: weekStart.Format(time.DateOnly)}
+}
+
+// GetTimesheet возвращает состояние табеля пользователя за неделю.
+// Если табель ещё не отправлялся, возвращается черновик.
+func (t *TimesheetManageMemory) GetTimesheet(ctx context.Context, peopleID int, weekStart time.Time) (entities.Timesheet, error) {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	sheet := entities.Timesheet{PeopleID: peopleID, WeekStart: weekStart, Status: entities.TimesheetDraft}
+
+	if row, ok := t.db.timesheets[weekKey(peopleID, weekStart)]; ok {
+		sheet.Status = row.Status
+		sheet.Comment = row.Comment
+		sheet.SubmittedAt = row.SubmittedAt
+		sheet.ReviewedAt = row.ReviewedAt
+		sheet.ReviewedBy = row.ReviewedBy
+	}
+
+	return sheet, nil
+}
+
+// SubmitTimesheet отправляет табель на согласование.
+// Отправить можно черновик или отклонённый табель, иначе возвращается ErrNoRecordsFound.
+func (t *TimesheetManageMemory) SubmitTimesheet(ctx context.Context, peopleID int, weekStart, submittedAt time.Time) error {
+	const op = "memory.Timesheet.Submit"
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	if _, ok := t.db.people[peopleID]; !ok {
+		return fmt.Errorf("%w: people ID %d not found, operation: %s", domain.ErrInputData, peopleID, op)
+	}
+
+	key := weekKey(peopleID, weekStart)
+
+	row, ok := t.db.timesheets[key]
+	if ok && row.Status != entities.TimesheetDraft && row.Status != entities.TimesheetRejected {
+		return fmt.Errorf("%w: draft or rejected timesheet, operation: %s", domain.ErrNoRecordsFound, op)
+	}
+
+	t.db.timesheets[key] = &entities.Timesheet{
+		PeopleID:    peopleID,
+		WeekStart:   weekStart,
+		Status:      entities.TimesheetSubmitted,
+		SubmittedAt: submittedAt,
+	}
+
+	return nil
+}
+
+// ReviewTimesheet согласует или отклоняет отправленный табель.
+func (t *TimesheetManageMemory) ReviewTimesheet(ctx context.Context, peopleID int, weekStart time.Time, status entities.TimesheetStatus, comment string, reviewerID int, reviewedAt time.Time) error {
+	const op = "memory.Timesheet.Review"
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+
+	row, ok := t.db.timesheets[weekKey(peopleID, weekStart)]
+	if !ok || row.Status != entities.TimesheetSubmitted {
+		return fmt.Errorf("%w: submitted timesheet, operation: %s", domain.ErrNoRecordsFound, op)
+	}
+
+	if status != entities.TimesheetApproved && status != entities.TimesheetRejected {
+		return fmt.Errorf("%w: timesheet status %q, operation: %s", domain.ErrInputData, status, op)
+	}
+
+	if _, ok := t.db.people[reviewerID]; reviewerID != 0 && !ok {
+		return fmt.Errorf("%w: people ID %d not found, operation: %s", domain.ErrInputData, reviewerID, op)
+	}
+
+	row.Status = status
+	row.Comment = comment
+	row.ReviewedBy = reviewerID
+	row.ReviewedAt = reviewedAt
+
+	return nil
+}
+
+// ApprovedWeek возвращает начало первой согласованной недели пользователя, пересекающейся с периодом [start, end],
+// или нулевое время, если таких недель нет.
+func (t *TimesheetManageMemory) ApprovedWeek(ctx context.Context, peopleID int, start, end time.Time) (time.Time, error) {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	start, end = utc(start), utc(end)
+
+	var first time.Time
+	for _, row := range t.db.timesheets {
+		weekStart := utc(row.WeekStart)
+		if row.PeopleID != peopleID || row.Status != entities.TimesheetApproved ||
+			weekStart.After(end) || !weekStart.AddDate(0, 0, 7).After(start) {
+			continue
+		}
+		if first.IsZero() || weekStart.Before(first) {
+			first = weekStart
+		}
+	}
+
+	return first, nil
+}
+
+// TimeSegments возвращает отрезки работы пользователя, пересекающие период [start, end).
+// Открытые отрезки считаются продолжающимися до момента now.
+func (t *TimesheetManageMemory) TimeSegments(ctx context.Context, peopleID int, start, end, now time.Time) ([]entities.TaskTimeSegment, error) {
+	t.db.mu.RLock()
+	defer t.db.mu.RUnlock()
+
+	start, end, now = utc(start), utc(end), utc(now)
+
+	var segments []entities.TaskTimeSegment
+	for _, entry := range t.db.entries {
+		if entry.PeopleID != peopleID || entry.StartTime.IsZero() {
+			continue
+		}
+
+		segmentEnd := entry.EndTime
+		if segmentEnd.IsZero() {
+			segmentEnd = now
+		}
+
+		if entry.StartTime.Before(end) && segmentEnd.After(start) {
+			segments = append(segments, entities.TaskTimeSegment{
+				TaskID:    entry.TaskID,
+				TaskTitle: t.db.tasks[entry.TaskID].title,
+				StartTime: entry.StartTime,
+				EndTime:   segmentEnd,
+			})
+		}
+	}
+
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].StartTime.Before(segments[j].StartTime)
+	})
+
+	return segments, nil
+}
@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type APIKeyManagePostgres struct {
+	db *sql.DB
+}
+
+func NewAPIKeyManage(db *sql.DB) *APIKeyManagePostgres {
+	return &APIKeyManagePostgres{db: db}
+}
+
+const apiKeySelectQuery = `SELECT id, people_id, name, prefix, key_hash, scopes, last_used_at, revoked_at, created_at 
+	FROM api_keys`
+
+// CreateAPIKey сохраняет новый API ключ.
+func (a *APIKeyManagePostgres) CreateAPIKey(ctx context.Context, key entities.APIKey) (int, error) {
+	const op = "postgres.APIKey.Create"
+
+	query := `INSERT INTO api_keys (people_id, name, prefix, key_hash, scopes) 
+		VALUES ($1, $2, $3, $4, $5) 
+		RETURNING id;`
+
+	stmt, err := a.db.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	var id int
+	err = stmt.QueryRowContext(ctx, key.PeopleID, key.Name, key.Prefix, key.KeyHash, pq.Array(scopeStrings(key.Scopes))).Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" { // "foreign_key_violation"
+			return 0, fmt.Errorf("%w, operation: %s", domain.ErrInputData, op)
+		}
+		return 0, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	return id, nil
+}
+
+// GetAPIKeyByPrefix возвращает API ключ по его открытому префиксу.
+func (a *APIKeyManagePostgres) GetAPIKeyByPrefix(ctx context.Context, prefix string) (entities.APIKey, error) {
+	const op = "postgres.APIKey.GetByPrefix"
+
+	stmt, err := a.db.PrepareContext(ctx, apiKeySelectQuery+` WHERE prefix = $1;`)
+	if err != nil {
+		return entities.APIKey{}, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	key, err := scanAPIKey(stmt.QueryRowContext(ctx, prefix))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return key, fmt.Errorf("%w: api key, operation: %s", domain.ErrNoRecordsFound, op)
+		}
+		return key, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	return key, nil
+}
+
+// ListAPIKeys возвращает все API ключи пользователя, включая отозванные.
+func (a *APIKeyManagePostgres) ListAPIKeys(ctx context.Context, peopleID int) ([]entities.APIKey, error) {
+	const op = "postgres.APIKey.List"
+
+	stmt, err := a.db.PrepareContext(ctx, apiKeySelectQuery+` WHERE people_id = $1 ORDER BY id;`)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	rows, err := stmt.QueryContext(ctx, peopleID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	var keys []entities.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey отзывает API ключ пользователя. Если peopleID равен 0, владелец ключа не проверяется.
+func (a *APIKeyManagePostgres) RevokeAPIKey(ctx context.Context, keyID, peopleID int, revokedAt time.Time) error {
+	const op = "postgres.APIKey.Revoke"
+
+	query := `UPDATE api_keys 
+		SET revoked_at = $1
+		WHERE id = $2 AND ($3 = 0 OR people_id = $3) AND revoked_at IS NULL;`
+
+	stmt, err := a.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	result, err := stmt.ExecContext(ctx, revokedAt, keyID, peopleID)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: active api key ID %d, operation: %s", domain.ErrNoRecordsFound, keyID, op)
+	}
+
+	return nil
+}
+
+// TouchAPIKey сохраняет время последнего использования API ключа.
+func (a *APIKeyManagePostgres) TouchAPIKey(ctx context.Context, keyID int, usedAt time.Time) error {
+	const op = "postgres.APIKey.Touch"
+
+	stmt, err := a.db.PrepareContext(ctx, `UPDATE api_keys SET last_used_at = $1 WHERE id = $2;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	if _, err := stmt.ExecContext(ctx, usedAt, keyID); err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	return nil
+}
+
+func scanAPIKey(row scanner) (entities.APIKey, error) {
+	var (
+		key                        entities.APIKey
+		scopes                     []string
+		lastUsed, revoked, created sql.NullTime
+	)
+
+	err := row.Scan(&key.ID, &key.PeopleID, &key.Name, &key.Prefix, &key.KeyHash, pq.Array(&scopes), &lastUsed, &revoked, &created)
+	if err != nil {
+		return key, err
+	}
+
+	key.Scopes = make([]entities.Scope, 0, len(scopes))
+	for _, s := range scopes {
+		key.Scopes = append(key.Scopes, entities.Scope(s))
+	}
+	key.LastUsedAt = lastUsed.Time
+	key.RevokedAt = revoked.Time
+	key.Created = created.Time
+
+	return key, nil
+}
+
+func scopeStrings(scopes []entities.Scope) []string {
+	out := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		out = append(out, string(s))
+	}
+	return out
+}
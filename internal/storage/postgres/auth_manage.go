@@ -0,0 +1,190 @@
+package postgres
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type AuthManagePostgres struct {
+	db *sql.DB
+}
+
+func NewAuthManage(db *sql.DB) *AuthManagePostgres {
+	return &AuthManagePostgres{db: db}
+}
+
+// SetPasswordHash сохраняет хеш пароля пользователя.
+func (a *AuthManagePostgres) SetPasswordHash(ctx context.Context, peopleID int, hash string) error {
+	const op = "postgres.Auth.SetPasswordHash"
+
+	query := `UPDATE people_info SET password_hash = $1 WHERE id = $2;`
+
+	stmt, err := a.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	result, err := stmt.ExecContext(ctx, hash, peopleID)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
+	}
+
+	return nil
+}
+
+// GetPasswordHash возвращает хеш пароля пользователя, пустая строка - пароль не задан.
+func (a *AuthManagePostgres) GetPasswordHash(ctx context.Context, peopleID int) (string, error) {
+	const op = "postgres.Auth.GetPasswordHash"
+
+	query := `SELECT COALESCE(password_hash, '') FROM people_info WHERE id = $1;`
+
+	stmt, err := a.db.PrepareContext(ctx, query)
+	if err != nil {
+		return "", fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	var hash string
+	if err := stmt.QueryRowContext(ctx, peopleID).Scan(&hash); err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("%w: people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
+		}
+		return "", fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	return hash, nil
+}
+
+// CreateSession сохраняет новую сессию входа.
+func (a *AuthManagePostgres) CreateSession(ctx context.Context, session entities.Session) error {
+	const op = "postgres.Auth.CreateSession"
+
+	query := `INSERT INTO auth_sessions (id, people_id, refresh_hash, expires_at) 
+		VALUES ($1, $2, $3, $4);`
+
+	stmt, err := a.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	if _, err := stmt.ExecContext(ctx, session.ID, session.PeopleID, session.RefreshHash, session.ExpiresAt); err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	return nil
+}
+
+// GetSession возвращает сессию входа по её ID.
+func (a *AuthManagePostgres) GetSession(ctx context.Context, sessionID string) (entities.Session, error) {
+	const op = "postgres.Auth.GetSession"
+
+	query := `SELECT id, people_id, refresh_hash, expires_at, revoked_at, created_at 
+		FROM auth_sessions 
+		WHERE id = $1;`
+
+	stmt, err := a.db.PrepareContext(ctx, query)
+	if err != nil {
+		return entities.Session{}, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	var (
+		session          entities.Session
+		revoked, created sql.NullTime
+	)
+
+	err = stmt.QueryRowContext(ctx, sessionID).Scan(&session.ID, &session.PeopleID, &session.RefreshHash, &session.ExpiresAt, &revoked, &created)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return session, fmt.Errorf("%w: session, operation: %s", domain.ErrNoRecordsFound, op)
+		}
+		return session, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	session.RevokedAt = revoked.Time
+	session.Created = created.Time
+
+	return session, nil
+}
+
+// RotateSession заменяет refresh token действующей сессии.
+// Замена выполняется, только если предъявлен текущий refresh token сессии.
+func (a *AuthManagePostgres) RotateSession(ctx context.Context, sessionID, oldRefreshHash, newRefreshHash string, expiresAt time.Time) error {
+	const op = "postgres.Auth.RotateSession"
+
+	query := `UPDATE auth_sessions 
+		SET refresh_hash = $1, expires_at = $2
+		WHERE id = $3 AND refresh_hash = $4 AND revoked_at IS NULL;`
+
+	stmt, err := a.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	result, err := stmt.ExecContext(ctx, newRefreshHash, expiresAt, sessionID, oldRefreshHash)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: active session, operation: %s", domain.ErrNoRecordsFound, op)
+	}
+
+	return nil
+}
+
+// RevokeSession отзывает сессию входа.
+func (a *AuthManagePostgres) RevokeSession(ctx context.Context, sessionID string, revokedAt time.Time) error {
+	const op = "postgres.Auth.RevokeSession"
+
+	query := `UPDATE auth_sessions 
+		SET revoked_at = $1
+		WHERE id = $2 AND revoked_at IS NULL;`
+
+	stmt, err := a.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	if _, err := stmt.ExecContext(ctx, revokedAt, sessionID); err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	return nil
+}
+
+// RevokePeopleSessions отзывает все сессии пользователя, например после смены пароля.
+func (a *AuthManagePostgres) RevokePeopleSessions(ctx context.Context, peopleID int, revokedAt time.Time) error {
+	const op = "postgres.Auth.RevokePeopleSessions"
+
+	query := `UPDATE auth_sessions 
+		SET revoked_at = $1
+		WHERE people_id = $2 AND revoked_at IS NULL;`
+
+	stmt, err := a.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	if _, err := stmt.ExecContext(ctx, revokedAt, peopleID); err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package postgres
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type DependencyManagePostgres struct {
+	db *sql.DB
+}
+
+func NewDependencyManage(db *sql.DB) *DependencyManagePostgres {
+	return &DependencyManagePostgres{db: db}
+}
+
+// taskDependencyLock ключ блокировки, под которой добавляются зависимости задач.
+const taskDependencyLock = 7_301_021
+
+// Add добавляет зависимость: задача blockerID блокирует задачу blockedID.
+// Повторное добавление не считается ошибкой, зависимость, замыкающая цепочку задач в цикл, отклоняется.
+func (d *DependencyManagePostgres) Add(ctx context.Context, blockerID, blockedID int) error {
+	const op = "postgres.Dependency.Add"
+
+	// Создание транзакции
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	// Зависимости добавляются по очереди: две встречные зависимости, каждая из которых
+	// допустима по отдельности, вместе образовали бы цикл
+	if _, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1);`, taskDependencyLock); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("lock error: %w, operation: %s", err, op)
+	}
+
+	// Цикл возникает, если blockedID уже прямо или через другие задачи блокирует blockerID
+	cycleQuery := `WITH RECURSIVE chain(id, path) AS (
+		SELECT $1::int, ARRAY[$1::int]
+		UNION ALL
+		SELECT d.blocked_id, c.path || d.blocked_id
+		FROM task_dependencies d
+		JOIN chain c ON d.blocker_id = c.id
+		WHERE c.id <> $2 AND NOT d.blocked_id = ANY(c.path)
+	)
+	SELECT path FROM chain WHERE id = $2 ORDER BY cardinality(path) LIMIT 1;`
+
+	var path pq.Int64Array
+	err = tx.QueryRowContext(ctx, cycleQuery, blockedID, blockerID).Scan(&path)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		tx.Rollback()
+		return fmt.Errorf("database error during cycle check: %w, operation: %s", err, op)
+	case blockerID != blockedID: // зависимость задачи от самой себя отклоняется ограничением таблицы
+		tx.Rollback()
+		return fmt.Errorf("%w: %s, operation: %s", domain.ErrDependencyCycle, dependencyChain(path, blockedID), op)
+	}
+
+	if _, err = tx.ExecContext(ctx, `INSERT INTO task_dependencies (blocker_id, blocked_id) 
+	VALUES ($1, $2) 
+	ON CONFLICT DO NOTHING;`, blockerID, blockedID); err != nil {
+		tx.Rollback()
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23503": // "foreign_key_violation"
+				return fmt.Errorf("%w: task ID %d or task ID %d, operation: %s", domain.ErrNoRecordsFound, blockerID, blockedID, op)
+			case "23514": // "check_violation"
+				return fmt.Errorf("%w: task cannot block itself, operation: %s", domain.ErrInputData, op)
+			}
+		}
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	// Завершение транзакции
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("database error during commit: %w, operation: %s", err, op)
+	}
+
+	return nil
+}
+
+// dependencyChain записывает цепочку задач, замыкаемую новой зависимостью, например 3 -> 5 -> 1 -> 3.
+func dependencyChain(path []int64, blockedID int) string {
+	chain := make([]string, 0, len(path)+1)
+	for _, id := range path {
+		chain = append(chain, strconv.FormatInt(id, 10))
+	}
+	chain = append(chain, strconv.Itoa(blockedID))
+	return strings.Join(chain, " -> ")
+}
+
+// Remove удаляет зависимость между задачами.
+func (d *DependencyManagePostgres) Remove(ctx context.Context, blockerID, blockedID int) error {
+	const op = "postgres.Dependency.Remove"
+
+	stmt, err := d.db.PrepareContext(ctx, `DELETE FROM task_dependencies WHERE blocker_id = $1 AND blocked_id = $2;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: task ID %d does not block task ID %d, operation: %s", domain.ErrNoRecordsFound, blockerID, blockedID, op)
+	}
+
+	return nil
+}
+
+// Blockers возвращает задачи, которые блокируют задачу taskID, упорядоченные по ID.
+func (d *DependencyManagePostgres) Blockers(ctx context.Context, taskID int) ([]entities.TaskRef, error) {
+	const op = "postgres.Dependency.Blockers"
+
+	refs, err := d.refs(ctx, `SELECT t.id, t.title, t.status 
+	FROM task_dependencies d
+	JOIN tasks t ON t.id = d.blocker_id
+	WHERE d.blocked_id = $1
+	ORDER BY t.id;`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	return refs, nil
+}
+
+// Blocked возвращает задачи, которые блокирует задача taskID, упорядоченные по ID.
+func (d *DependencyManagePostgres) Blocked(ctx context.Context, taskID int) ([]entities.TaskRef, error) {
+	const op = "postgres.Dependency.Blocked"
+
+	refs, err := d.refs(ctx, `SELECT t.id, t.title, t.status 
+	FROM task_dependencies d
+	JOIN tasks t ON t.id = d.blocked_id
+	WHERE d.blocker_id = $1
+	ORDER BY t.id;`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	return refs, nil
+}
+
+// refs выполняет запрос, возвращающий ID, заголовок и статус задач.
+func (d *DependencyManagePostgres) refs(ctx context.Context, query string, taskID int) ([]entities.TaskRef, error) {
+	stmt, err := d.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	refs := []entities.TaskRef{}
+	for rows.Next() {
+		var ref entities.TaskRef
+		if err := rows.Scan(&ref.ID, &ref.Title, &ref.Status); err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+		refs = append(refs, ref)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return refs, nil
+}
+
+// loadTaskDependencies заполняет BlockedBy и Blocks задач одним запросом,
+// у задачи без зависимостей списки пустые.
+func loadTaskDependencies(ctx context.Context, db *sql.DB, tasks []entities.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(tasks))
+	index := make(map[int]int, len(tasks))
+	for i := range tasks {
+		tasks[i].BlockedBy = []entities.TaskRef{}
+		tasks[i].Blocks = []entities.TaskRef{}
+		ids[i] = int64(tasks[i].ID)
+		index[tasks[i].ID] = i
+	}
+
+	// blocked_by отмечает блокирующие задачи, остальные строки - задачи, которые блокирует задача task_id
+	stmt, err := db.PrepareContext(ctx, `SELECT d.blocked_id AS task_id, true AS blocked_by, t.id, t.title, t.status
+	FROM task_dependencies d
+	JOIN tasks t ON t.id = d.blocker_id
+	WHERE d.blocked_id = ANY($1)
+	UNION ALL
+	SELECT d.blocker_id, false, t.id, t.title, t.status
+	FROM task_dependencies d
+	JOIN tasks t ON t.id = d.blocked_id
+	WHERE d.blocker_id = ANY($1)
+	ORDER BY 3;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID int
+		var blockedBy bool
+		var ref entities.TaskRef
+		if err := rows.Scan(&taskID, &blockedBy, &ref.ID, &ref.Title, &ref.Status); err != nil {
+			return fmt.Errorf("scan error: %w", err)
+		}
+		task := &tasks[index[taskID]]
+		if blockedBy {
+			task.BlockedBy = append(task.BlockedBy, ref)
+		} else {
+			task.Blocks = append(task.Blocks, ref)
+		}
+	}
+
+	return rows.Err()
+}
@@ -1,9 +1,11 @@
 package postgres
 
 import (
+	"TaskSync/internal/domain"
 	"TaskSync/internal/entities"
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -21,8 +23,8 @@ func NewPeopleManage(db *sql.DB) *PeopleManagePostgres {
 func (p *PeopleManagePostgres) Create(ctx context.Context, people entities.People) (int, error) {
 	const op = "postgres.People.Create"
 
-	stmt, err := p.db.PrepareContext(ctx, `INSERT INTO people_info (passport_series, passport_number, surname, name, patronymic, address) 
-	VALUES ($1, $2, $3, $4, $5, $6) 
+	stmt, err := p.db.PrepareContext(ctx, `INSERT INTO people_info (passport_series, passport_number, surname, name, patronymic, address, role, manager_id) 
+	VALUES ($1, $2, $3, $4, $5, $6, COALESCE(NULLIF($7, ''), 'member'), NULLIF($8, 0)) 
 	RETURNING id;`)
 	if err != nil {
 		return 0, fmt.Errorf("%s Prepare: %w", op, err)
@@ -30,13 +32,19 @@ func (p *PeopleManagePostgres) Create(ctx context.Context, people entities.Peopl
 
 	var id int
 
-	row := stmt.QueryRowContext(ctx, people.PassportSeries, people.PassportNumber, people.Surname, people.Name, people.Patronymic, people.Address)
+	row := stmt.QueryRowContext(ctx, people.PassportSeries, people.PassportNumber, people.Surname, people.Name, people.Patronymic, people.Address, people.Role, people.ManagerID)
 
 	err = row.Scan(&id)
 	if err != nil {
 		if pqErr, ok := err.(*pq.Error); ok {
-			if pqErr.Code == "22023" { // "invalid_parameter_value"
-				return 0, fmt.Errorf("%w, operation: %s", ErrInputData, op)
+			switch pqErr.Code {
+			case "23514": // "check_violation", паспортные данные или роль
+				return 0, fmt.Errorf("%w: %w, operation: %s", domain.ErrInputData, peopleCheckError(pqErr), op)
+			case "22023", // "invalid_parameter_value"
+				"23503": // "foreign_key_violation", руководитель не найден
+				return 0, fmt.Errorf("%w, operation: %s", domain.ErrInputData, op)
+			case "23505": // "unique_violation"
+				return 0, fmt.Errorf("%w: passport %d %d, operation: %s", domain.ErrAlreadyExists, people.PassportSeries, people.PassportNumber, op)
 			}
 			return 0, fmt.Errorf("database error: %w, operation: %s", pqErr, op)
 		}
@@ -49,7 +57,7 @@ func (p *PeopleManagePostgres) Create(ctx context.Context, people entities.Peopl
 func (p *PeopleManagePostgres) GetByID(ctx context.Context, peopleID int) (entities.People, error) {
 	const op = "postgres.People.Get"
 
-	stmt, err := p.db.PrepareContext(ctx, `SELECT id, passport_series, passport_number, surname, name, patronymic, address FROM people_info WHERE id = $1;`)
+	stmt, err := p.db.PrepareContext(ctx, `SELECT id, passport_series, passport_number, surname, name, patronymic, address, role, COALESCE(manager_id, 0) FROM people_info WHERE id = $1;`)
 	if err != nil {
 		return entities.People{}, fmt.Errorf("prepare error: %w, operation: %s", err, op)
 	}
@@ -58,10 +66,10 @@ func (p *PeopleManagePostgres) GetByID(ctx context.Context, peopleID int) (entit
 
 	row := stmt.QueryRowContext(ctx, peopleID)
 
-	err = row.Scan(&people.ID, &people.PassportSeries, &people.PassportNumber, &people.Surname, &people.Name, &people.Patronymic, &people.Address)
+	err = row.Scan(&people.ID, &people.PassportSeries, &people.PassportNumber, &people.Surname, &people.Name, &people.Patronymic, &people.Address, &people.Role, &people.ManagerID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return people, fmt.Errorf("no records found, operation: %s", op)
+			return people, fmt.Errorf("%w: people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
 		}
 		return people, fmt.Errorf("scan error: %w, operation: %s", err, op)
 	}
@@ -69,109 +77,160 @@ func (p *PeopleManagePostgres) GetByID(ctx context.Context, peopleID int) (entit
 	return people, nil
 }
 
-func (p *PeopleManagePostgres) GetByFilter(ctx context.Context, filterPeople entities.People, limit, offset int) ([]entities.People, error) {
+// peopleSortColumns поля сортировки списка пользователей.
+var peopleSortColumns = map[string]string{
+	"id":         "id",
+	"surname":    "surname",
+	"name":       "name",
+	"patronymic": "COALESCE(patronymic, '')",
+}
+
+// GetByFilter возвращает страницу пользователей, у которых совпадают все заданные поля фильтра,
+// и общее число таких пользователей.
+func (p *PeopleManagePostgres) GetByFilter(ctx context.Context, filterPeople entities.PeopleFilter, page entities.PageRequest) ([]entities.People, int, error) {
 	const op = "postgres.People.GetByFilter"
 
+	// При отсутствии фильтров - выведет все записи.
+	where, args := peopleFilter(filterPeople)
+
+	total, err := countRows(ctx, p.db, `SELECT COUNT(*) FROM people_info`+where, args)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count error: %w, operation: %s", err, op)
+	}
+
+	after, orderBy, args, err := keyset(page, peopleSortColumns, args)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w, operation: %s", err, op)
+	}
+
 	// Конструктор для запроса
 	var q strings.Builder
 
-	q.WriteString(`SELECT id, passport_series, passport_number, surname, name, patronymic, address 
-	FROM people_info
-	WHERE 1 = 1`)
-	// При отсутствии фильтров - выведет все записи.
+	q.WriteString(`SELECT id, passport_series, passport_number, surname, name, patronymic, address, role, COALESCE(manager_id, 0)
+	FROM people_info`)
+	q.WriteString(where)
 
-	argCount := 1
+	// Пагинация по курсору: записи после последней записи предыдущей страницы.
+	if after != "" {
+		q.WriteString(" AND " + after)
+	}
+	q.WriteString(" ORDER BY " + orderBy)
+	if page.Limit > 0 {
+		args = append(args, page.Limit)
+		q.WriteString(fmt.Sprintf(" LIMIT $%d", len(args)))
+	}
+
+	stmt, err := p.db.PrepareContext(ctx, q.String())
+	if err != nil {
+		return nil, 0, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	var peopleList []entities.People
+
+	for rows.Next() {
+		var people entities.People
+		if err := rows.Scan(&people.ID, &people.PassportSeries, &people.PassportNumber, &people.Surname, &people.Name, &people.Patronymic, &people.Address, &people.Role, &people.ManagerID); err != nil {
+			return nil, 0, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		peopleList = append(peopleList, people)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return peopleList, total, nil
+}
+
+// peopleFilter собирает условие WHERE по заданным полям фильтра.
+func peopleFilter(filterPeople entities.PeopleFilter) (string, []interface{}) {
+	var q strings.Builder
+	q.WriteString(" WHERE 1 = 1")
 
-	// Собираем условия фильтрации
 	var args []interface{}
+	add := func(column string, value interface{}) {
+		args = append(args, value)
+		q.WriteString(fmt.Sprintf(" AND %s = $%d", column, len(args)))
+	}
+	match := func(field, column, value string) {
+		op := filterPeople.Match[field]
+		args = append(args, matchPattern(op, value))
+		q.WriteString(" AND " + matchCondition(op, column, len(args)))
+	}
+
 	if filterPeople.ID != 0 {
-		q.WriteString(fmt.Sprintf(" AND id = $%d", argCount))
-		args = append(args, filterPeople.ID)
-		argCount++
+		add("id", filterPeople.ID)
 	}
 	if filterPeople.PassportSeries != 0 {
-		q.WriteString(fmt.Sprintf(" AND passport_series = $%d", argCount))
-		args = append(args, filterPeople.PassportSeries)
-		argCount++
+		add("passport_series", filterPeople.PassportSeries)
 	}
 	if filterPeople.PassportNumber != 0 {
-		q.WriteString(fmt.Sprintf(" AND passport_number = $%d", argCount))
-		args = append(args, filterPeople.PassportNumber)
-		argCount++
+		add("passport_number", filterPeople.PassportNumber)
 	}
 	if filterPeople.Surname != "" {
-		q.WriteString(fmt.Sprintf(" AND surname = $%d", argCount))
-		args = append(args, filterPeople.Surname)
-		argCount++
+		match("surname", "surname", filterPeople.Surname)
 	}
 	if filterPeople.Name != "" {
-		q.WriteString(fmt.Sprintf(" AND name = $%d", argCount))
-		args = append(args, filterPeople.Name)
-		argCount++
+		match("name", "name", filterPeople.Name)
 	}
 	if filterPeople.Patronymic != "" {
-		q.WriteString(fmt.Sprintf(" AND patronymic = $%d", argCount))
-		args = append(args, filterPeople.Patronymic)
-		argCount++
+		match("patronymic", "COALESCE(patronymic, '')", filterPeople.Patronymic)
 	}
 	if filterPeople.Address != "" {
-		q.WriteString(fmt.Sprintf(" AND address = $%d", argCount))
-		args = append(args, filterPeople.Address)
-		argCount++
+		match("address", "address", filterPeople.Address)
 	}
-
-	// Пагинация
-	// Если limit равен 0 - OFFSET не добавляется.
-	if limit > 0 {
-		q.WriteString(fmt.Sprintf(" LIMIT $%d OFFSET $%d", argCount, argCount+1))
-		args = append(args, limit, offset)
-	} else {
-
-		if offset > 0 {
-			q.WriteString(fmt.Sprintf(" OFFSET $%d", argCount))
-			args = append(args, offset)
-		}
+	if filterPeople.Role != "" {
+		add("role", filterPeople.Role)
 	}
-
-	query := q.String()
-
-	stmt, err := p.db.PrepareContext(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
-	}
-
-	rows, err := stmt.QueryContext(ctx, args...)
-	if err != nil {
-		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	if filterPeople.ManagerID != 0 {
+		add("manager_id", filterPeople.ManagerID)
 	}
 
-	var peopleList []entities.People
+	return q.String(), args
+}
 
-	for rows.Next() {
-		var people entities.People
-		if err := rows.Scan(&people.ID, &people.PassportSeries, &people.PassportNumber, &people.Surname, &people.Name, &people.Patronymic, &people.Address); err != nil {
-			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
-		}
-		peopleList = append(peopleList, people)
+// matchCondition возвращает условие сравнения column с аргументом $n способом op.
+func matchCondition(op entities.MatchOp, column string, n int) string {
+	switch op {
+	case entities.MatchIExact:
+		return fmt.Sprintf("lower(%s) = lower($%d)", column, n)
+	case entities.MatchPrefix, entities.MatchContains:
+		return fmt.Sprintf("%s ILIKE $%d", column, n)
 	}
+	return fmt.Sprintf("%s = $%d", column, n)
+}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+// matchPattern возвращает значение аргумента для matchCondition, для LIKE спецсимволы value экранируются.
+func matchPattern(op entities.MatchOp, value string) string {
+	switch op {
+	case entities.MatchPrefix:
+		return likeEscaper.Replace(value) + "%"
+	case entities.MatchContains:
+		return "%" + likeEscaper.Replace(value) + "%"
 	}
-
-	return peopleList, nil
+	return value
 }
 
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 func (p *PeopleManagePostgres) Update(ctx context.Context, people entities.People) error {
 	const op = "postgres.People.Update"
 
 	// Проверяем, что ID предоставлен и хотя бы одно значение для обновления задано
 	if people.ID == 0 {
-		return fmt.Errorf("missing ID, operation: %s", op)
+		return fmt.Errorf("%w: missing ID, operation: %s", domain.ErrInputData, op)
 	}
 	if people.PassportSeries == 0 && people.PassportNumber == 0 && people.Surname == "" &&
-		people.Name == "" && people.Patronymic == "" && people.Address == "" {
-		return fmt.Errorf("no values to update, operation: %s", op)
+		people.Name == "" && people.Patronymic == "" && people.Address == "" &&
+		people.Role == "" && people.ManagerID == 0 {
+		return fmt.Errorf("%w: no values to update, operation: %s", domain.ErrInputData, op)
 	}
 
 	// Конструктор строки для запроса
@@ -208,10 +267,20 @@ func (p *PeopleManagePostgres) Update(ctx context.Context, people entities.Peopl
 		argCount++
 	}
 	if people.Address != "" {
-		q.WriteString(fmt.Sprintf(" address = $%d", argCount))
+		q.WriteString(fmt.Sprintf(" address = $%d,", argCount))
 		args = append(args, people.Address)
 		argCount++
 	}
+	if people.Role != "" {
+		q.WriteString(fmt.Sprintf(" role = $%d,", argCount))
+		args = append(args, people.Role)
+		argCount++
+	}
+	if people.ManagerID != 0 {
+		q.WriteString(fmt.Sprintf(" manager_id = $%d,", argCount))
+		args = append(args, people.ManagerID)
+		argCount++
+	}
 
 	// Удаляем последнюю запятую
 	query := q.String()
@@ -230,6 +299,16 @@ func (p *PeopleManagePostgres) Update(ctx context.Context, people entities.Peopl
 
 	result, err := stmt.ExecContext(ctx, args...)
 	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23514":
+				return fmt.Errorf("%w: %w, operation: %s", domain.ErrInputData, peopleCheckError(pqErr), op)
+			case "23503":
+				return fmt.Errorf("%w, operation: %s", domain.ErrInputData, op)
+			case "23505": // "unique_violation"
+				return fmt.Errorf("%w: passport %d %d, operation: %s", domain.ErrAlreadyExists, people.PassportSeries, people.PassportNumber, op)
+			}
+		}
 		return fmt.Errorf("database error: %w, operation: %s", err, op)
 	}
 
@@ -239,42 +318,15 @@ func (p *PeopleManagePostgres) Update(ctx context.Context, people entities.Peopl
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("no rows updated, operation: %s", op)
+		return fmt.Errorf("%w: people ID %d, operation: %s", domain.ErrNoRecordsFound, people.ID, op)
 	}
 
 	return nil
 }
 
-func (p *PeopleManagePostgres) List(ctx context.Context) ([]entities.People, error) {
-	const op = "postgres.People.List"
-
-	q := `SELECT id, passport_series, passport_number, surname, name, patronymic, address FROM people_info;`
-
-	stmt, err := p.db.PrepareContext(ctx, q)
-	if err != nil {
-		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
-	}
-
-	rows, err := stmt.QueryContext(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
-	}
-
-	var peopleList []entities.People
-
-	for rows.Next() {
-		var people entities.People
-		if err := rows.Scan(&people.ID, &people.PassportSeries, &people.PassportNumber, &people.Surname, &people.Name, &people.Patronymic, &people.Address); err != nil {
-			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
-		}
-		peopleList = append(peopleList, people)
-	}
-
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
-	}
-
-	return peopleList, nil
+// List возвращает страницу всех пользователей и их общее число.
+func (p *PeopleManagePostgres) List(ctx context.Context, page entities.PageRequest) ([]entities.People, int, error) {
+	return p.GetByFilter(ctx, entities.PeopleFilter{}, page)
 }
 
 func (p *PeopleManagePostgres) Delete(ctx context.Context, peopleID int) error {
@@ -301,8 +353,21 @@ func (p *PeopleManagePostgres) Delete(ctx context.Context, peopleID int) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("no rows affected, operation: %s", op)
+		return fmt.Errorf("%w: people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
 	}
 
 	return nil
 }
+
+// peopleCheckError преобразует нарушение ограничения CHECK таблицы people_info в ошибку поля.
+func peopleCheckError(pqErr *pq.Error) error {
+	switch pqErr.Constraint {
+	case "chk_passport_series":
+		return domain.NewFieldError("passport_series", "must be a 4-digit number")
+	case "chk_passport_number":
+		return domain.NewFieldError("passport_number", "must be a 6-digit number")
+	case "chk_people_role":
+		return domain.NewFieldError("role", "must be one of admin, manager, member")
+	}
+	return errors.New(pqErr.Message)
+}
@@ -0,0 +1,307 @@
+package postgres
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type RecurrenceManagePostgres struct {
+	db *sql.DB
+}
+
+func NewRecurrenceManage(db *sql.DB) *RecurrenceManagePostgres {
+	return &RecurrenceManagePostgres{db: db}
+}
+
+// Create сохраняет повторяющуюся задачу вместе с её исполнителями.
+func (r *RecurrenceManagePostgres) Create(ctx context.Context, rec entities.Recurrence) (int, error) {
+	const op = "postgres.Recurrence.Create"
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+	defer tx.Rollback()
+
+	var id int
+	err = tx.QueryRowContext(ctx, `INSERT INTO recurrences (title, description, project_id, estimate_seconds, rule, start_at, next_run)
+	VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, 0), $5, $6, $7)
+	RETURNING id;`, rec.Title, rec.Description, rec.ProjectID, rec.EstimateSeconds, rec.Rule, rec.Start, nullTime(rec.NextRun)).Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" { // "foreign_key_violation"
+			return 0, fmt.Errorf("%w: project ID %d not found, operation: %s", domain.ErrInputData, rec.ProjectID, op)
+		}
+		return 0, fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO recurrence_assignees (recurrence_id, people_id)
+	VALUES ($1, $2)
+	ON CONFLICT DO NOTHING;`)
+	if err != nil {
+		return 0, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	for _, peopleID := range rec.AssigneeIDs {
+		if _, err := stmt.ExecContext(ctx, id, peopleID); err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" { // "foreign_key_violation"
+				return 0, fmt.Errorf("%w: people ID %d not found, operation: %s", domain.ErrInputData, peopleID, op)
+			}
+			return 0, fmt.Errorf("database error during assign: %w, operation: %s", err, op)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("database error during commit: %w, operation: %s", err, op)
+	}
+
+	return id, nil
+}
+
+// recurrenceSelectQuery выбирает повторяющиеся задачи без исполнителей, их заполняет loadRecurrenceAssignees.
+const recurrenceSelectQuery = `SELECT id, title, COALESCE(description, ''), COALESCE(project_id, 0), COALESCE(estimate_seconds, 0), rule, start_at, next_run, last_run
+	FROM recurrences`
+
+func (r *RecurrenceManagePostgres) GetByID(ctx context.Context, recurrenceID int) (entities.Recurrence, error) {
+	const op = "postgres.Recurrence.GetByID"
+
+	stmt, err := r.db.PrepareContext(ctx, recurrenceSelectQuery+`
+	WHERE id = $1;`)
+	if err != nil {
+		return entities.Recurrence{}, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rec, err := scanRecurrence(stmt.QueryRowContext(ctx, recurrenceID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return rec, fmt.Errorf("%w: recurrence ID %d, operation: %s", domain.ErrNoRecordsFound, recurrenceID, op)
+		}
+		return rec, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	recs := []entities.Recurrence{rec}
+	if err := loadRecurrenceAssignees(ctx, r.db, recs); err != nil {
+		return rec, fmt.Errorf("load assignees error: %w, operation: %s", err, op)
+	}
+
+	return recs[0], nil
+}
+
+// List возвращает все повторяющиеся задачи в порядке создания.
+func (r *RecurrenceManagePostgres) List(ctx context.Context) ([]entities.Recurrence, error) {
+	const op = "postgres.Recurrence.List"
+
+	recs, err := r.query(ctx, recurrenceSelectQuery+`
+	ORDER BY id;`)
+	if err != nil {
+		return nil, fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	return recs, nil
+}
+
+// Due возвращает повторяющиеся задачи, следующее повторение которых наступило к моменту at.
+func (r *RecurrenceManagePostgres) Due(ctx context.Context, at time.Time) ([]entities.Recurrence, error) {
+	const op = "postgres.Recurrence.Due"
+
+	recs, err := r.query(ctx, recurrenceSelectQuery+`
+	WHERE next_run <= $1
+	ORDER BY next_run, id;`, at)
+	if err != nil {
+		return nil, fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	return recs, nil
+}
+
+func (r *RecurrenceManagePostgres) query(ctx context.Context, query string, args ...any) ([]entities.Recurrence, error) {
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	recs := []entities.Recurrence{}
+	for rows.Next() {
+		rec, err := scanRecurrence(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+		recs = append(recs, rec)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	if err := loadRecurrenceAssignees(ctx, r.db, recs); err != nil {
+		return nil, fmt.Errorf("load assignees error: %w", err)
+	}
+
+	return recs, nil
+}
+
+func scanRecurrence(row scanner) (entities.Recurrence, error) {
+	var (
+		rec              entities.Recurrence
+		estimate         int64
+		nextRun, lastRun sql.NullTime
+	)
+
+	err := row.Scan(&rec.ID, &rec.Title, &rec.Description, &rec.ProjectID, &estimate, &rec.Rule, &rec.Start, &nextRun, &lastRun)
+	if err != nil {
+		return rec, err
+	}
+
+	rec.SetEstimate(time.Duration(estimate) * time.Second)
+	rec.Start = rec.Start.UTC()
+	rec.NextRun = nextRun.Time.UTC()
+	rec.LastRun = lastRun.Time.UTC()
+
+	return rec, nil
+}
+
+// loadRecurrenceAssignees заполняет исполнителей повторяющихся задач одним запросом.
+func loadRecurrenceAssignees(ctx context.Context, db *sql.DB, recs []entities.Recurrence) error {
+	if len(recs) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(recs))
+	index := make(map[int]int, len(recs))
+	for i := range recs {
+		recs[i].AssigneeIDs = []int{}
+		ids[i] = int64(recs[i].ID)
+		index[recs[i].ID] = i
+	}
+
+	stmt, err := db.PrepareContext(ctx, `SELECT recurrence_id, people_id FROM recurrence_assignees
+	WHERE recurrence_id = ANY($1)
+	ORDER BY people_id;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var recurrenceID, peopleID int
+		if err := rows.Scan(&recurrenceID, &peopleID); err != nil {
+			return fmt.Errorf("scan error: %w", err)
+		}
+		i := index[recurrenceID]
+		recs[i].AssigneeIDs = append(recs[i].AssigneeIDs, peopleID)
+	}
+
+	return rows.Err()
+}
+
+// Delete удаляет повторяющуюся задачу, уже созданные задачи остаются.
+func (r *RecurrenceManagePostgres) Delete(ctx context.Context, recurrenceID int) error {
+	const op = "postgres.Recurrence.Delete"
+
+	stmt, err := r.db.PrepareContext(ctx, `DELETE FROM recurrences WHERE id = $1;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, recurrenceID)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: recurrence ID %d, operation: %s", domain.ErrNoRecordsFound, recurrenceID, op)
+	}
+
+	return nil
+}
+
+// Materialize создаёт задачу повторения occurrence вместе с исполнителями повторяющейся задачи
+// и переносит следующее повторение на nextRun, нулевой nextRun завершает расписание.
+// Повторение создаётся не более одного раза: если оно уже создано, возвращается 0 без ошибки.
+func (r *RecurrenceManagePostgres) Materialize(ctx context.Context, recurrenceID int, occurrence time.Time, task entities.Task, nextRun time.Time) (int, error) {
+	const op = "postgres.Recurrence.Materialize"
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+	defer tx.Rollback()
+
+	// Параллельная вставка того же повторения ждёт завершения этой транзакции и ничего не делает
+	result, err := tx.ExecContext(ctx, `INSERT INTO recurrence_runs (recurrence_id, occurrence_at)
+	VALUES ($1, $2)
+	ON CONFLICT (recurrence_id, occurrence_at) DO NOTHING;`, recurrenceID, occurrence)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" { // "foreign_key_violation"
+			return 0, fmt.Errorf("%w: recurrence ID %d, operation: %s", domain.ErrNoRecordsFound, recurrenceID, op)
+		}
+		return 0, fmt.Errorf("database error during insertRun: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return 0, nil
+	}
+
+	var taskID int
+	err = tx.QueryRowContext(ctx, `INSERT INTO tasks (title, description, status, project_id, due_date, estimate_seconds)
+	VALUES ($1, $2, COALESCE(NULLIF($3, ''), 'todo'), NULLIF($4, 0), $5, NULLIF($6, 0))
+	RETURNING id;`, task.Title, task.Description, task.Status, task.ProjectID, nullTime(task.DueDate), task.EstimateSeconds).Scan(&taskID)
+	if err != nil {
+		return 0, fmt.Errorf("database error during insertTask: %w, operation: %s", err, op)
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO task_assignees (task_id, people_id, assigned_at)
+	SELECT $1, people_id, $2 FROM recurrence_assignees WHERE recurrence_id = $3;`, taskID, time.Now().UTC(), recurrenceID)
+	if err != nil {
+		return 0, fmt.Errorf("database error during assign: %w, operation: %s", err, op)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE recurrence_runs SET task_id = $1
+	WHERE recurrence_id = $2 AND occurrence_at = $3;`, taskID, recurrenceID, occurrence)
+	if err != nil {
+		return 0, fmt.Errorf("database error during updateRun: %w, operation: %s", err, op)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE recurrences SET next_run = $1, last_run = $2
+	WHERE id = $3;`, nullTime(nextRun), occurrence, recurrenceID)
+	if err != nil {
+		return 0, fmt.Errorf("database error during updateRecurrence: %w, operation: %s", err, op)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("database error during commit: %w, operation: %s", err, op)
+	}
+
+	return taskID, nil
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+type SearchManagePostgres struct {
+	db *sql.DB
+}
+
+func NewSearchManage(db *sql.DB) *SearchManagePostgres {
+	return &SearchManagePostgres{db: db}
+}
+
+// Search ищет пользователей по ФИО и задачи по заголовку и описанию с помощью полнотекстового поиска.
+// Каждый из terms должен совпадать с началом какого-либо слова записи.
+// Результаты упорядочены по убыванию ts_rank, совпадения в заголовке задачи весят больше, чем в описании.
+func (s *SearchManagePostgres) Search(ctx context.Context, terms []string, limit int) ([]entities.SearchResult, error) {
+	const op = "postgres.Search.Search"
+
+	// Слова запроса состоят только из букв и цифр, поэтому их можно передать в to_tsquery как есть
+	prefixes := make([]string, len(terms))
+	for i, term := range terms {
+		prefixes[i] = term + ":*"
+	}
+
+	q := `WITH q AS (SELECT to_tsquery('simple', $1) AS query)
+	SELECT 'people', p.id, concat_ws(' ', p.surname, p.name, NULLIF(p.patronymic, '')), ts_rank(p.search, q.query) AS rank
+	FROM people_info p, q
+	WHERE p.search @@ q.query
+	UNION ALL
+	SELECT 'task', t.id, t.title, ts_rank(t.search, q.query) AS rank
+	FROM tasks t, q
+	WHERE t.search @@ q.query
+	ORDER BY rank DESC, 1, 2
+	LIMIT $2;`
+
+	stmt, err := s.db.PrepareContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, strings.Join(prefixes, " & "), limit)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	var results []entities.SearchResult
+
+	for rows.Next() {
+		var result entities.SearchResult
+		if err := rows.Scan(&result.Type, &result.ID, &result.Title, &result.Rank); err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return results, nil
+}
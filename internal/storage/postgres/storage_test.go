@@ -0,0 +1,86 @@
+package postgres_test
+
+import (
+	"TaskSync/internal/storage"
+	"TaskSync/internal/storage/storagetest"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	migratepg "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/lib/pq"
+)
+
+// dsnEnv строка подключения к тестовой базе PostgreSQL, без неё проверка пропускается,
+// например "host=localhost port=5432 user=postgres password=postgres dbname=tasksync_test sslmode=disable".
+const dsnEnv = "TASKSYNC_TEST_POSTGRES_DSN"
+
+// Каждая проверка получает свою схему с применёнными миграциями, схема удаляется после проверки.
+func TestStorage(t *testing.T) {
+	dsn := os.Getenv(dsnEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", dsnEnv)
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer admin.Close()
+
+	// Расширение общее для всей базы, поэтому создаётся в public до миграций схем
+	if _, err := admin.Exec(`CREATE EXTENSION IF NOT EXISTS btree_gist SCHEMA public;`); err != nil {
+		t.Fatalf("create extension: %v", err)
+	}
+
+	n := 0
+	storagetest.Run(t, func(t *testing.T) *storage.Storage {
+		n++
+		schema := fmt.Sprintf("storagetest_%d_%d", os.Getpid(), n)
+		if _, err := admin.Exec(`CREATE SCHEMA ` + schema + `;`); err != nil {
+			t.Fatalf("create schema: %v", err)
+		}
+		t.Cleanup(func() { admin.Exec(`DROP SCHEMA ` + schema + ` CASCADE;`) })
+
+		db, err := sql.Open("postgres", withSearchPath(dsn, schema+",public"))
+		if err != nil {
+			t.Fatalf("open: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		driver, err := migratepg.WithInstance(db, &migratepg.Config{})
+		if err != nil {
+			t.Fatalf("migrate driver: %v", err)
+		}
+		m, err := migrate.NewWithDatabaseInstance("file://../../../migrations", "postgres", driver)
+		if err != nil {
+			t.Fatalf("migrate: %v", err)
+		}
+		if err := m.Up(); err != nil {
+			t.Fatalf("migrate up: %v", err)
+		}
+
+		return storage.NewStorage(db)
+	})
+}
+
+// withSearchPath добавляет search_path к строке подключения в виде URL или пар ключ=значение.
+func withSearchPath(dsn, searchPath string) string {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return dsn
+		}
+		q := u.Query()
+		q.Set("search_path", searchPath)
+		u.RawQuery = q.Encode()
+		return u.String()
+	}
+
+	return dsn + " search_path=" + searchPath
+}
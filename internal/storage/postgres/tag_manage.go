@@ -0,0 +1,251 @@
+package postgres
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+type TagManagePostgres struct {
+	db *sql.DB
+}
+
+func NewTagManage(db *sql.DB) *TagManagePostgres {
+	return &TagManagePostgres{db: db}
+}
+
+func (t *TagManagePostgres) Create(ctx context.Context, tag entities.Tag) (int, error) {
+	const op = "postgres.Tag.Create"
+
+	stmt, err := t.db.PrepareContext(ctx, `INSERT INTO tags (name) 
+	VALUES ($1) 
+	RETURNING id;`)
+	if err != nil {
+		return 0, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	var id int
+
+	err = stmt.QueryRowContext(ctx, tag.Name).Scan(&id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // "unique_violation"
+			return 0, fmt.Errorf("%w: tag %q, operation: %s", domain.ErrAlreadyExists, tag.Name, op)
+		}
+		return 0, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	return id, nil
+}
+
+func (t *TagManagePostgres) GetByID(ctx context.Context, tagID int) (entities.Tag, error) {
+	const op = "postgres.Tag.GetByID"
+
+	stmt, err := t.db.PrepareContext(ctx, `SELECT id, name FROM tags WHERE id = $1;`)
+	if err != nil {
+		return entities.Tag{}, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	var tag entities.Tag
+
+	err = stmt.QueryRowContext(ctx, tagID).Scan(&tag.ID, &tag.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return tag, fmt.Errorf("%w: tag ID %d, operation: %s", domain.ErrNoRecordsFound, tagID, op)
+		}
+		return tag, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	return tag, nil
+}
+
+// List возвращает все метки в порядке имени.
+func (t *TagManagePostgres) List(ctx context.Context) ([]entities.Tag, error) {
+	const op = "postgres.Tag.List"
+
+	stmt, err := t.db.PrepareContext(ctx, `SELECT id, name FROM tags ORDER BY name, id;`)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	var tags []entities.Tag
+
+	for rows.Next() {
+		var tag entities.Tag
+		if err := rows.Scan(&tag.ID, &tag.Name); err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return tags, nil
+}
+
+// Update переименовывает метку, новое имя применяется ко всем задачам с этой меткой.
+func (t *TagManagePostgres) Update(ctx context.Context, tag entities.Tag) error {
+	const op = "postgres.Tag.Update"
+
+	if tag.ID == 0 || tag.Name == "" {
+		return fmt.Errorf("%w: missing ID or name, operation: %s", domain.ErrInputData, op)
+	}
+
+	stmt, err := t.db.PrepareContext(ctx, `UPDATE tags SET name = $1 WHERE id = $2;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, tag.Name, tag.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // "unique_violation"
+			return fmt.Errorf("%w: tag %q, operation: %s", domain.ErrAlreadyExists, tag.Name, op)
+		}
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: tag ID %d, operation: %s", domain.ErrNoRecordsFound, tag.ID, op)
+	}
+
+	return nil
+}
+
+func (t *TagManagePostgres) Delete(ctx context.Context, tagID int) error {
+	const op = "postgres.Tag.Delete"
+
+	// Метка снимается со всех задач, foreign key с опцией ON DELETE CASCADE.
+	stmt, err := t.db.PrepareContext(ctx, `DELETE FROM tags WHERE id = $1;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, tagID)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: tag ID %d, operation: %s", domain.ErrNoRecordsFound, tagID, op)
+	}
+
+	return nil
+}
+
+// AttachTag назначает метку задаче, повторное назначение не считается ошибкой.
+func (t *TagManagePostgres) AttachTag(ctx context.Context, taskID, tagID int) error {
+	const op = "postgres.Tag.AttachTag"
+
+	stmt, err := t.db.PrepareContext(ctx, `INSERT INTO task_tags (task_id, tag_id) 
+	VALUES ($1, $2) 
+	ON CONFLICT DO NOTHING;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, taskID, tagID); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" { // "foreign_key_violation"
+			return fmt.Errorf("%w: task ID %d or tag ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, tagID, op)
+		}
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	return nil
+}
+
+// DetachTag снимает метку с задачи.
+func (t *TagManagePostgres) DetachTag(ctx context.Context, taskID, tagID int) error {
+	const op = "postgres.Tag.DetachTag"
+
+	stmt, err := t.db.PrepareContext(ctx, `DELETE FROM task_tags WHERE task_id = $1 AND tag_id = $2;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, taskID, tagID)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: tag ID %d on task ID %d, operation: %s", domain.ErrNoRecordsFound, tagID, taskID, op)
+	}
+
+	return nil
+}
+
+// loadTaskTags заполняет метки задач одним запросом, у задачи без меток Tags - пустой список.
+func loadTaskTags(ctx context.Context, db *sql.DB, tasks []entities.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(tasks))
+	index := make(map[int]int, len(tasks))
+	for i := range tasks {
+		tasks[i].Tags = []entities.Tag{}
+		ids[i] = int64(tasks[i].ID)
+		index[tasks[i].ID] = i
+	}
+
+	stmt, err := db.PrepareContext(ctx, `SELECT tt.task_id, tg.id, tg.name 
+	FROM task_tags tt
+	JOIN tags tg ON tg.id = tt.tag_id
+	WHERE tt.task_id = ANY($1)
+	ORDER BY tg.name, tg.id;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID int
+		var tag entities.Tag
+		if err := rows.Scan(&taskID, &tag.ID, &tag.Name); err != nil {
+			return fmt.Errorf("scan error: %w", err)
+		}
+		i := index[taskID]
+		tasks[i].Tags = append(tasks[i].Tags, tag)
+	}
+
+	return rows.Err()
+}
@@ -1,11 +1,15 @@
 package postgres
 
 import (
+	"TaskSync/internal/domain"
 	"TaskSync/internal/entities"
 	"context"
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/lib/pq"
 )
 
 type TaskManagePostgres struct {
@@ -16,7 +20,9 @@ func NewTaskManage(db *sql.DB) *TaskManagePostgres {
 	return &TaskManagePostgres{db: db}
 }
 
-func (t *TaskManagePostgres) Create(ctx context.Context, task entities.Task) (int, error) {
+// Create создает задачу и, если задан исполнитель, запись о времени по ней.
+// Изменения записей, пересекающихся с записью о времени, применяются в той же транзакции.
+func (t *TaskManagePostgres) Create(ctx context.Context, task entities.Task, overlaps entities.OverlapChanges) (int, error) {
 	const op = "postgres.Task.Create"
 
 	// Создание транзакции
@@ -26,8 +32,8 @@ func (t *TaskManagePostgres) Create(ctx context.Context, task entities.Task) (in
 	}
 
 	// Подготовка первого запроса
-	insertTaskQuery := `INSERT INTO tasks (title, description) 
-      VALUES($1, $2)
+	insertTaskQuery := `INSERT INTO tasks (title, description, status, project_id, parent_id, due_date, estimate_seconds) 
+      VALUES($1, $2, COALESCE(NULLIF($3, ''), 'todo'), NULLIF($4, 0), NULLIF($5, 0), $6, NULLIF($7, 0))
 	  RETURNING id;`
 	stmtInsertTask, err := tx.PrepareContext(ctx, insertTaskQuery)
 	if err != nil {
@@ -37,38 +43,75 @@ func (t *TaskManagePostgres) Create(ctx context.Context, task entities.Task) (in
 
 	// Выполнение первого запроса
 	var newTaskID int
-	err = stmtInsertTask.QueryRowContext(ctx, task.Title, task.Description).Scan(&newTaskID)
+	err = stmtInsertTask.QueryRowContext(ctx, task.Title, task.Description, task.Status, task.ProjectID, task.ParentID,
+		nullTime(task.DueDate), task.EstimateSeconds).Scan(&newTaskID)
 	if err != nil {
 		tx.Rollback()
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" { // "foreign_key_violation"
+			if pqErr.Constraint == "tasks_parent_id_fkey" {
+				return 0, fmt.Errorf("%w: parent task ID %d not found, operation: %s", domain.ErrInputData, task.ParentID, op)
+			}
+			return 0, fmt.Errorf("%w: project ID %d not found, operation: %s", domain.ErrInputData, task.ProjectID, op)
+		}
 		return 0, fmt.Errorf("database error during insertTask execution: %w, operation: %s", err, op)
 	}
 
-	// Подготовка второго запроса
-	insertTimeEntryQuery := `INSERT INTO time_entries (people_id, task_id, start_time, end_time) 
-      VALUES($1, $2, $3, $4)
-	  RETURNING id;`
-	stmtInsertTimeEntry, err := tx.PrepareContext(ctx, insertTimeEntryQuery)
-	if err != nil {
+	if err := insertChecklist(ctx, tx, newTaskID, task.Checklist); err != nil {
 		tx.Rollback()
-		return 0, fmt.Errorf("prepare error for insertTimeEntry: %w, operation: %s", err, op)
+		return 0, fmt.Errorf("insertChecklist error: %w, operation: %s", err, op)
 	}
 
-	// Выполнение второго запроса
-	result, err := stmtInsertTimeEntry.ExecContext(ctx, task.TimeEntry.PeopleID, newTaskID, task.TimeEntry.StartTime, task.TimeEntry.EndTime)
-	if err != nil {
-		tx.Rollback()
-		return 0, fmt.Errorf("database error during insertTimeEntry execution: %w, operation: %s", err, op)
-	}
+	// Без исполнителя запись о времени не создаётся
+	if task.TimeEntry.PeopleID != 0 {
+		if err := applyOverlaps(ctx, tx, overlaps, task.TimeEntry.StartTime); err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("overlaps error: %w, operation: %s", err, op)
+		}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		tx.Rollback()
-		return 0, fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
-	}
+		// Подготовка второго запроса
+		insertTimeEntryQuery := `INSERT INTO time_entries (people_id, task_id, start_time, end_time, overlaps) 
+      VALUES($1, $2, $3, $4, $5)
+	  RETURNING id;`
+		stmtInsertTimeEntry, err := tx.PrepareContext(ctx, insertTimeEntryQuery)
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("prepare error for insertTimeEntry: %w, operation: %s", err, op)
+		}
 
-	if rowsAffected == 0 {
-		tx.Rollback()
-		return 0, fmt.Errorf("no rows affected, operation: %s", op)
+		// Выполнение второго запроса
+		// Незаданное время сохраняется как NULL, сессия открывается позже через StartTimeEntry
+		result, err := stmtInsertTimeEntry.ExecContext(ctx, task.TimeEntry.PeopleID, newTaskID, nullTime(task.TimeEntry.StartTime), nullTime(task.TimeEntry.EndTime), len(overlaps.Flag) > 0)
+		if err != nil {
+			tx.Rollback()
+			if storageErr := timeEntryError(err); storageErr != nil {
+				return 0, fmt.Errorf("%w for people ID %d, operation: %s", storageErr, task.TimeEntry.PeopleID, op)
+			}
+			return 0, fmt.Errorf("database error during insertTimeEntry execution: %w, operation: %s", err, op)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+		}
+
+		if rowsAffected == 0 {
+			tx.Rollback()
+			return 0, fmt.Errorf("no rows affected, operation: %s", op)
+		}
+
+		// Пользователь из записи о времени становится исполнителем задачи
+		stmtInsertAssignee, err := tx.PrepareContext(ctx, `INSERT INTO task_assignees (task_id, people_id, assigned_at) 
+      VALUES($1, $2, $3);`)
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("prepare error for insertAssignee: %w, operation: %s", err, op)
+		}
+
+		if _, err := stmtInsertAssignee.ExecContext(ctx, newTaskID, task.TimeEntry.PeopleID, time.Now().UTC()); err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("database error during insertAssignee execution: %w, operation: %s", err, op)
+		}
 	}
 
 	// Завершение транзакции
@@ -80,12 +123,22 @@ func (t *TaskManagePostgres) Create(ctx context.Context, task entities.Task) (in
 	return newTaskID, nil
 }
 
-func (t *TaskManagePostgres) GetByID(ctx context.Context, taskID int) (entities.Task, error) {
+// taskSelectQuery выбирает задачи вместе с последней сессией работы над ними.
+const taskSelectQuery = `SELECT t.id, t.title, t.description, t.status, t.project_id, t.parent_id, t.due_date, t.estimate_seconds, te.id, te.task_id, te.people_id, te.start_time, te.end_time, te.created_at 
+	FROM tasks t
+	LEFT JOIN LATERAL (
+		SELECT id, task_id, people_id, start_time, end_time, created_at
+		FROM time_entries
+		WHERE task_id = t.id
+		ORDER BY created_at DESC, id DESC
+		LIMIT 1
+	) te ON true`
+
+// GetByID возвращает задачу, с subtree - вместе со всеми подзадачами и временем по поддереву.
+func (t *TaskManagePostgres) GetByID(ctx context.Context, taskID int, subtree bool) (entities.Task, error) {
 	const op = "postgres.Task.GetByID"
 
-	query := `SELECT t.id, t.title, t.description, te.people_id, te.start_time, te.end_time, te.created_at 
-	FROM tasks t
-	JOIN time_entries te ON t.id = te.task_id
+	query := taskSelectQuery + `
 	WHERE t.id = $1;`
 
 	stmt, err := t.db.PrepareContext(ctx, query)
@@ -93,17 +146,157 @@ func (t *TaskManagePostgres) GetByID(ctx context.Context, taskID int) (entities.
 		return entities.Task{}, fmt.Errorf("prepare error: %w, operation: %s", err, op)
 	}
 
-	var task entities.Task
 	row := stmt.QueryRowContext(ctx, taskID)
 
-	err = row.Scan(&task.ID, &task.Title, &task.Description, &task.TimeEntry.PeopleID, &task.TimeEntry.StartTime, &task.TimeEntry.EndTime, &task.TimeEntry.Created)
+	task, err := scanTask(row)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return task, fmt.Errorf("no records found, operation: %s", op)
+			return task, fmt.Errorf("%w: task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
 		}
 		return task, fmt.Errorf("scan error: %w, operation: %s", err, op)
 	}
 
+	tasks := []entities.Task{task}
+	if err := loadTaskTags(ctx, t.db, tasks); err != nil {
+		return task, fmt.Errorf("tags error: %w, operation: %s", err, op)
+	}
+
+	if err := loadTaskDependencies(ctx, t.db, tasks); err != nil {
+		return task, fmt.Errorf("dependencies error: %w, operation: %s", err, op)
+	}
+
+	if err := loadTaskAssignees(ctx, t.db, tasks); err != nil {
+		return task, fmt.Errorf("assignees error: %w, operation: %s", err, op)
+	}
+	task = tasks[0]
+
+	if err := loadTaskChecklist(ctx, t.db, &task); err != nil {
+		return task, fmt.Errorf("checklist error: %w, operation: %s", err, op)
+	}
+
+	if subtree {
+		if err := t.loadSubtree(ctx, &task); err != nil {
+			return task, fmt.Errorf("subtree error: %w, operation: %s", err, op)
+		}
+	}
+
+	return task, nil
+}
+
+// loadSubtree читает всех потомков задачи и время их завершённых сессий и собирает из них поддерево.
+func (t *TaskManagePostgres) loadSubtree(ctx context.Context, task *entities.Task) error {
+	descendantsQuery := `WITH RECURSIVE subtree AS (
+		SELECT id FROM tasks WHERE parent_id = $1
+		UNION
+		SELECT c.id FROM tasks c JOIN subtree s ON c.parent_id = s.id
+	)
+	` + taskSelectQuery + `
+	WHERE t.id IN (SELECT id FROM subtree)
+	ORDER BY t.id;`
+
+	stmt, err := t.db.PrepareContext(ctx, descendantsQuery)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, task.ID)
+	if err != nil {
+		return fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	var descendants []entities.Task
+	ids := []int64{int64(task.ID)}
+	for rows.Next() {
+		descendant, err := scanTask(rows)
+		if err != nil {
+			return fmt.Errorf("scan error: %w", err)
+		}
+		descendants = append(descendants, descendant)
+		ids = append(ids, int64(descendant.ID))
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	if err := loadTaskTags(ctx, t.db, descendants); err != nil {
+		return fmt.Errorf("tags error: %w", err)
+	}
+
+	if err := loadTaskDependencies(ctx, t.db, descendants); err != nil {
+		return fmt.Errorf("dependencies error: %w", err)
+	}
+
+	if err := loadTaskAssignees(ctx, t.db, descendants); err != nil {
+		return fmt.Errorf("assignees error: %w", err)
+	}
+
+	// Учитываются только завершённые сессии, как и в отчётах по времени
+	spentStmt, err := t.db.PrepareContext(ctx, `SELECT task_id, EXTRACT(EPOCH FROM SUM(end_time - start_time))::float8
+	FROM time_entries
+	WHERE task_id = ANY($1) AND start_time IS NOT NULL AND end_time IS NOT NULL
+	GROUP BY task_id;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w", err)
+	}
+	defer spentStmt.Close()
+
+	spentRows, err := spentStmt.QueryContext(ctx, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("query error: %w", err)
+	}
+	defer spentRows.Close()
+
+	spent := make(map[int]time.Duration)
+	for spentRows.Next() {
+		var taskID int
+		var seconds float64
+		if err := spentRows.Scan(&taskID, &seconds); err != nil {
+			return fmt.Errorf("scan error: %w", err)
+		}
+		spent[taskID] = time.Duration(seconds * float64(time.Second))
+	}
+	if err := spentRows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	task.BuildSubtree(descendants, spent)
+
+	return nil
+}
+
+// scanTask читает задачу и её последнюю сессию, у задачи без сессий TimeEntry остаётся пустым.
+func scanTask(row scanner) (entities.Task, error) {
+	var (
+		task                entities.Task
+		projectID, parentID sql.NullInt64
+		dueDate             sql.NullTime
+		estimate            sql.NullInt64
+		entryID, entryTask  sql.NullInt64
+		peopleID            sql.NullInt64
+		start, end, created sql.NullTime
+	)
+
+	err := row.Scan(&task.ID, &task.Title, &task.Description, &task.Status, &projectID, &parentID, &dueDate, &estimate,
+		&entryID, &entryTask, &peopleID, &start, &end, &created)
+	if err != nil {
+		return task, err
+	}
+
+	task.ProjectID = int(projectID.Int64)
+	task.ParentID = int(parentID.Int64)
+	task.DueDate = dueDate.Time
+	task.SetEstimate(time.Duration(estimate.Int64) * time.Second)
+	task.TimeEntry = entities.TimeEntry{
+		ID:        int(entryID.Int64),
+		TaskID:    int(entryTask.Int64),
+		PeopleID:  int(peopleID.Int64),
+		StartTime: start.Time,
+		EndTime:   end.Time,
+		Created:   created.Time,
+	}
+
 	return task, nil
 }
 
@@ -128,90 +321,177 @@ func (t *TaskManagePostgres) Delete(ctx context.Context, taskID int) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("no rows affected, operation: %s", op)
+		return fmt.Errorf("%w: task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
 	}
 
 	return nil
 }
 
-func (t *TaskManagePostgres) List(ctx context.Context) ([]entities.Task, error) {
+// taskSortColumns поля сортировки списка задач.
+var taskSortColumns = map[string]string{
+	"id":         "t.id",
+	"title":      "t.title",
+	"status":     "t.status",
+	"project_id": "COALESCE(t.project_id, 0)",
+}
+
+// List возвращает страницу задач, удовлетворяющих фильтру, и общее число таких задач.
+func (t *TaskManagePostgres) List(ctx context.Context, filter entities.TaskFilter, page entities.PageRequest) ([]entities.Task, int, error) {
 	const op = "postgres.Task.List"
 
-	query := `SELECT t.id, t.title, t.description, te.people_id, te.start_time, te.end_time, te.created_at 
-	FROM tasks t
-	JOIN time_entries te ON t.id = te.task_id;`
+	var where strings.Builder
+	where.WriteString(`
+	WHERE 1 = 1`)
+	// При отсутствии фильтров - выведет все записи.
 
-	stmt, err := t.db.PrepareContext(ctx, query)
+	var args []interface{}
+	if filter.ProjectID != 0 {
+		args = append(args, filter.ProjectID)
+		where.WriteString(fmt.Sprintf(" AND t.project_id = $%d", len(args)))
+	}
+	if filter.ParentID != 0 {
+		args = append(args, filter.ParentID)
+		where.WriteString(fmt.Sprintf(" AND t.parent_id = $%d", len(args)))
+	}
+	if filter.Tag != "" {
+		args = append(args, filter.Tag)
+		where.WriteString(fmt.Sprintf(` AND EXISTS (
+		SELECT 1 FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id
+		WHERE tt.task_id = t.id AND tg.name = $%d)`, len(args)))
+	}
+
+	total, err := countRows(ctx, t.db, `SELECT COUNT(*) FROM tasks t`+where.String(), args)
 	if err != nil {
-		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+		return nil, 0, fmt.Errorf("count error: %w, operation: %s", err, op)
 	}
 
-	rows, err := stmt.QueryContext(ctx)
+	after, orderBy, args, err := keyset(page, taskSortColumns, args)
 	if err != nil {
-		return nil, fmt.Errorf("database error: %w, operation: %s", err, op)
+		return nil, 0, fmt.Errorf("%w, operation: %s", err, op)
 	}
 
+	var q strings.Builder
+	q.WriteString(taskSelectQuery + where.String())
+
+	// Пагинация по курсору: записи после последней записи предыдущей страницы.
+	if after != "" {
+		q.WriteString(" AND " + after)
+	}
+	q.WriteString(" ORDER BY " + orderBy)
+	if page.Limit > 0 {
+		args = append(args, page.Limit)
+		q.WriteString(fmt.Sprintf(" LIMIT $%d", len(args)))
+	}
+
+	stmt, err := t.db.PrepareContext(ctx, q.String())
+	if err != nil {
+		return nil, 0, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
 	var taskList []entities.Task
 
 	for rows.Next() {
-		var task entities.Task
-		err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.TimeEntry.PeopleID, &task.TimeEntry.StartTime, &task.TimeEntry.EndTime, &task.TimeEntry.Created)
+		task, err := scanTask(rows)
 		if err != nil {
-			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+			return nil, 0, fmt.Errorf("scan error: %w, operation: %s", err, op)
 		}
 
 		taskList = append(taskList, task)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+		return nil, 0, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	if err := loadTaskTags(ctx, t.db, taskList); err != nil {
+		return nil, 0, fmt.Errorf("tags error: %w, operation: %s", err, op)
+	}
+
+	if err := loadTaskDependencies(ctx, t.db, taskList); err != nil {
+		return nil, 0, fmt.Errorf("dependencies error: %w, operation: %s", err, op)
+	}
+
+	if err := loadTaskAssignees(ctx, t.db, taskList); err != nil {
+		return nil, 0, fmt.Errorf("assignees error: %w, operation: %s", err, op)
 	}
 
-	return taskList, nil
+	return taskList, total, nil
 }
 
 func (t *TaskManagePostgres) Update(ctx context.Context, taskID int, title string, description string) error {
 	const op = "postgres.task.Update"
 
-	var q strings.Builder
-	q.WriteString(`UPDATE tasks SET`)
+	if title == "" && description == "" {
+		return fmt.Errorf("%w: no values to update, operation: %s", domain.ErrInputData, op)
+	}
 
+	// Конструктор строки для запроса
+	var sets []string
 	var args []interface{}
-	argCount := 1
 
 	if title != "" {
-		q.WriteString(fmt.Sprintf(" title = $%d,", argCount))
 		args = append(args, title)
-		argCount++
+		sets = append(sets, fmt.Sprintf("title = $%d", len(args)))
 	}
-
 	if description != "" {
-		q.WriteString(fmt.Sprintf(" description = $%d", argCount))
 		args = append(args, description)
-		argCount++
+		sets = append(sets, fmt.Sprintf("description = $%d", len(args)))
 	}
 
-	// Удаляем последнюю запятую, если она есть
-	if q.Len() > len("UPDATE tasks SET") {
-		query := q.String()
-		query = query[:len(query)-1] // Удаление последней запятой
-		q.Reset()
-		q.WriteString(query)
+	args = append(args, taskID)
+	query := fmt.Sprintf("UPDATE tasks SET %s WHERE id = $%d", strings.Join(sets, ", "), len(args))
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
 	}
 
-	// Добавление ID обновляемой записи
-	q.WriteString(fmt.Sprintf(" WHERE id = $%d", argCount))
-	args = append(args, taskID)
+	result, err := stmt.ExecContext(ctx, args...)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
 
-	query := q.String()
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
+	}
+
+	return nil
+}
+
+// UpdateProject переносит задачу в проект, нулевой projectID убирает задачу из проекта.
+func (t *TaskManagePostgres) UpdateProject(ctx context.Context, projectID, taskID int) error {
+	const op = "postgres.Task.UpdateProject"
+
+	if projectID < 0 || taskID <= 0 {
+		return fmt.Errorf("%w: incorrect values or their absence, operation: %s", domain.ErrInputData, op)
+	}
+
+	query := `UPDATE tasks 
+		SET project_id = NULLIF($1, 0)
+		WHERE id = $2`
 
 	stmt, err := t.db.PrepareContext(ctx, query)
 	if err != nil {
 		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
 	}
 
-	result, err := stmt.ExecContext(ctx, args...)
+	result, err := stmt.ExecContext(ctx, projectID, taskID)
 	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" { // "foreign_key_violation"
+			return fmt.Errorf("%w: project ID %d not found, operation: %s", domain.ErrInputData, projectID, op)
+		}
 		return fmt.Errorf("database error: %w, operation: %s", err, op)
 	}
 
@@ -221,40 +501,232 @@ func (t *TaskManagePostgres) Update(ctx context.Context, taskID int, title strin
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("no rows affected, operation: %s", op)
+		return fmt.Errorf("%w: task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
 	}
 
 	return nil
 }
 
-func (t *TaskManagePostgres) UpdatePeople(ctx context.Context, peopleID, taskID int) error {
-	const op = "postgres.Task.UpdatePeople"
+// UpdateSchedule задаёт срок выполнения и оценку задачи, нулевые значения их снимают.
+func (t *TaskManagePostgres) UpdateSchedule(ctx context.Context, taskID int, dueDate time.Time, estimate time.Duration) error {
+	const op = "postgres.Task.UpdateSchedule"
 
-	if peopleID <= 0 || taskID <= 0 {
-		return fmt.Errorf("incorrect values or their absence, operation: %s", op)
+	if taskID <= 0 || estimate < 0 {
+		return fmt.Errorf("%w: incorrect values or their absence, operation: %s", domain.ErrInputData, op)
 	}
 
-	query := `UPDATE time_entries 
-		SET people_id = $1
-		WHERE task_id = $2`
+	query := `UPDATE tasks 
+		SET due_date = $1, estimate_seconds = NULLIF($2, 0)
+		WHERE id = $3`
 
 	stmt, err := t.db.PrepareContext(ctx, query)
 	if err != nil {
 		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
 	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, nullTime(dueDate), int64(estimate/time.Second), taskID)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
+	}
+
+	return nil
+}
+
+// taskHierarchyLock ключ блокировки, под которой выполняются переносы задач между родителями.
+const taskHierarchyLock = 7_301_020
+
+// UpdateParent переносит задачу под задачу parentID, нулевой parentID делает её задачей верхнего уровня.
+// Перенос под саму задачу или любую её подзадачу образовал бы цикл и отклоняется.
+func (t *TaskManagePostgres) UpdateParent(ctx context.Context, parentID, taskID int) error {
+	const op = "postgres.Task.UpdateParent"
+
+	if parentID < 0 || taskID <= 0 {
+		return fmt.Errorf("%w: incorrect values or their absence, operation: %s", domain.ErrInputData, op)
+	}
+
+	// Создание транзакции
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	// Переносы выполняются по очереди: два встречных переноса, каждый из которых
+	// допустим по отдельности, вместе образовали бы цикл
+	if _, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1);`, taskHierarchyLock); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("lock error: %w, operation: %s", err, op)
+	}
+
+	if parentID != 0 {
+		// Цикл возникает, если переносимая задача - предок нового родителя или сам родитель
+		cycleQuery := `WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id FROM tasks WHERE id = $1
+			UNION
+			SELECT p.id, p.parent_id FROM tasks p JOIN ancestors a ON p.id = a.parent_id
+		)
+		SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = $2);`
+
+		var cycle bool
+		if err = tx.QueryRowContext(ctx, cycleQuery, parentID, taskID).Scan(&cycle); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("database error during cycle check: %w, operation: %s", err, op)
+		}
+
+		if cycle {
+			tx.Rollback()
+			return fmt.Errorf("%w: task ID %d under task ID %d, operation: %s", domain.ErrTaskCycle, taskID, parentID, op)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `UPDATE tasks SET parent_id = NULLIF($1, 0) WHERE id = $2;`, parentID, taskID)
+	if err != nil {
+		tx.Rollback()
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" { // "foreign_key_violation"
+			return fmt.Errorf("%w: parent task ID %d not found, operation: %s", domain.ErrInputData, parentID, op)
+		}
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		tx.Rollback()
+		return fmt.Errorf("%w: task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
+	}
+
+	// Завершение транзакции
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("database error during commit: %w, operation: %s", err, op)
+	}
+
+	return nil
+}
+
+// UpdateStatus переводит задачу из статуса from в статус to.
+// Если статус задачи уже изменился, обновление не выполняется.
+// При ненулевом timer.PeopleID в той же транзакции запускается таймер пользователя по задаче,
+// так что при отказе не остаётся ни новой сессии, ни завершённой приостановленной.
+func (t *TaskManagePostgres) UpdateStatus(ctx context.Context, taskID int, from, to entities.TaskStatus, timer entities.TimerStart) error {
+	const op = "postgres.Task.UpdateStatus"
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	query := `UPDATE tasks 
+		SET status = $1
+		WHERE id = $2 AND status = $3;`
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
 
-	result, err := stmt.ExecContext(ctx, peopleID, taskID)
+	result, err := stmt.ExecContext(ctx, to, taskID, from)
 	if err != nil {
+		tx.Rollback()
 		return fmt.Errorf("database error: %w, operation: %s", err, op)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
+		tx.Rollback()
 		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("no rows affected, operation: %s", op)
+		tx.Rollback()
+		return fmt.Errorf("%w: task ID %d with status %q, operation: %s", domain.ErrNoRecordsFound, taskID, from, op)
+	}
+
+	if timer.PeopleID != 0 {
+		if _, err := startTimeEntry(ctx, tx, taskID, timer.PeopleID, timer.StartTime, timer.Overlaps); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("%w, operation: %s", err, op)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("database error during commit: %w, operation: %s", err, op)
+	}
+
+	return nil
+}
+
+// UpdatePeople делает пользователя единственным исполнителем задачи: остальные назначения снимаются
+// и остаются в истории. Заготовки сессий без начала переходят к новому исполнителю,
+// начатые сессии остаются за тем, кто над ними работал.
+func (t *TaskManagePostgres) UpdatePeople(ctx context.Context, peopleID, taskID int) error {
+	const op = "postgres.Task.UpdatePeople"
+
+	if peopleID <= 0 || taskID <= 0 {
+		return fmt.Errorf("%w: incorrect values or their absence, operation: %s", domain.ErrInputData, op)
+	}
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+	defer tx.Rollback()
+
+	// Блокировка строки задачи не даёт параллельным переназначениям разойтись
+	var id int
+	err = tx.QueryRowContext(ctx, `SELECT id FROM tasks WHERE id = $1 FOR UPDATE;`, taskID).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
+		}
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	now := time.Now().UTC()
+
+	_, err = tx.ExecContext(ctx, `UPDATE task_assignees 
+		SET unassigned_at = GREATEST($3, assigned_at)
+		WHERE task_id = $1 AND people_id <> $2 AND unassigned_at IS NULL;`, taskID, peopleID, now)
+	if err != nil {
+		return fmt.Errorf("database error during unassign: %w, operation: %s", err, op)
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO task_assignees (task_id, people_id, assigned_at) 
+	VALUES ($1, $2, $3) 
+	ON CONFLICT (task_id, people_id) WHERE unassigned_at IS NULL DO NOTHING;`, taskID, peopleID, now)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" { // "foreign_key_violation"
+			return fmt.Errorf("%w: people ID %d not found, operation: %s", domain.ErrInputData, peopleID, op)
+		}
+		return fmt.Errorf("database error during assign: %w, operation: %s", err, op)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE time_entries 
+		SET people_id = $1
+		WHERE task_id = $2 AND start_time IS NULL`, peopleID, taskID)
+	if err != nil {
+		if storageErr := timeEntryError(err); storageErr != nil {
+			return fmt.Errorf("%w for people ID %d, operation: %s", storageErr, peopleID, op)
+		}
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("database error during commit: %w, operation: %s", err, op)
 	}
 
 	return nil
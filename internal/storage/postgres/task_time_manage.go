@@ -1,11 +1,14 @@
 package postgres
 
 import (
+	"TaskSync/internal/domain"
 	"TaskSync/internal/entities"
 	"context"
 	"database/sql"
 	"fmt"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type TimeManagePostgres struct {
@@ -16,21 +19,120 @@ func NewTimeManage(db *sql.DB) *TimeManagePostgres {
 	return &TimeManagePostgres{db: db}
 }
 
-func (t *TimeManagePostgres) StartTimeEntry(ctx context.Context, taskID int, startTime time.Time) error {
+// timeEntryError преобразует нарушения ограничений time_entries в ошибки хранилища.
+func timeEntryError(err error) error {
+	pqErr, ok := err.(*pq.Error)
+	if !ok {
+		return nil
+	}
+
+	switch pqErr.Code {
+	case "23505": // "unique_violation", у пользователя уже запущен таймер
+		return domain.ErrTimeEntryStarted
+	case "23P01": // "exclusion_violation"
+		return domain.ErrTimeEntryOverlap
+	case "23503", // "foreign_key_violation", задача или пользователь не найдены
+		"23514": // "check_violation", окончание раньше начала
+		return domain.ErrInputData
+	}
+	return nil
+}
+
+// StartTimeEntry открывает новую сессию работы пользователя над задачей и возвращает её ID.
+// Если у пользователя есть запись по задаче без start_time (создана вместе с задачей), сессия открывается в ней.
+// Приостановленная сессия пользователя при этом считается завершённой.
+// Изменения пересекающихся записей применяются в той же транзакции, при пометке помечается и новая запись.
+func (t *TimeManagePostgres) StartTimeEntry(ctx context.Context, taskID, peopleID int, startTime time.Time, overlaps entities.OverlapChanges) (int, error) {
 	const op = "postgres.Time.StartTimeEntry"
 
+	if peopleID <= 0 || taskID <= 0 {
+		return 0, fmt.Errorf("%w, operation: %s", domain.ErrInputData, op)
+	}
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	id, err := startTimeEntry(ctx, tx, taskID, peopleID, startTime, overlaps)
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("database error during commit: %w, operation: %s", err, op)
+	}
+
+	return id, nil
+}
+
+// startTimeEntry открывает сессию в транзакции tx: завершает приостановленную сессию пользователя,
+// применяет изменения пересекающихся записей и заполняет пустую запись по задаче или создаёт новую.
+func startTimeEntry(ctx context.Context, tx *sql.Tx, taskID, peopleID int, startTime time.Time, overlaps entities.OverlapChanges) (int, error) {
+	// Завершение приостановленной сессии
+	_, err := tx.ExecContext(ctx, `UPDATE time_entries SET paused = FALSE WHERE people_id = $1 AND paused;`, peopleID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to close paused time entry: %w", err)
+	}
+
+	if err := applyOverlaps(ctx, tx, overlaps, startTime); err != nil {
+		return 0, fmt.Errorf("overlaps error: %w", err)
+	}
+
+	// Заполнение пустой записи, созданной вместе с задачей
+	updateQuery := `UPDATE time_entries 
+		SET start_time = $1, overlaps = $4
+		WHERE id = (
+			SELECT id FROM time_entries
+			WHERE task_id = $2 AND people_id = $3 AND start_time IS NULL
+			ORDER BY id
+			LIMIT 1
+		)
+		RETURNING id;`
+
+	flag := len(overlaps.Flag) > 0
+
+	var id int
+	err = tx.QueryRowContext(ctx, updateQuery, startTime, taskID, peopleID, flag).Scan(&id)
+	if err == sql.ErrNoRows {
+		// Пустой записи нет - открываем новую сессию
+		insertQuery := `INSERT INTO time_entries (people_id, task_id, start_time, overlaps) 
+			VALUES ($1, $2, $3, $4)
+			RETURNING id;`
+		err = tx.QueryRowContext(ctx, insertQuery, peopleID, taskID, startTime, flag).Scan(&id)
+	}
+	if err != nil {
+		if storageErr := timeEntryError(err); storageErr != nil {
+			return 0, fmt.Errorf("%w for task ID %d", storageErr, taskID)
+		}
+		return 0, fmt.Errorf("failed to start time entry for task ID %d: %w", taskID, err)
+	}
+
+	return id, nil
+}
+
+// EndTimeEntry закрывает открытую или приостановленную сессию пользователя по задаче.
+// У приостановленной сессии время последнего отрезка не меняется.
+func (t *TimeManagePostgres) EndTimeEntry(ctx context.Context, taskID, peopleID int, endTime time.Time) error {
+	const op = "postgres.Time.EndTimeEntry"
+
 	query := `UPDATE time_entries 
-		SET start_time = $1
-		WHERE task_id = $2;`
+		SET end_time = COALESCE(end_time, $1), paused = FALSE
+		WHERE task_id = $2 AND people_id = $3
+			AND start_time IS NOT NULL AND (end_time IS NULL OR paused);`
 
 	stmt, err := t.db.PrepareContext(ctx, query)
 	if err != nil {
 		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
 	}
 
-	result, err := stmt.ExecContext(ctx, startTime, taskID)
+	result, err := stmt.ExecContext(ctx, endTime, taskID, peopleID)
 	if err != nil {
-		return fmt.Errorf("failed to update start time for task ID %d: %w, operation: %s", taskID, err, op)
+		if storageErr := timeEntryError(err); storageErr != nil {
+			return fmt.Errorf("%w for task ID %d, operation: %s", storageErr, taskID, op)
+		}
+		return fmt.Errorf("failed to update end time for task ID %d: %w, operation: %s", taskID, err, op)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -39,27 +141,51 @@ func (t *TimeManagePostgres) StartTimeEntry(ctx context.Context, taskID int, sta
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("no rows updated for task ID %d, operation: %s", taskID, op)
+		return fmt.Errorf("%w: no open time entry for task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
 	}
 
 	return nil
 }
 
-func (t *TimeManagePostgres) EndTimeEntry(ctx context.Context, taskID int, endTime time.Time) error {
-	const op = "postgres.Time.EndTimeEntry"
+// EndTaskTimeEntries закрывает все открытые и приостановленные сессии по задаче.
+func (t *TimeManagePostgres) EndTaskTimeEntries(ctx context.Context, taskID int, endTime time.Time) error {
+	const op = "postgres.Time.EndTaskTimeEntries"
 
 	query := `UPDATE time_entries 
-		SET end_time = $1
-		WHERE task_id = $2;`
+		SET end_time = COALESCE(end_time, GREATEST($1, start_time)), paused = FALSE
+		WHERE task_id = $2 AND start_time IS NOT NULL AND (end_time IS NULL OR paused);`
 
 	stmt, err := t.db.PrepareContext(ctx, query)
 	if err != nil {
 		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
 	}
 
-	result, err := stmt.ExecContext(ctx, endTime, taskID)
+	if _, err := stmt.ExecContext(ctx, endTime, taskID); err != nil {
+		return fmt.Errorf("failed to end time entries for task ID %d: %w, operation: %s", taskID, err, op)
+	}
+
+	return nil
+}
+
+// PauseTimeEntry приостанавливает запущенную сессию пользователя: текущий отрезок закрывается и помечается паузой.
+func (t *TimeManagePostgres) PauseTimeEntry(ctx context.Context, peopleID int, pauseTime time.Time) error {
+	const op = "postgres.Time.PauseTimeEntry"
+
+	query := `UPDATE time_entries 
+		SET end_time = $1, paused = TRUE
+		WHERE people_id = $2 AND start_time IS NOT NULL AND end_time IS NULL;`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
 	if err != nil {
-		return fmt.Errorf("failed to update end time for task ID %d: %w, operation: %s", taskID, err, op)
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	result, err := stmt.ExecContext(ctx, pauseTime, peopleID)
+	if err != nil {
+		if storageErr := timeEntryError(err); storageErr != nil {
+			return fmt.Errorf("%w for people ID %d, operation: %s", storageErr, peopleID, op)
+		}
+		return fmt.Errorf("failed to pause time entry for people ID %d: %w, operation: %s", peopleID, err, op)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -68,15 +194,256 @@ func (t *TimeManagePostgres) EndTimeEntry(ctx context.Context, taskID int, endTi
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("no rows updated for task ID %d, operation: %s", taskID, op)
+		return fmt.Errorf("%w: no running time entry for people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
 	}
 
 	return nil
 }
 
+// ResumeTimeEntry продолжает приостановленную сессию пользователя новым отрезком и возвращает его ID.
+// Изменения пересекающихся записей применяются в той же транзакции, при пометке помечается и новый отрезок.
+func (t *TimeManagePostgres) ResumeTimeEntry(ctx context.Context, peopleID int, resumeTime time.Time, overlaps entities.OverlapChanges) (int, error) {
+	const op = "postgres.Time.ResumeTimeEntry"
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	// Снятие паузы с последнего отрезка сессии
+	updateQuery := `UPDATE time_entries 
+		SET paused = FALSE
+		WHERE people_id = $1 AND paused
+		RETURNING task_id, COALESCE(session_id, id);`
+
+	var taskID, sessionID int
+	err = tx.QueryRowContext(ctx, updateQuery, peopleID).Scan(&taskID, &sessionID)
+	if err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("%w: no paused time entry for people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
+		}
+		return 0, fmt.Errorf("failed to resume time entry for people ID %d: %w, operation: %s", peopleID, err, op)
+	}
+
+	if err := applyOverlaps(ctx, tx, overlaps, resumeTime); err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("overlaps error: %w, operation: %s", err, op)
+	}
+
+	// Новый отрезок той же сессии
+	insertQuery := `INSERT INTO time_entries (people_id, task_id, start_time, session_id, overlaps) 
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id;`
+
+	var id int
+	err = tx.QueryRowContext(ctx, insertQuery, peopleID, taskID, resumeTime, sessionID, len(overlaps.Flag) > 0).Scan(&id)
+	if err != nil {
+		tx.Rollback()
+		if storageErr := timeEntryError(err); storageErr != nil {
+			return 0, fmt.Errorf("%w for people ID %d, operation: %s", storageErr, peopleID, op)
+		}
+		return 0, fmt.Errorf("failed to insert time entry: %w, operation: %s", err, op)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("database error during commit: %w, operation: %s", err, op)
+	}
+
+	return id, nil
+}
+
+// ActiveTimeEntry возвращает запущенную или приостановленную сессию пользователя.
+// ElapsedSeconds содержит время только закрытых отрезков сессии, время текущего отрезка досчитывает вызывающий.
+// Если активной сессии нет, возвращается пустой ActiveTimer.
+func (t *TimeManagePostgres) ActiveTimeEntry(ctx context.Context, peopleID int) (entities.ActiveTimer, error) {
+	const op = "postgres.Time.ActiveTimeEntry"
+
+	query := `SELECT te.id, te.task_id, t.title, te.people_id, te.start_time, te.paused,
+		s.session_start, s.closed_seconds
+	FROM time_entries te
+	JOIN tasks t ON t.id = te.task_id
+	JOIN LATERAL (
+		SELECT MIN(start_time) AS session_start,
+			COALESCE(SUM(EXTRACT(EPOCH FROM (end_time - start_time))) FILTER (WHERE end_time IS NOT NULL), 0)::BIGINT AS closed_seconds
+		FROM time_entries
+		WHERE COALESCE(session_id, id) = COALESCE(te.session_id, te.id)
+	) s ON true
+	WHERE te.people_id = $1 AND te.start_time IS NOT NULL AND (te.end_time IS NULL OR te.paused)
+	ORDER BY te.start_time DESC
+	LIMIT 1;`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return entities.ActiveTimer{}, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	var timer entities.ActiveTimer
+
+	row := stmt.QueryRowContext(ctx, peopleID)
+
+	err = row.Scan(&timer.TimeEntryID, &timer.TaskID, &timer.TaskTitle, &timer.PeopleID, &timer.SegmentStart, &timer.Paused,
+		&timer.SessionStart, &timer.ElapsedSeconds)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entities.ActiveTimer{}, nil
+		}
+		return entities.ActiveTimer{}, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	return timer, nil
+}
+
+// OverlappingTimeEntries возвращает записи пользователя, пересекающиеся с интервалом [startTime, endTime).
+// Нулевой endTime означает интервал без верхней границы, как у открытой сессии.
+// Запись excludeID в проверке не участвует.
+func (t *TimeManagePostgres) OverlappingTimeEntries(ctx context.Context, peopleID int, startTime, endTime time.Time, excludeID int) ([]entities.TimeEntry, error) {
+	const op = "postgres.Time.OverlappingTimeEntries"
+
+	query := `SELECT id, task_id, people_id, start_time, end_time, overlaps, created_at
+		FROM time_entries
+		WHERE people_id = $1 AND id <> $4 AND start_time IS NOT NULL
+			AND tsrange(start_time, end_time) && tsrange($2::timestamp, $3::timestamp)
+		ORDER BY start_time, id;`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	rows, err := stmt.QueryContext(ctx, peopleID, startTime, nullTime(endTime), excludeID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	var entries []entities.TimeEntry
+
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return entries, nil
+}
+
+// applyOverlaps применяет в транзакции изменения записей, пересекающихся с новой записью, начатой в at:
+// обрезает записи до at и помечает записи как пересекающиеся.
+func applyOverlaps(ctx context.Context, tx *sql.Tx, changes entities.OverlapChanges, at time.Time) error {
+	if len(changes.Trim) > 0 {
+		stmt, err := tx.PrepareContext(ctx, `UPDATE time_entries 
+		SET end_time = $1
+		WHERE id = $2 AND start_time <= $1;`)
+		if err != nil {
+			return fmt.Errorf("prepare error: %w", err)
+		}
+		defer stmt.Close()
+
+		for _, entryID := range changes.Trim {
+			result, err := stmt.ExecContext(ctx, at, entryID)
+			if err != nil {
+				return fmt.Errorf("failed to trim time entry ID %d: %w", entryID, err)
+			}
+
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("error retrieving affected rows: %w", err)
+			}
+
+			if rowsAffected == 0 {
+				return fmt.Errorf("%w: time entry ID %d", domain.ErrNoRecordsFound, entryID)
+			}
+		}
+	}
+
+	if len(changes.Flag) > 0 {
+		_, err := tx.ExecContext(ctx, `UPDATE time_entries SET overlaps = TRUE WHERE id = ANY($1);`, pq.Array(changes.Flag))
+		if err != nil {
+			return fmt.Errorf("failed to flag time entries: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListTimeEntries возвращает все сессии работы над задачей в порядке их начала.
+func (t *TimeManagePostgres) ListTimeEntries(ctx context.Context, taskID int) ([]entities.TimeEntry, error) {
+	const op = "postgres.Time.ListTimeEntries"
+
+	query := `SELECT id, task_id, people_id, start_time, end_time, overlaps, created_at
+		FROM time_entries
+		WHERE task_id = $1
+		ORDER BY start_time NULLS LAST, id;`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	rows, err := stmt.QueryContext(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	var entries []entities.TimeEntry
+
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return entries, nil
+}
+
+// scanner общий интерфейс для *sql.Row и *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTimeEntry читает запись time_entries, NULL значения остаются нулевыми.
+func scanTimeEntry(row scanner) (entities.TimeEntry, error) {
+	var (
+		entry               entities.TimeEntry
+		peopleID            sql.NullInt64
+		start, end, created sql.NullTime
+	)
+
+	if err := row.Scan(&entry.ID, &entry.TaskID, &peopleID, &start, &end, &entry.Overlaps, &created); err != nil {
+		return entry, err
+	}
+
+	entry.PeopleID = int(peopleID.Int64)
+	entry.StartTime = start.Time
+	entry.EndTime = end.Time
+	entry.Created = created.Time
+
+	return entry, nil
+}
+
+// nullTime преобразует нулевое время в NULL.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
 // GetTaskTimeSpent извлекает данные о том, сколько времени пользователь потратил на задачи за определённый период времени.
 // Функция возвращает список, в котором содержится информация о пользователе, задачах и количестве времени, затраченного на каждую задачу.
-func (t *TimeManagePostgres) TasksTimeSpent(ctx context.Context, peopleID int, startTime, endTime time.Time) ([]entities.TaskTimeSpent, error) {
+// Ненулевой projectID ограничивает выборку задачами проекта.
+func (t *TimeManagePostgres) TasksTimeSpent(ctx context.Context, peopleID, projectID int, startTime, endTime time.Time) ([]entities.TaskTimeSpent, error) {
 	const op = "postgres.Time.GetTaskTimeSpent"
 
 	// Определяем запрос
@@ -93,7 +460,8 @@ func (t *TimeManagePostgres) TasksTimeSpent(ctx context.Context, peopleID int, s
             EXTRACT(EPOCH FROM (te.end_time - te.start_time)) / 3600
         ),
         0
-    ) * INTERVAL '1 hour' AS time_spent
+    ) * INTERVAL '1 hour' AS time_spent,
+		ROUND(COALESCE(SUM(EXTRACT(EPOCH FROM (te.end_time - te.start_time))), 0) / 3600, 2)::float8 AS hours
 	FROM
 		tasks t
 	JOIN
@@ -101,13 +469,17 @@ func (t *TimeManagePostgres) TasksTimeSpent(ctx context.Context, peopleID int, s
 	JOIN
 		people_info p ON te.people_id = p.id
 	WHERE
-		p.id = $1
+		-- Учитываются только завершённые сессии, время суммируется по всем сессиям задачи.
+		-- peopleID 0 - все пользователи
+		($1::int = 0 OR p.id = $1)
 		AND te.start_time >= $2::timestamptz
 		AND te.end_time <= $3::timestamptz
+		AND te.end_time IS NOT NULL
+		AND ($4::int = 0 OR t.project_id = $4)
 	GROUP BY
 		p.id, p.surname, p.name, p.patronymic, t.id, t.title
 	ORDER BY
-		time_spent DESC;
+		p.surname, p.name, p.id, time_spent DESC;
 
 	`
 
@@ -118,17 +490,143 @@ func (t *TimeManagePostgres) TasksTimeSpent(ctx context.Context, peopleID int, s
 	}
 
 	// Выполнение подготовленного запроса
-	rows, err := stmt.QueryContext(ctx, peopleID, startTime, endTime)
+	rows, err := stmt.QueryContext(ctx, peopleID, startTime, endTime, projectID)
 	if err != nil {
 		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
 	}
+	defer rows.Close()
 
 	// Получение результатов
 	var entries []entities.TaskTimeSpent
 
 	for rows.Next() {
 		var entry entities.TaskTimeSpent
-		if err := rows.Scan(&entry.PeopleID, &entry.Surname, &entry.Name, &entry.Patronymic, &entry.TaskID, &entry.TaskTitle, &entry.TimeSpent); err != nil {
+		if err := rows.Scan(&entry.PeopleID, &entry.Surname, &entry.Name, &entry.Patronymic, &entry.TaskID, &entry.TaskTitle, &entry.TimeSpent, &entry.Hours); err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return entries, nil
+}
+
+// ProjectsTimeSpent возвращает время, затраченное на задачи каждого проекта за определённый период.
+// Нулевой peopleID означает всех пользователей.
+func (t *TimeManagePostgres) ProjectsTimeSpent(ctx context.Context, peopleID int, startTime, endTime time.Time) ([]entities.ProjectTimeSpent, error) {
+	const op = "postgres.Time.ProjectsTimeSpent"
+
+	const query = `
+	SELECT
+		pr.id AS project_id,
+		pr.name AS project_name,
+		COALESCE(
+			SUM(
+				EXTRACT(EPOCH FROM (te.end_time - te.start_time)) / 3600
+			),
+			0
+		) * INTERVAL '1 hour' AS time_spent
+	FROM
+		projects pr
+	JOIN
+		tasks t ON t.project_id = pr.id
+	JOIN
+		time_entries te ON t.id = te.task_id
+	WHERE
+		($1::int = 0 OR te.people_id = $1)
+		AND te.start_time >= $2::timestamptz
+		AND te.end_time <= $3::timestamptz
+		AND te.end_time IS NOT NULL
+	GROUP BY
+		pr.id, pr.name
+	ORDER BY
+		time_spent DESC;
+	`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	rows, err := stmt.QueryContext(ctx, peopleID, startTime, endTime)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	var entries []entities.ProjectTimeSpent
+
+	for rows.Next() {
+		var entry entities.ProjectTimeSpent
+		if err := rows.Scan(&entry.ProjectID, &entry.ProjectName, &entry.TimeSpent); err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return entries, nil
+}
+
+// TagsTimeSpent возвращает время, затраченное на задачи с каждой меткой за определённый период.
+// Время задачи с несколькими метками учитывается в каждой из них, задачи без меток не учитываются.
+// Нулевой peopleID означает всех пользователей, ненулевой projectID ограничивает выборку задачами проекта.
+func (t *TimeManagePostgres) TagsTimeSpent(ctx context.Context, peopleID, projectID int, startTime, endTime time.Time) ([]entities.TagTimeSpent, error) {
+	const op = "postgres.Time.TagsTimeSpent"
+
+	const query = `
+	SELECT
+		tg.id AS tag_id,
+		tg.name AS tag_name,
+		COALESCE(
+			SUM(
+				EXTRACT(EPOCH FROM (te.end_time - te.start_time)) / 3600
+			),
+			0
+		) * INTERVAL '1 hour' AS time_spent,
+		ROUND(COALESCE(SUM(EXTRACT(EPOCH FROM (te.end_time - te.start_time))), 0) / 3600, 2)::float8 AS hours
+	FROM
+		tags tg
+	JOIN
+		task_tags tt ON tt.tag_id = tg.id
+	JOIN
+		tasks t ON t.id = tt.task_id
+	JOIN
+		time_entries te ON t.id = te.task_id
+	WHERE
+		($1::int = 0 OR te.people_id = $1)
+		AND te.start_time >= $2::timestamptz
+		AND te.end_time <= $3::timestamptz
+		AND te.end_time IS NOT NULL
+		AND ($4::int = 0 OR t.project_id = $4)
+	GROUP BY
+		tg.id, tg.name
+	ORDER BY
+		time_spent DESC, tg.name;
+	`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	rows, err := stmt.QueryContext(ctx, peopleID, startTime, endTime, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	var entries []entities.TagTimeSpent
+
+	for rows.Next() {
+		var entry entities.TagTimeSpent
+		if err := rows.Scan(&entry.TagID, &entry.TagName, &entry.TimeSpent, &entry.Hours); err != nil {
 			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
 		}
 		entries = append(entries, entry)
@@ -140,3 +638,103 @@ func (t *TimeManagePostgres) TasksTimeSpent(ctx context.Context, peopleID int, s
 
 	return entries, nil
 }
+
+// TaskEstimates возвращает задачи с оценкой или сроком и время их завершённых сессий, всего и по пользователям.
+// Ненулевой peopleID оставляет задачи, на которые пользователь назначен или над которыми работал,
+// и из времени по пользователям только его время, общее время задачи учитывает всех.
+// Ненулевой projectID оставляет задачи проекта. Задачи упорядочены по ID.
+func (t *TimeManagePostgres) TaskEstimates(ctx context.Context, peopleID, projectID int) ([]entities.TaskEstimate, error) {
+	const op = "postgres.Time.TaskEstimates"
+
+	// Строка на пару задача-пользователь, время записей удалённых пользователей попадает в строку без пользователя
+	query := `
+	SELECT
+		t.id,
+		t.title,
+		t.status,
+		COALESCE(t.project_id, 0),
+		COALESCE(pr.name, ''),
+		t.due_date,
+		COALESCE(t.estimate_seconds, 0),
+		p.id,
+		COALESCE(p.surname, ''),
+		COALESCE(p.name, ''),
+		COALESCE(p.patronymic, ''),
+		COALESCE(SUM(EXTRACT(EPOCH FROM (te.end_time - te.start_time))), 0)::float8 AS seconds
+	FROM
+		tasks t
+	LEFT JOIN
+		projects pr ON pr.id = t.project_id
+	LEFT JOIN
+		time_entries te ON te.task_id = t.id AND te.start_time IS NOT NULL AND te.end_time IS NOT NULL
+	LEFT JOIN
+		people_info p ON p.id = te.people_id
+	WHERE
+		(t.estimate_seconds IS NOT NULL OR t.due_date IS NOT NULL)
+		AND ($2 = 0 OR t.project_id = $2)
+		AND ($1 = 0
+			OR EXISTS (SELECT 1 FROM task_assignees ta WHERE ta.task_id = t.id AND ta.people_id = $1 AND ta.unassigned_at IS NULL)
+			OR EXISTS (SELECT 1 FROM time_entries w WHERE w.task_id = t.id AND w.people_id = $1))
+	GROUP BY
+		t.id, pr.name, p.id
+	ORDER BY
+		t.id, p.surname, p.name, p.id;
+	`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, peopleID, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	estimates := []entities.TaskEstimate{}
+	var actual []float64
+
+	for rows.Next() {
+		var (
+			task     entities.TaskEstimate
+			dueDate  sql.NullTime
+			estimate int64
+			people   entities.PeopleTimeActual
+			personID sql.NullInt64
+			seconds  float64
+		)
+		err := rows.Scan(&task.TaskID, &task.TaskTitle, &task.Status, &task.ProjectID, &task.ProjectName, &dueDate, &estimate,
+			&personID, &people.Surname, &people.Name, &people.Patronymic, &seconds)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+
+		if n := len(estimates); n == 0 || estimates[n-1].TaskID != task.TaskID {
+			task.DueDate = dueDate.Time
+			task.SetEstimate(time.Duration(estimate) * time.Second)
+			task.People = []entities.PeopleTimeActual{}
+			estimates = append(estimates, task)
+			actual = append(actual, 0)
+		}
+
+		last := len(estimates) - 1
+		actual[last] += seconds
+		if personID.Valid && (peopleID == 0 || int(personID.Int64) == peopleID) {
+			people.PeopleID = int(personID.Int64)
+			people.SetActual(time.Duration(seconds * float64(time.Second)))
+			estimates[last].People = append(estimates[last].People, people)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	for i := range estimates {
+		estimates[i].SetActual(time.Duration(actual[i] * float64(time.Second)))
+	}
+
+	return estimates, nil
+}
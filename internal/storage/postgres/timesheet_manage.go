@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type TimesheetManagePostgres struct {
+	db *sql.DB
+}
+
+func NewTimesheetManage(db *sql.DB) *TimesheetManagePostgres {
+	return &TimesheetManagePostgres{db: db}
+}
+
+// GetTimesheet возвращает состояние табеля пользователя за неделю.
+// Если табель ещё не отправлялся, возвращается черновик.
+func (t *TimesheetManagePostgres) GetTimesheet(ctx context.Context, peopleID int, weekStart time.Time) (entities.Timesheet, error) {
+	const op = "postgres.Timesheet.Get"
+
+	query := `SELECT status, comment, submitted_at, reviewed_at, COALESCE(reviewed_by, 0)
+		FROM timesheets 
+		WHERE people_id = $1 AND week_start = $2;`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return entities.Timesheet{}, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	var (
+		sheet               = entities.Timesheet{PeopleID: peopleID, WeekStart: weekStart}
+		submitted, reviewed sql.NullTime
+	)
+
+	err = stmt.QueryRowContext(ctx, peopleID, weekStart).Scan(&sheet.Status, &sheet.Comment, &submitted, &reviewed, &sheet.ReviewedBy)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			sheet.Status = entities.TimesheetDraft
+			return sheet, nil
+		}
+		return sheet, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	sheet.SubmittedAt = submitted.Time
+	sheet.ReviewedAt = reviewed.Time
+
+	return sheet, nil
+}
+
+// SubmitTimesheet отправляет табель на согласование.
+// Отправить можно черновик или отклонённый табель, иначе возвращается domain.ErrNoRecordsFound.
+func (t *TimesheetManagePostgres) SubmitTimesheet(ctx context.Context, peopleID int, weekStart, submittedAt time.Time) error {
+	const op = "postgres.Timesheet.Submit"
+
+	query := `INSERT INTO timesheets (people_id, week_start, status, submitted_at) 
+		VALUES ($1, $2, 'submitted', $3)
+		ON CONFLICT (people_id, week_start) DO UPDATE 
+		SET status = 'submitted', submitted_at = EXCLUDED.submitted_at, comment = '', reviewed_at = NULL, reviewed_by = NULL
+		WHERE timesheets.status IN ('draft', 'rejected');`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	result, err := stmt.ExecContext(ctx, peopleID, weekStart, submittedAt)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: draft or rejected timesheet, operation: %s", domain.ErrNoRecordsFound, op)
+	}
+
+	return nil
+}
+
+// ReviewTimesheet согласует или отклоняет отправленный табель.
+func (t *TimesheetManagePostgres) ReviewTimesheet(ctx context.Context, peopleID int, weekStart time.Time, status entities.TimesheetStatus, comment string, reviewerID int, reviewedAt time.Time) error {
+	const op = "postgres.Timesheet.Review"
+
+	query := `UPDATE timesheets 
+		SET status = $1, comment = $2, reviewed_by = $3, reviewed_at = $4
+		WHERE people_id = $5 AND week_start = $6 AND status = 'submitted';`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	result, err := stmt.ExecContext(ctx, status, comment, sql.NullInt64{Int64: int64(reviewerID), Valid: reviewerID != 0}, reviewedAt, peopleID, weekStart)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: submitted timesheet, operation: %s", domain.ErrNoRecordsFound, op)
+	}
+
+	return nil
+}
+
+// ApprovedWeek возвращает начало первой согласованной недели пользователя, пересекающейся с периодом [start, end],
+// или нулевое время, если таких недель нет.
+func (t *TimesheetManagePostgres) ApprovedWeek(ctx context.Context, peopleID int, start, end time.Time) (time.Time, error) {
+	const op = "postgres.Timesheet.ApprovedWeek"
+
+	query := `SELECT week_start FROM timesheets
+		WHERE people_id = $1 AND status = 'approved'
+			AND week_start <= $3::timestamp AND week_start + INTERVAL '7 days' > $2::timestamp
+		ORDER BY week_start
+		LIMIT 1;`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	var weekStart time.Time
+	if err := stmt.QueryRowContext(ctx, peopleID, start, end).Scan(&weekStart); err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	return weekStart.UTC(), nil
+}
+
+// TimeSegments возвращает отрезки работы пользователя, пересекающие период [start, end).
+// Открытые отрезки считаются продолжающимися до момента now.
+func (t *TimesheetManagePostgres) TimeSegments(ctx context.Context, peopleID int, start, end, now time.Time) ([]entities.TaskTimeSegment, error) {
+	const op = "postgres.Timesheet.TimeSegments"
+
+	query := `SELECT te.task_id, t.title, te.start_time, COALESCE(te.end_time, $4)
+		FROM time_entries te
+		JOIN tasks t ON t.id = te.task_id
+		WHERE te.people_id = $1 
+			AND te.start_time IS NOT NULL
+			AND te.start_time < $3
+			AND COALESCE(te.end_time, $4) > $2
+		ORDER BY te.start_time;`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+
+	rows, err := stmt.QueryContext(ctx, peopleID, start, end, now)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	var segments []entities.TaskTimeSegment
+	for rows.Next() {
+		var s entities.TaskTimeSegment
+		if err := rows.Scan(&s.TaskID, &s.TaskTitle, &s.StartTime, &s.EndTime); err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		segments = append(segments, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return segments, nil
+}
@@ -0,0 +1,240 @@
+package sqlite
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+type AssigneeManageSQLite struct {
+	db *sql.DB
+}
+
+func NewAssigneeManage(db *sql.DB) *AssigneeManageSQLite {
+	return &AssigneeManageSQLite{db: db}
+}
+
+// Assign назначает пользователя исполнителем задачи с момента at.
+// Повторное назначение текущего исполнителя не считается ошибкой и не меняет историю.
+func (a *AssigneeManageSQLite) Assign(ctx context.Context, taskID, peopleID int, at time.Time) error {
+	const op = "sqlite.Assignee.Assign"
+
+	stmt, err := a.db.PrepareContext(ctx, `INSERT INTO task_assignees (task_id, people_id, assigned_at) 
+	VALUES ($1, $2, $3) 
+	ON CONFLICT (task_id, people_id) WHERE unassigned_at IS NULL DO NOTHING;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, taskID, peopleID, nullTime(at)); err != nil {
+		if constraintCode(err) == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY {
+			return fmt.Errorf("%w: task ID %d or people ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, peopleID, op)
+		}
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	return nil
+}
+
+// Unassign снимает пользователя с задачи в момент at, назначение остаётся в истории.
+func (a *AssigneeManageSQLite) Unassign(ctx context.Context, taskID, peopleID int, at time.Time) error {
+	const op = "sqlite.Assignee.Unassign"
+
+	// Назначение не может закончиться раньше, чем началось
+	stmt, err := a.db.PrepareContext(ctx, `UPDATE task_assignees 
+		SET unassigned_at = MAX($3, assigned_at)
+		WHERE task_id = $1 AND people_id = $2 AND unassigned_at IS NULL;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, taskID, peopleID, nullTime(at))
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: people ID %d is not assigned to task ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, taskID, op)
+	}
+
+	return nil
+}
+
+// History возвращает все назначения задачи, текущие и снятые, в порядке назначения.
+func (a *AssigneeManageSQLite) History(ctx context.Context, taskID int) ([]entities.TaskAssignment, error) {
+	const op = "sqlite.Assignee.History"
+
+	stmt, err := a.db.PrepareContext(ctx, assignmentSelectQuery+`
+	WHERE ta.task_id = $1
+	ORDER BY ta.assigned_at, ta.id;`)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	assignments := []entities.TaskAssignment{}
+	for rows.Next() {
+		assignment, err := scanAssignment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return assignments, nil
+}
+
+// TimeByAssignee возвращает время завершённых сессий задачи по каждому пользователю:
+// текущим исполнителям и всем, кто работал над задачей, в порядке убывания времени.
+func (a *AssigneeManageSQLite) TimeByAssignee(ctx context.Context, taskID int) ([]entities.AssigneeTimeSpent, error) {
+	const op = "sqlite.Assignee.TimeByAssignee"
+
+	query := `
+	WITH participants AS (
+		SELECT people_id FROM task_assignees WHERE task_id = $1 AND unassigned_at IS NULL
+		UNION
+		SELECT people_id FROM time_entries WHERE task_id = $1 AND people_id IS NOT NULL
+	)
+	SELECT
+		p.id,
+		p.surname,
+		p.name,
+		COALESCE(p.patronymic, ''),
+		EXISTS (
+			SELECT 1 FROM task_assignees ta
+			WHERE ta.task_id = $1 AND ta.people_id = p.id AND ta.unassigned_at IS NULL
+		) AS assigned,
+		COALESCE(SUM(` + secondsExpr + `), 0) AS seconds
+	FROM participants pt
+	JOIN people_info p ON p.id = pt.people_id
+	-- Учитываются только завершённые сессии
+	LEFT JOIN time_entries te ON te.task_id = $1 AND te.people_id = p.id
+		AND te.start_time IS NOT NULL AND te.end_time IS NOT NULL
+	GROUP BY p.id, p.surname, p.name, p.patronymic
+	ORDER BY seconds DESC, p.surname, p.name, p.id;`
+
+	stmt, err := a.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	entries := []entities.AssigneeTimeSpent{}
+	for rows.Next() {
+		var (
+			entry   entities.AssigneeTimeSpent
+			seconds float64
+		)
+		if err := rows.Scan(&entry.PeopleID, &entry.Surname, &entry.Name, &entry.Patronymic, &entry.Assigned, &seconds); err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		entry.TimeSpent = formatInterval(seconds)
+		entry.Hours = hours(seconds)
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return entries, nil
+}
+
+// assignmentSelectQuery выбирает назначения вместе с ФИО пользователей.
+const assignmentSelectQuery = `SELECT ta.id, ta.task_id, ta.people_id, p.surname, p.name, COALESCE(p.patronymic, ''), ta.assigned_at, ta.unassigned_at
+	FROM task_assignees ta
+	JOIN people_info p ON p.id = ta.people_id`
+
+// scanAssignment читает назначение, у текущего назначения UnassignedAt остаётся нулевым.
+func scanAssignment(row scanner) (entities.TaskAssignment, error) {
+	var (
+		assignment           entities.TaskAssignment
+		assigned, unassigned timeValue
+	)
+
+	err := row.Scan(&assignment.ID, &assignment.TaskID, &assignment.PeopleID, &assignment.Surname, &assignment.Name,
+		&assignment.Patronymic, &assigned, &unassigned)
+	if err != nil {
+		return assignment, err
+	}
+
+	assignment.AssignedAt = assigned.Time
+	assignment.UnassignedAt = unassigned.Time
+
+	return assignment, nil
+}
+
+// loadTaskAssignees заполняет текущих исполнителей задач одним запросом,
+// у задачи без исполнителей Assignees - пустой список.
+func loadTaskAssignees(ctx context.Context, db *sql.DB, tasks []entities.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(tasks))
+	index := make(map[int]int, len(tasks))
+	for i := range tasks {
+		tasks[i].Assignees = []entities.TaskAssignment{}
+		ids[i] = tasks[i].ID
+		index[tasks[i].ID] = i
+	}
+
+	idList, err := json.Marshal(ids)
+	if err != nil {
+		return fmt.Errorf("marshal error: %w", err)
+	}
+
+	stmt, err := db.PrepareContext(ctx, assignmentSelectQuery+`
+	WHERE ta.task_id IN (SELECT value FROM json_each($1)) AND ta.unassigned_at IS NULL
+	ORDER BY ta.assigned_at, ta.id;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, string(idList))
+	if err != nil {
+		return fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		assignment, err := scanAssignment(rows)
+		if err != nil {
+			return fmt.Errorf("scan error: %w", err)
+		}
+		i := index[assignment.TaskID]
+		tasks[i].Assignees = append(tasks[i].Assignees, assignment)
+	}
+
+	return rows.Err()
+}
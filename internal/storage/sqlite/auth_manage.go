@@ -0,0 +1,198 @@
+package sqlite
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type AuthManageSQLite struct {
+	db *sql.DB
+}
+
+func NewAuthManage(db *sql.DB) *AuthManageSQLite {
+	return &AuthManageSQLite{db: db}
+}
+
+// SetPasswordHash сохраняет хеш пароля пользователя.
+func (a *AuthManageSQLite) SetPasswordHash(ctx context.Context, peopleID int, hash string) error {
+	const op = "sqlite.Auth.SetPasswordHash"
+
+	query := `UPDATE people_info SET password_hash = $1 WHERE id = $2;`
+
+	stmt, err := a.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, hash, peopleID)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
+	}
+
+	return nil
+}
+
+// GetPasswordHash возвращает хеш пароля пользователя, пустая строка - пароль не задан.
+func (a *AuthManageSQLite) GetPasswordHash(ctx context.Context, peopleID int) (string, error) {
+	const op = "sqlite.Auth.GetPasswordHash"
+
+	query := `SELECT COALESCE(password_hash, '') FROM people_info WHERE id = $1;`
+
+	stmt, err := a.db.PrepareContext(ctx, query)
+	if err != nil {
+		return "", fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	var hash string
+	if err := stmt.QueryRowContext(ctx, peopleID).Scan(&hash); err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("%w: people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
+		}
+		return "", fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	return hash, nil
+}
+
+// CreateSession сохраняет новую сессию входа.
+func (a *AuthManageSQLite) CreateSession(ctx context.Context, session entities.Session) error {
+	const op = "sqlite.Auth.CreateSession"
+
+	query := `INSERT INTO auth_sessions (id, people_id, refresh_hash, expires_at) 
+		VALUES ($1, $2, $3, $4);`
+
+	stmt, err := a.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, session.ID, session.PeopleID, session.RefreshHash, nullTime(session.ExpiresAt)); err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	return nil
+}
+
+// GetSession возвращает сессию входа по её ID.
+func (a *AuthManageSQLite) GetSession(ctx context.Context, sessionID string) (entities.Session, error) {
+	const op = "sqlite.Auth.GetSession"
+
+	query := `SELECT id, people_id, refresh_hash, expires_at, revoked_at, created_at 
+		FROM auth_sessions 
+		WHERE id = $1;`
+
+	stmt, err := a.db.PrepareContext(ctx, query)
+	if err != nil {
+		return entities.Session{}, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	var (
+		session                   entities.Session
+		expires, revoked, created timeValue
+	)
+
+	err = stmt.QueryRowContext(ctx, sessionID).Scan(&session.ID, &session.PeopleID, &session.RefreshHash, &expires, &revoked, &created)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return session, fmt.Errorf("%w: session, operation: %s", domain.ErrNoRecordsFound, op)
+		}
+		return session, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	session.ExpiresAt = expires.Time
+	session.RevokedAt = revoked.Time
+	session.Created = created.Time
+
+	return session, nil
+}
+
+// RotateSession заменяет refresh token действующей сессии.
+// Замена выполняется, только если предъявлен текущий refresh token сессии.
+func (a *AuthManageSQLite) RotateSession(ctx context.Context, sessionID, oldRefreshHash, newRefreshHash string, expiresAt time.Time) error {
+	const op = "sqlite.Auth.RotateSession"
+
+	query := `UPDATE auth_sessions 
+		SET refresh_hash = $1, expires_at = $2
+		WHERE id = $3 AND refresh_hash = $4 AND revoked_at IS NULL;`
+
+	stmt, err := a.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, newRefreshHash, nullTime(expiresAt), sessionID, oldRefreshHash)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: active session, operation: %s", domain.ErrNoRecordsFound, op)
+	}
+
+	return nil
+}
+
+// RevokeSession отзывает сессию входа.
+func (a *AuthManageSQLite) RevokeSession(ctx context.Context, sessionID string, revokedAt time.Time) error {
+	const op = "sqlite.Auth.RevokeSession"
+
+	query := `UPDATE auth_sessions 
+		SET revoked_at = $1
+		WHERE id = $2 AND revoked_at IS NULL;`
+
+	stmt, err := a.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, nullTime(revokedAt), sessionID); err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	return nil
+}
+
+// RevokePeopleSessions отзывает все сессии пользователя, например после смены пароля.
+func (a *AuthManageSQLite) RevokePeopleSessions(ctx context.Context, peopleID int, revokedAt time.Time) error {
+	const op = "sqlite.Auth.RevokePeopleSessions"
+
+	query := `UPDATE auth_sessions 
+		SET revoked_at = $1
+		WHERE people_id = $2 AND revoked_at IS NULL;`
+
+	stmt, err := a.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, nullTime(revokedAt), peopleID); err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package sqlite
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+type ChecklistManageSQLite struct {
+	db *sql.DB
+}
+
+func NewChecklistManage(db *sql.DB) *ChecklistManageSQLite {
+	return &ChecklistManageSQLite{db: db}
+}
+
+// AddItem добавляет пункт в конец чек-листа задачи.
+func (c *ChecklistManageSQLite) AddItem(ctx context.Context, taskID int, title string) (int, error) {
+	const op = "sqlite.Checklist.AddItem"
+
+	stmt, err := c.db.PrepareContext(ctx, `INSERT INTO task_checklist_items (task_id, position, title)
+	SELECT $1, COALESCE(MAX(position), 0) + 1, $2 FROM task_checklist_items WHERE task_id = $1
+	RETURNING id;`)
+	if err != nil {
+		return 0, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	var id int
+	if err := stmt.QueryRowContext(ctx, taskID, title).Scan(&id); err != nil {
+		if constraintCode(err) == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY {
+			return 0, fmt.Errorf("%w: task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
+		}
+		return 0, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	return id, nil
+}
+
+// SetItemDone отмечает пункт чек-листа выполненным в момент doneAt, нулевой doneAt снимает отметку.
+// Повторная отметка сохраняет время первой.
+func (c *ChecklistManageSQLite) SetItemDone(ctx context.Context, taskID, itemID int, doneAt time.Time) error {
+	const op = "sqlite.Checklist.SetItemDone"
+
+	stmt, err := c.db.PrepareContext(ctx, `UPDATE task_checklist_items
+	SET done_at = CASE WHEN $3 IS NULL THEN NULL ELSE COALESCE(done_at, $3) END
+	WHERE id = $1 AND task_id = $2;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, itemID, taskID, nullTime(doneAt))
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: checklist item ID %d of task ID %d, operation: %s", domain.ErrNoRecordsFound, itemID, taskID, op)
+	}
+
+	return nil
+}
+
+// DeleteItem удаляет пункт чек-листа, номера остальных пунктов не меняются.
+func (c *ChecklistManageSQLite) DeleteItem(ctx context.Context, taskID, itemID int) error {
+	const op = "sqlite.Checklist.DeleteItem"
+
+	stmt, err := c.db.PrepareContext(ctx, `DELETE FROM task_checklist_items WHERE id = $1 AND task_id = $2;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, itemID, taskID)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: checklist item ID %d of task ID %d, operation: %s", domain.ErrNoRecordsFound, itemID, taskID, op)
+	}
+
+	return nil
+}
+
+// insertChecklist сохраняет пункты чек-листа новой задачи в порядке перечисления.
+func insertChecklist(ctx context.Context, tx *sql.Tx, taskID int, items []entities.ChecklistItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO task_checklist_items (task_id, position, title)
+	VALUES ($1, $2, $3);`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	for i, item := range items {
+		if _, err := stmt.ExecContext(ctx, taskID, i+1, item.Title); err != nil {
+			return fmt.Errorf("database error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// loadTaskChecklist заполняет чек-лист задачи и его выполнение.
+func loadTaskChecklist(ctx context.Context, db *sql.DB, task *entities.Task) error {
+	stmt, err := db.PrepareContext(ctx, `SELECT id, task_id, position, title, done_at
+	FROM task_checklist_items
+	WHERE task_id = $1
+	ORDER BY position, id;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, task.ID)
+	if err != nil {
+		return fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	var items []entities.ChecklistItem
+	for rows.Next() {
+		var (
+			item   entities.ChecklistItem
+			doneAt timeValue
+		)
+		if err := rows.Scan(&item.ID, &item.TaskID, &item.Position, &item.Title, &doneAt); err != nil {
+			return fmt.Errorf("scan error: %w", err)
+		}
+		item.Done = !doneAt.Time.IsZero()
+		item.DoneAt = doneAt.Time
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	task.SetChecklist(items)
+	return nil
+}
@@ -0,0 +1,231 @@
+package sqlite
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+type DependencyManageSQLite struct {
+	db *sql.DB
+}
+
+func NewDependencyManage(db *sql.DB) *DependencyManageSQLite {
+	return &DependencyManageSQLite{db: db}
+}
+
+// Add добавляет зависимость: задача blockerID блокирует задачу blockedID.
+// Повторное добавление не считается ошибкой, зависимость, замыкающая цепочку задач в цикл, отклоняется.
+func (d *DependencyManageSQLite) Add(ctx context.Context, blockerID, blockedID int) error {
+	const op = "sqlite.Dependency.Add"
+
+	// Соединение с базой одно, проверка и добавление в транзакции не пересекаются с другими добавлениями
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	// Цикл возникает, если blockedID уже прямо или через другие задачи блокирует blockerID,
+	// массивов в SQLite нет, цепочка собирается строкой ID через запятую
+	cycleQuery := `WITH RECURSIVE chain(id, path) AS (
+		SELECT $1, CAST($1 AS TEXT)
+		UNION ALL
+		SELECT d.blocked_id, c.path || ',' || d.blocked_id
+		FROM task_dependencies d
+		JOIN chain c ON d.blocker_id = c.id
+		WHERE c.id <> $2 AND instr(',' || c.path || ',', ',' || d.blocked_id || ',') = 0
+	)
+	SELECT path FROM chain WHERE id = $2 ORDER BY length(path) - length(replace(path, ',', '')) LIMIT 1;`
+
+	var path string
+	err = tx.QueryRowContext(ctx, cycleQuery, blockedID, blockerID).Scan(&path)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		tx.Rollback()
+		return fmt.Errorf("database error during cycle check: %w, operation: %s", err, op)
+	case blockerID != blockedID: // зависимость задачи от самой себя отклоняется ограничением таблицы
+		tx.Rollback()
+		chain := strings.ReplaceAll(path, ",", " -> ") + " -> " + strconv.Itoa(blockedID)
+		return fmt.Errorf("%w: %s, operation: %s", domain.ErrDependencyCycle, chain, op)
+	}
+
+	if _, err = tx.ExecContext(ctx, `INSERT INTO task_dependencies (blocker_id, blocked_id) 
+	VALUES ($1, $2) 
+	ON CONFLICT DO NOTHING;`, blockerID, blockedID); err != nil {
+		tx.Rollback()
+		switch constraintCode(err) {
+		case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+			return fmt.Errorf("%w: task ID %d or task ID %d, operation: %s", domain.ErrNoRecordsFound, blockerID, blockedID, op)
+		case sqlite3.SQLITE_CONSTRAINT_CHECK:
+			return fmt.Errorf("%w: task cannot block itself, operation: %s", domain.ErrInputData, op)
+		}
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	// Завершение транзакции
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("database error during commit: %w, operation: %s", err, op)
+	}
+
+	return nil
+}
+
+// Remove удаляет зависимость между задачами.
+func (d *DependencyManageSQLite) Remove(ctx context.Context, blockerID, blockedID int) error {
+	const op = "sqlite.Dependency.Remove"
+
+	stmt, err := d.db.PrepareContext(ctx, `DELETE FROM task_dependencies WHERE blocker_id = $1 AND blocked_id = $2;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, blockerID, blockedID)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: task ID %d does not block task ID %d, operation: %s", domain.ErrNoRecordsFound, blockerID, blockedID, op)
+	}
+
+	return nil
+}
+
+// Blockers возвращает задачи, которые блокируют задачу taskID, упорядоченные по ID.
+func (d *DependencyManageSQLite) Blockers(ctx context.Context, taskID int) ([]entities.TaskRef, error) {
+	const op = "sqlite.Dependency.Blockers"
+
+	refs, err := d.refs(ctx, `SELECT t.id, t.title, t.status 
+	FROM task_dependencies d
+	JOIN tasks t ON t.id = d.blocker_id
+	WHERE d.blocked_id = $1
+	ORDER BY t.id;`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	return refs, nil
+}
+
+// Blocked возвращает задачи, которые блокирует задача taskID, упорядоченные по ID.
+func (d *DependencyManageSQLite) Blocked(ctx context.Context, taskID int) ([]entities.TaskRef, error) {
+	const op = "sqlite.Dependency.Blocked"
+
+	refs, err := d.refs(ctx, `SELECT t.id, t.title, t.status 
+	FROM task_dependencies d
+	JOIN tasks t ON t.id = d.blocked_id
+	WHERE d.blocker_id = $1
+	ORDER BY t.id;`, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	return refs, nil
+}
+
+// refs выполняет запрос, возвращающий ID, заголовок и статус задач.
+func (d *DependencyManageSQLite) refs(ctx context.Context, query string, taskID int) ([]entities.TaskRef, error) {
+	stmt, err := d.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	refs := []entities.TaskRef{}
+	for rows.Next() {
+		var ref entities.TaskRef
+		if err := rows.Scan(&ref.ID, &ref.Title, &ref.Status); err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+		refs = append(refs, ref)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return refs, nil
+}
+
+// loadTaskDependencies заполняет BlockedBy и Blocks задач одним запросом,
+// у задачи без зависимостей списки пустые.
+func loadTaskDependencies(ctx context.Context, db *sql.DB, tasks []entities.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(tasks))
+	index := make(map[int]int, len(tasks))
+	for i := range tasks {
+		tasks[i].BlockedBy = []entities.TaskRef{}
+		tasks[i].Blocks = []entities.TaskRef{}
+		ids[i] = tasks[i].ID
+		index[tasks[i].ID] = i
+	}
+
+	// Массивов в SQLite нет, список ID передаётся как JSON и разбирается json_each
+	idList, err := json.Marshal(ids)
+	if err != nil {
+		return fmt.Errorf("marshal error: %w", err)
+	}
+
+	// blocked_by отмечает блокирующие задачи, остальные строки - задачи, которые блокирует задача task_id
+	stmt, err := db.PrepareContext(ctx, `SELECT d.blocked_id AS task_id, 1 AS blocked_by, t.id, t.title, t.status
+	FROM task_dependencies d
+	JOIN tasks t ON t.id = d.blocker_id
+	WHERE d.blocked_id IN (SELECT value FROM json_each($1))
+	UNION ALL
+	SELECT d.blocker_id, 0, t.id, t.title, t.status
+	FROM task_dependencies d
+	JOIN tasks t ON t.id = d.blocked_id
+	WHERE d.blocker_id IN (SELECT value FROM json_each($1))
+	ORDER BY 3;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, string(idList))
+	if err != nil {
+		return fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID int
+		var blockedBy bool
+		var ref entities.TaskRef
+		if err := rows.Scan(&taskID, &blockedBy, &ref.ID, &ref.Title, &ref.Status); err != nil {
+			return fmt.Errorf("scan error: %w", err)
+		}
+		task := &tasks[index[taskID]]
+		if blockedBy {
+			task.BlockedBy = append(task.BlockedBy, ref)
+		} else {
+			task.Blocks = append(task.Blocks, ref)
+		}
+	}
+
+	return rows.Err()
+}
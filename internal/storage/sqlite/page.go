@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// keyset строит условие продолжения списка после page.After и порядок сортировки по page.Sort.
+// columns сопоставляет полям сортировки выражения запроса, аргументы условия добавляются к args.
+// Для полей с разным направлением сортировки условие раскрывается в
+// (a > $1) OR (a = $1 AND b < $2), что сохраняет порядок ORDER BY a, b DESC.
+func keyset(page entities.PageRequest, columns map[string]string, args []interface{}) (string, string, []interface{}, error) {
+	sort := page.Sort
+	if len(sort) == 0 {
+		sort = []entities.SortField{{Field: "id"}}
+	}
+
+	order := make([]string, 0, len(sort))
+	for _, field := range sort {
+		column, ok := columns[field.Field]
+		if !ok {
+			return "", "", nil, fmt.Errorf("%w: unknown sort field %q", domain.ErrInputData, field.Field)
+		}
+		if field.Desc {
+			column += " DESC"
+		}
+		order = append(order, column)
+	}
+
+	if len(page.After) == 0 {
+		return "", strings.Join(order, ", "), args, nil
+	}
+	if len(page.After) != len(sort) {
+		return "", "", nil, fmt.Errorf("%w: cursor does not match sort", domain.ErrInputData)
+	}
+
+	var or []string
+	for i, field := range sort {
+		var and []string
+		for j := 0; j < i; j++ {
+			args = append(args, page.After[j])
+			and = append(and, fmt.Sprintf("%s = $%d", columns[sort[j].Field], len(args)))
+		}
+
+		cmp := ">"
+		if field.Desc {
+			cmp = "<"
+		}
+		args = append(args, page.After[i])
+		and = append(and, fmt.Sprintf("%s %s $%d", columns[field.Field], cmp, len(args)))
+
+		or = append(or, "("+strings.Join(and, " AND ")+")")
+	}
+
+	return "(" + strings.Join(or, " OR ") + ")", strings.Join(order, ", "), args, nil
+}
+
+// countRows возвращает результат запроса COUNT(*).
+func countRows(ctx context.Context, db *sql.DB, query string, args []interface{}) (int, error) {
+	stmt, err := db.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	var total int
+	if err := stmt.QueryRowContext(ctx, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("scan error: %w", err)
+	}
+
+	return total, nil
+}
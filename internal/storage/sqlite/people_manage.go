@@ -0,0 +1,356 @@
+package sqlite
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+type PeopleManageSQLite struct {
+	db *sql.DB
+}
+
+func NewPeopleManage(db *sql.DB) *PeopleManageSQLite {
+	return &PeopleManageSQLite{db: db}
+}
+
+func (p *PeopleManageSQLite) Create(ctx context.Context, people entities.People) (int, error) {
+	const op = "sqlite.People.Create"
+
+	stmt, err := p.db.PrepareContext(ctx, `INSERT INTO people_info (passport_series, passport_number, surname, name, patronymic, address, role, manager_id) 
+	VALUES ($1, $2, $3, $4, $5, $6, COALESCE(NULLIF($7, ''), 'member'), NULLIF($8, 0)) 
+	RETURNING id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s Prepare: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var id int
+
+	row := stmt.QueryRowContext(ctx, people.PassportSeries, people.PassportNumber, people.Surname, people.Name, people.Patronymic, people.Address, people.Role, people.ManagerID)
+
+	err = row.Scan(&id)
+	if err != nil {
+		switch constraintCode(err) {
+		case sqlite3.SQLITE_CONSTRAINT_CHECK, // паспорт вне диапазона или неизвестная роль
+			sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY: // руководитель не найден
+			return 0, fmt.Errorf("%w, operation: %s", domain.ErrInputData, op)
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE: // паспорт уже зарегистрирован
+			return 0, fmt.Errorf("%w: passport %d %d, operation: %s", domain.ErrAlreadyExists, people.PassportSeries, people.PassportNumber, op)
+		}
+		return 0, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	return id, nil
+}
+
+func (p *PeopleManageSQLite) GetByID(ctx context.Context, peopleID int) (entities.People, error) {
+	const op = "sqlite.People.Get"
+
+	stmt, err := p.db.PrepareContext(ctx, `SELECT id, passport_series, passport_number, surname, name, patronymic, address, role, COALESCE(manager_id, 0) FROM people_info WHERE id = $1;`)
+	if err != nil {
+		return entities.People{}, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	var people entities.People
+
+	row := stmt.QueryRowContext(ctx, peopleID)
+
+	err = row.Scan(&people.ID, &people.PassportSeries, &people.PassportNumber, &people.Surname, &people.Name, &people.Patronymic, &people.Address, &people.Role, &people.ManagerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return people, fmt.Errorf("%w: people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
+		}
+		return people, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	return people, nil
+}
+
+// peopleSortColumns поля сортировки списка пользователей.
+var peopleSortColumns = map[string]string{
+	"id":         "id",
+	"surname":    "surname",
+	"name":       "name",
+	"patronymic": "COALESCE(patronymic, '')",
+}
+
+// GetByFilter возвращает страницу пользователей, у которых совпадают все заданные поля фильтра,
+// и общее число таких пользователей.
+func (p *PeopleManageSQLite) GetByFilter(ctx context.Context, filterPeople entities.PeopleFilter, page entities.PageRequest) ([]entities.People, int, error) {
+	const op = "sqlite.People.GetByFilter"
+
+	// При отсутствии фильтров - выведет все записи.
+	where, args := peopleFilter(filterPeople)
+
+	total, err := countRows(ctx, p.db, `SELECT COUNT(*) FROM people_info`+where, args)
+	if err != nil {
+		return nil, 0, fmt.Errorf("count error: %w, operation: %s", err, op)
+	}
+
+	after, orderBy, args, err := keyset(page, peopleSortColumns, args)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	// Конструктор для запроса
+	var q strings.Builder
+
+	q.WriteString(`SELECT id, passport_series, passport_number, surname, name, patronymic, address, role, COALESCE(manager_id, 0)
+	FROM people_info`)
+	q.WriteString(where)
+
+	// Пагинация по курсору: записи после последней записи предыдущей страницы.
+	if after != "" {
+		q.WriteString(" AND " + after)
+	}
+	q.WriteString(" ORDER BY " + orderBy)
+	if page.Limit > 0 {
+		args = append(args, page.Limit)
+		q.WriteString(fmt.Sprintf(" LIMIT $%d", len(args)))
+	}
+
+	stmt, err := p.db.PrepareContext(ctx, q.String())
+	if err != nil {
+		return nil, 0, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	var peopleList []entities.People
+
+	for rows.Next() {
+		var people entities.People
+		if err := rows.Scan(&people.ID, &people.PassportSeries, &people.PassportNumber, &people.Surname, &people.Name, &people.Patronymic, &people.Address, &people.Role, &people.ManagerID); err != nil {
+			return nil, 0, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		peopleList = append(peopleList, people)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return peopleList, total, nil
+}
+
+// peopleFilter собирает условие WHERE по заданным полям фильтра.
+func peopleFilter(filterPeople entities.PeopleFilter) (string, []interface{}) {
+	var q strings.Builder
+	q.WriteString(" WHERE 1 = 1")
+
+	var args []interface{}
+	add := func(column string, value interface{}) {
+		args = append(args, value)
+		q.WriteString(fmt.Sprintf(" AND %s = $%d", column, len(args)))
+	}
+	match := func(field, column, value string) {
+		op := filterPeople.Match[field]
+		args = append(args, matchPattern(op, value))
+		q.WriteString(" AND " + matchCondition(op, column, len(args)))
+	}
+
+	if filterPeople.ID != 0 {
+		add("id", filterPeople.ID)
+	}
+	if filterPeople.PassportSeries != 0 {
+		add("passport_series", filterPeople.PassportSeries)
+	}
+	if filterPeople.PassportNumber != 0 {
+		add("passport_number", filterPeople.PassportNumber)
+	}
+	if filterPeople.Surname != "" {
+		match("surname", "surname", filterPeople.Surname)
+	}
+	if filterPeople.Name != "" {
+		match("name", "name", filterPeople.Name)
+	}
+	if filterPeople.Patronymic != "" {
+		match("patronymic", "COALESCE(patronymic, '')", filterPeople.Patronymic)
+	}
+	if filterPeople.Address != "" {
+		match("address", "address", filterPeople.Address)
+	}
+	if filterPeople.Role != "" {
+		add("role", filterPeople.Role)
+	}
+	if filterPeople.ManagerID != 0 {
+		add("manager_id", filterPeople.ManagerID)
+	}
+
+	return q.String(), args
+}
+
+// matchCondition возвращает условие сравнения column с аргументом $n способом op.
+// Обе стороны LIKE приводятся к нижнему регистру через unicode_lower, собственное сравнение LIKE без учёта
+// регистра в SQLite работает только для латинских букв.
+func matchCondition(op entities.MatchOp, column string, n int) string {
+	switch op {
+	case entities.MatchIExact:
+		return fmt.Sprintf("unicode_lower(%s) = unicode_lower($%d)", column, n)
+	case entities.MatchPrefix, entities.MatchContains:
+		return fmt.Sprintf(`unicode_lower(%s) LIKE unicode_lower($%d) ESCAPE '\'`, column, n)
+	}
+	return fmt.Sprintf("%s = $%d", column, n)
+}
+
+// matchPattern возвращает значение аргумента для matchCondition, для LIKE спецсимволы value экранируются.
+func matchPattern(op entities.MatchOp, value string) string {
+	switch op {
+	case entities.MatchPrefix:
+		return likeEscaper.Replace(value) + "%"
+	case entities.MatchContains:
+		return "%" + likeEscaper.Replace(value) + "%"
+	}
+	return value
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (p *PeopleManageSQLite) Update(ctx context.Context, people entities.People) error {
+	const op = "sqlite.People.Update"
+
+	// Проверяем, что ID предоставлен и хотя бы одно значение для обновления задано
+	if people.ID == 0 {
+		return fmt.Errorf("%w: missing ID, operation: %s", domain.ErrInputData, op)
+	}
+	if people.PassportSeries == 0 && people.PassportNumber == 0 && people.Surname == "" &&
+		people.Name == "" && people.Patronymic == "" && people.Address == "" &&
+		people.Role == "" && people.ManagerID == 0 {
+		return fmt.Errorf("%w: no values to update, operation: %s", domain.ErrInputData, op)
+	}
+
+	// Конструктор строки для запроса
+	var q strings.Builder
+	q.WriteString(`UPDATE people_info SET`)
+
+	var args []interface{}
+	argCount := 1
+
+	// Добавление значений в запрос
+	if people.PassportSeries != 0 {
+		q.WriteString(fmt.Sprintf(" passport_series = $%d,", argCount))
+		args = append(args, people.PassportSeries)
+		argCount++
+	}
+	if people.PassportNumber != 0 {
+		q.WriteString(fmt.Sprintf(" passport_number = $%d,", argCount))
+		args = append(args, people.PassportNumber)
+		argCount++
+	}
+	if people.Surname != "" {
+		q.WriteString(fmt.Sprintf(" surname = $%d,", argCount))
+		args = append(args, people.Surname)
+		argCount++
+	}
+	if people.Name != "" {
+		q.WriteString(fmt.Sprintf(" name = $%d,", argCount))
+		args = append(args, people.Name)
+		argCount++
+	}
+	if people.Patronymic != "" {
+		q.WriteString(fmt.Sprintf(" patronymic = $%d,", argCount))
+		args = append(args, people.Patronymic)
+		argCount++
+	}
+	if people.Address != "" {
+		q.WriteString(fmt.Sprintf(" address = $%d,", argCount))
+		args = append(args, people.Address)
+		argCount++
+	}
+	if people.Role != "" {
+		q.WriteString(fmt.Sprintf(" role = $%d,", argCount))
+		args = append(args, people.Role)
+		argCount++
+	}
+	if people.ManagerID != 0 {
+		q.WriteString(fmt.Sprintf(" manager_id = $%d,", argCount))
+		args = append(args, people.ManagerID)
+		argCount++
+	}
+
+	// Удаляем последнюю запятую
+	query := q.String()
+	if len(query) > len("UPDATE people_info SET") {
+		query = query[:len(query)-1] // Удаление последней запятой
+	}
+
+	// Добавление ID обновляемой записи
+	query += fmt.Sprintf(" WHERE id = $%d", argCount)
+	args = append(args, people.ID)
+
+	stmt, err := p.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, args...)
+	if err != nil {
+		switch constraintCode(err) {
+		case sqlite3.SQLITE_CONSTRAINT_CHECK, sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+			return fmt.Errorf("%w, operation: %s", domain.ErrInputData, op)
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE:
+			return fmt.Errorf("%w: passport %d %d, operation: %s", domain.ErrAlreadyExists, people.PassportSeries, people.PassportNumber, op)
+		}
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: people ID %d, operation: %s", domain.ErrNoRecordsFound, people.ID, op)
+	}
+
+	return nil
+}
+
+// List возвращает страницу всех пользователей и их общее число.
+func (p *PeopleManageSQLite) List(ctx context.Context, page entities.PageRequest) ([]entities.People, int, error) {
+	return p.GetByFilter(ctx, entities.PeopleFilter{}, page)
+}
+
+func (p *PeopleManageSQLite) Delete(ctx context.Context, peopleID int) error {
+	const op = "sqlite.People.Delete"
+
+	// Удаляется только пользователь, остальные таблицы не затрагиваются.
+	// Например, в дальнейшем, это позволит поменять исполнителя задачи.
+	// Foreign key для time_entries с опцией ON DELETE SET NULL.
+	q := `DELETE FROM people_info WHERE id = $1;`
+
+	stmt, err := p.db.PrepareContext(ctx, q)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, peopleID)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package sqlite
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+type ProjectManageSQLite struct {
+	db *sql.DB
+}
+
+func NewProjectManage(db *sql.DB) *ProjectManageSQLite {
+	return &ProjectManageSQLite{db: db}
+}
+
+func (p *ProjectManageSQLite) Create(ctx context.Context, project entities.Project) (int, error) {
+	const op = "sqlite.Project.Create"
+
+	stmt, err := p.db.PrepareContext(ctx, `INSERT INTO projects (name, description) 
+	VALUES ($1, $2) 
+	RETURNING id;`)
+	if err != nil {
+		return 0, fmt.Errorf("%s Prepare: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var id int
+
+	row := stmt.QueryRowContext(ctx, project.Name, project.Description)
+
+	err = row.Scan(&id)
+	if err != nil {
+		if constraintCode(err) == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+			return 0, fmt.Errorf("%w: project %q, operation: %s", domain.ErrAlreadyExists, project.Name, op)
+		}
+		return 0, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	return id, nil
+}
+
+func (p *ProjectManageSQLite) GetByID(ctx context.Context, projectID int) (entities.Project, error) {
+	const op = "sqlite.Project.GetByID"
+
+	stmt, err := p.db.PrepareContext(ctx, `SELECT id, name, COALESCE(description, '') FROM projects WHERE id = $1;`)
+	if err != nil {
+		return entities.Project{}, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	var project entities.Project
+
+	row := stmt.QueryRowContext(ctx, projectID)
+
+	err = row.Scan(&project.ID, &project.Name, &project.Description)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return project, fmt.Errorf("%w: project ID %d, operation: %s", domain.ErrNoRecordsFound, projectID, op)
+		}
+		return project, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	return project, nil
+}
+
+func (p *ProjectManageSQLite) List(ctx context.Context) ([]entities.Project, error) {
+	const op = "sqlite.Project.List"
+
+	q := `SELECT id, name, COALESCE(description, '') FROM projects ORDER BY id;`
+
+	stmt, err := p.db.PrepareContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	var projects []entities.Project
+
+	for rows.Next() {
+		var project entities.Project
+		if err := rows.Scan(&project.ID, &project.Name, &project.Description); err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		projects = append(projects, project)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return projects, nil
+}
+
+func (p *ProjectManageSQLite) Update(ctx context.Context, project entities.Project) error {
+	const op = "sqlite.Project.Update"
+
+	if project.ID == 0 {
+		return fmt.Errorf("%w: missing ID, operation: %s", domain.ErrInputData, op)
+	}
+	if project.Name == "" && project.Description == "" {
+		return fmt.Errorf("%w: no values to update, operation: %s", domain.ErrInputData, op)
+	}
+
+	// Конструктор строки для запроса
+	var sets []string
+	var args []interface{}
+
+	if project.Name != "" {
+		args = append(args, project.Name)
+		sets = append(sets, fmt.Sprintf("name = $%d", len(args)))
+	}
+	if project.Description != "" {
+		args = append(args, project.Description)
+		sets = append(sets, fmt.Sprintf("description = $%d", len(args)))
+	}
+
+	args = append(args, project.ID)
+	query := fmt.Sprintf("UPDATE projects SET %s WHERE id = $%d", strings.Join(sets, ", "), len(args))
+
+	stmt, err := p.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, args...)
+	if err != nil {
+		if constraintCode(err) == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+			return fmt.Errorf("%w: project %q, operation: %s", domain.ErrAlreadyExists, project.Name, op)
+		}
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: project ID %d, operation: %s", domain.ErrNoRecordsFound, project.ID, op)
+	}
+
+	return nil
+}
+
+func (p *ProjectManageSQLite) Delete(ctx context.Context, projectID int) error {
+	const op = "sqlite.Project.Delete"
+
+	// Задачи проекта не удаляются, foreign key с опцией ON DELETE SET NULL.
+	q := `DELETE FROM projects WHERE id = $1;`
+
+	stmt, err := p.db.PrepareContext(ctx, q)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: project ID %d, operation: %s", domain.ErrNoRecordsFound, projectID, op)
+	}
+
+	return nil
+}
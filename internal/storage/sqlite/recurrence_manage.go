@@ -0,0 +1,312 @@
+package sqlite
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+type RecurrenceManageSQLite struct {
+	db *sql.DB
+}
+
+func NewRecurrenceManage(db *sql.DB) *RecurrenceManageSQLite {
+	return &RecurrenceManageSQLite{db: db}
+}
+
+// Create сохраняет повторяющуюся задачу вместе с её исполнителями.
+func (r *RecurrenceManageSQLite) Create(ctx context.Context, rec entities.Recurrence) (int, error) {
+	const op = "sqlite.Recurrence.Create"
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+	defer tx.Rollback()
+
+	var id int
+	err = tx.QueryRowContext(ctx, `INSERT INTO recurrences (title, description, project_id, estimate_seconds, rule, start_at, next_run)
+	VALUES ($1, $2, NULLIF($3, 0), NULLIF($4, 0), $5, $6, $7)
+	RETURNING id;`, rec.Title, rec.Description, rec.ProjectID, rec.EstimateSeconds, rec.Rule, nullTime(rec.Start), nullTime(rec.NextRun)).Scan(&id)
+	if err != nil {
+		if constraintCode(err) == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY {
+			return 0, fmt.Errorf("%w: project ID %d not found, operation: %s", domain.ErrInputData, rec.ProjectID, op)
+		}
+		return 0, fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO recurrence_assignees (recurrence_id, people_id)
+	VALUES ($1, $2)
+	ON CONFLICT DO NOTHING;`)
+	if err != nil {
+		return 0, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	for _, peopleID := range rec.AssigneeIDs {
+		if _, err := stmt.ExecContext(ctx, id, peopleID); err != nil {
+			if constraintCode(err) == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY {
+				return 0, fmt.Errorf("%w: people ID %d not found, operation: %s", domain.ErrInputData, peopleID, op)
+			}
+			return 0, fmt.Errorf("database error during assign: %w, operation: %s", err, op)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("database error during commit: %w, operation: %s", err, op)
+	}
+
+	return id, nil
+}
+
+// recurrenceSelectQuery выбирает повторяющиеся задачи без исполнителей, их заполняет loadRecurrenceAssignees.
+const recurrenceSelectQuery = `SELECT id, title, COALESCE(description, ''), COALESCE(project_id, 0), COALESCE(estimate_seconds, 0), rule, start_at, next_run, last_run
+	FROM recurrences`
+
+func (r *RecurrenceManageSQLite) GetByID(ctx context.Context, recurrenceID int) (entities.Recurrence, error) {
+	const op = "sqlite.Recurrence.GetByID"
+
+	stmt, err := r.db.PrepareContext(ctx, recurrenceSelectQuery+`
+	WHERE id = $1;`)
+	if err != nil {
+		return entities.Recurrence{}, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rec, err := scanRecurrence(stmt.QueryRowContext(ctx, recurrenceID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return rec, fmt.Errorf("%w: recurrence ID %d, operation: %s", domain.ErrNoRecordsFound, recurrenceID, op)
+		}
+		return rec, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	recs := []entities.Recurrence{rec}
+	if err := loadRecurrenceAssignees(ctx, r.db, recs); err != nil {
+		return rec, fmt.Errorf("load assignees error: %w, operation: %s", err, op)
+	}
+
+	return recs[0], nil
+}
+
+// List возвращает все повторяющиеся задачи в порядке создания.
+func (r *RecurrenceManageSQLite) List(ctx context.Context) ([]entities.Recurrence, error) {
+	const op = "sqlite.Recurrence.List"
+
+	recs, err := r.query(ctx, recurrenceSelectQuery+`
+	ORDER BY id;`)
+	if err != nil {
+		return nil, fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	return recs, nil
+}
+
+// Due возвращает повторяющиеся задачи, следующее повторение которых наступило к моменту at.
+func (r *RecurrenceManageSQLite) Due(ctx context.Context, at time.Time) ([]entities.Recurrence, error) {
+	const op = "sqlite.Recurrence.Due"
+
+	recs, err := r.query(ctx, recurrenceSelectQuery+`
+	WHERE next_run <= $1
+	ORDER BY next_run, id;`, nullTime(at))
+	if err != nil {
+		return nil, fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	return recs, nil
+}
+
+func (r *RecurrenceManageSQLite) query(ctx context.Context, query string, args ...any) ([]entities.Recurrence, error) {
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	recs := []entities.Recurrence{}
+	for rows.Next() {
+		rec, err := scanRecurrence(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w", err)
+		}
+		recs = append(recs, rec)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	if err := loadRecurrenceAssignees(ctx, r.db, recs); err != nil {
+		return nil, fmt.Errorf("load assignees error: %w", err)
+	}
+
+	return recs, nil
+}
+
+func scanRecurrence(row scanner) (entities.Recurrence, error) {
+	var (
+		rec                     entities.Recurrence
+		estimate                int64
+		start, nextRun, lastRun timeValue
+	)
+
+	err := row.Scan(&rec.ID, &rec.Title, &rec.Description, &rec.ProjectID, &estimate, &rec.Rule, &start, &nextRun, &lastRun)
+	if err != nil {
+		return rec, err
+	}
+
+	rec.SetEstimate(time.Duration(estimate) * time.Second)
+	rec.Start = start.Time
+	rec.NextRun = nextRun.Time
+	rec.LastRun = lastRun.Time
+
+	return rec, nil
+}
+
+// loadRecurrenceAssignees заполняет исполнителей повторяющихся задач одним запросом.
+func loadRecurrenceAssignees(ctx context.Context, db *sql.DB, recs []entities.Recurrence) error {
+	if len(recs) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(recs))
+	index := make(map[int]int, len(recs))
+	for i := range recs {
+		recs[i].AssigneeIDs = []int{}
+		ids[i] = recs[i].ID
+		index[recs[i].ID] = i
+	}
+
+	idList, err := json.Marshal(ids)
+	if err != nil {
+		return fmt.Errorf("marshal error: %w", err)
+	}
+
+	stmt, err := db.PrepareContext(ctx, `SELECT recurrence_id, people_id FROM recurrence_assignees
+	WHERE recurrence_id IN (SELECT value FROM json_each($1))
+	ORDER BY people_id;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, string(idList))
+	if err != nil {
+		return fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var recurrenceID, peopleID int
+		if err := rows.Scan(&recurrenceID, &peopleID); err != nil {
+			return fmt.Errorf("scan error: %w", err)
+		}
+		i := index[recurrenceID]
+		recs[i].AssigneeIDs = append(recs[i].AssigneeIDs, peopleID)
+	}
+
+	return rows.Err()
+}
+
+// Delete удаляет повторяющуюся задачу, уже созданные задачи остаются.
+func (r *RecurrenceManageSQLite) Delete(ctx context.Context, recurrenceID int) error {
+	const op = "sqlite.Recurrence.Delete"
+
+	stmt, err := r.db.PrepareContext(ctx, `DELETE FROM recurrences WHERE id = $1;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, recurrenceID)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: recurrence ID %d, operation: %s", domain.ErrNoRecordsFound, recurrenceID, op)
+	}
+
+	return nil
+}
+
+// Materialize создаёт задачу повторения occurrence вместе с исполнителями повторяющейся задачи
+// и переносит следующее повторение на nextRun, нулевой nextRun завершает расписание.
+// Повторение создаётся не более одного раза: если оно уже создано, возвращается 0 без ошибки.
+func (r *RecurrenceManageSQLite) Materialize(ctx context.Context, recurrenceID int, occurrence time.Time, task entities.Task, nextRun time.Time) (int, error) {
+	const op = "sqlite.Recurrence.Materialize"
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `INSERT INTO recurrence_runs (recurrence_id, occurrence_at)
+	VALUES ($1, $2)
+	ON CONFLICT (recurrence_id, occurrence_at) DO NOTHING;`, recurrenceID, nullTime(occurrence))
+	if err != nil {
+		if constraintCode(err) == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY {
+			return 0, fmt.Errorf("%w: recurrence ID %d, operation: %s", domain.ErrNoRecordsFound, recurrenceID, op)
+		}
+		return 0, fmt.Errorf("database error during insertRun: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return 0, nil
+	}
+
+	var taskID int
+	err = tx.QueryRowContext(ctx, `INSERT INTO tasks (title, description, status, project_id, due_date, estimate_seconds)
+	VALUES ($1, $2, COALESCE(NULLIF($3, ''), 'todo'), NULLIF($4, 0), $5, NULLIF($6, 0))
+	RETURNING id;`, task.Title, task.Description, task.Status, task.ProjectID, nullTime(task.DueDate), task.EstimateSeconds).Scan(&taskID)
+	if err != nil {
+		return 0, fmt.Errorf("database error during insertTask: %w, operation: %s", err, op)
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO task_assignees (task_id, people_id, assigned_at)
+	SELECT $1, people_id, $2 FROM recurrence_assignees WHERE recurrence_id = $3;`, taskID, nullTime(time.Now().UTC()), recurrenceID)
+	if err != nil {
+		return 0, fmt.Errorf("database error during assign: %w, operation: %s", err, op)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE recurrence_runs SET task_id = $1
+	WHERE recurrence_id = $2 AND occurrence_at = $3;`, taskID, recurrenceID, nullTime(occurrence))
+	if err != nil {
+		return 0, fmt.Errorf("database error during updateRun: %w, operation: %s", err, op)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE recurrences SET next_run = $1, last_run = $2
+	WHERE id = $3;`, nullTime(nextRun), nullTime(occurrence), recurrenceID)
+	if err != nil {
+		return 0, fmt.Errorf("database error during updateRecurrence: %w, operation: %s", err, op)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("database error during commit: %w, operation: %s", err, op)
+	}
+
+	return taskID, nil
+}
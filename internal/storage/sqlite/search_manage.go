@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+type SearchManageSQLite struct {
+	db *sql.DB
+}
+
+func NewSearchManage(db *sql.DB) *SearchManageSQLite {
+	return &SearchManageSQLite{db: db}
+}
+
+// Search ищет пользователей по ФИО и задачи по заголовку и описанию.
+// Как и в PostgreSQL, каждый из terms должен совпадать с началом какого-либо слова записи.
+// lower и LIKE в SQLite не учитывают регистр только латинских букв, поэтому записи сравниваются в Go,
+// для однопользовательской базы это допустимо. Релевантность - доля совпавших слов,
+// слова заголовка задачи весят больше слов описания.
+func (s *SearchManageSQLite) Search(ctx context.Context, terms []string, limit int) ([]entities.SearchResult, error) {
+	const op = "sqlite.Search.Search"
+
+	q := `SELECT 'people', id, surname || ' ' || name || ' ' || COALESCE(patronymic, ''), ''
+	FROM people_info
+	UNION ALL
+	SELECT 'task', id, title, COALESCE(description, '')
+	FROM tasks
+	ORDER BY 1, 2;`
+
+	stmt, err := s.db.PrepareContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	var results []entities.SearchResult
+
+	for rows.Next() {
+		var result entities.SearchResult
+		var description string
+		if err := rows.Scan(&result.Type, &result.ID, &result.Title, &description); err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+
+		result.Title = strings.Join(strings.Fields(result.Title), " ")
+
+		var ok bool
+		if result.Rank, ok = searchRank(terms, searchField{result.Title, 1}, searchField{description, 0.4}); ok {
+			results = append(results, result)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	slices.SortStableFunc(results, func(a, b entities.SearchResult) int {
+		switch {
+		case a.Rank > b.Rank:
+			return -1
+		case a.Rank < b.Rank:
+			return 1
+		}
+		return 0
+	})
+
+	if limit > 0 && limit < len(results) {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+// searchField текст поля записи и вес его слов в релевантности.
+type searchField struct {
+	text   string
+	weight float64
+}
+
+// searchRank возвращает долю совпавших слов полей с учётом весов.
+// false, если какой-либо из terms не совпал с началом ни одного слова.
+func searchRank(terms []string, fields ...searchField) (float64, bool) {
+	matched := make([]bool, len(terms))
+	var score, total float64
+
+	for _, field := range fields {
+		words := strings.FieldsFunc(strings.ToLower(field.text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+
+		for _, word := range words {
+			total += field.weight
+			for i, term := range terms {
+				if strings.HasPrefix(word, term) {
+					matched[i] = true
+					score += field.weight
+					break
+				}
+			}
+		}
+	}
+
+	if total == 0 || slices.Contains(matched, false) {
+		return 0, false
+	}
+
+	return score / total, true
+}
@@ -0,0 +1,147 @@
+package sqlite
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Встроенная lower в SQLite меняет регистр только латинских букв, unicode_lower работает для любых букв,
+// например для кириллицы в фильтрах без учёта регистра. Функция регистрируется для всех новых соединений.
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction("unicode_lower", 1, func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		switch v := args[0].(type) {
+		case string:
+			return strings.ToLower(v), nil
+		case []byte:
+			return strings.ToLower(string(v)), nil
+		}
+		return args[0], nil
+	})
+}
+
+// NewSQLiteDB открывает файл базы SQLite, файл создаётся при первом запуске.
+// Внешние ключи по умолчанию в SQLite выключены и включаются для каждого соединения.
+func NewSQLiteDB(path string) (*sql.DB, error) {
+	const operation = "storage.NewSQLiteDB"
+
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Set("_time_format", "sqlite")
+
+	db, err := sql.Open("sqlite", "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("%s - failed to open database: %w", operation, err)
+	}
+
+	// Запись в SQLite последовательная, одно соединение исключает ошибки SQLITE_BUSY
+	db.SetMaxOpenConns(1)
+
+	if err = db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%s - failed to ping database: %w", operation, err)
+	}
+
+	return db, nil
+}
+
+// constraintCode возвращает расширенный код нарушенного ограничения SQLite, 0 - ошибка не связана с ограничениями.
+func constraintCode(err error) int {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code()&0xff == sqlite3.SQLITE_CONSTRAINT {
+		return sqliteErr.Code()
+	}
+	return 0
+}
+
+// isOverlap сообщает, отклонена ли запись триггером пересечения интервалов.
+func isOverlap(err error) bool {
+	return constraintCode(err) == sqlite3.SQLITE_CONSTRAINT_TRIGGER && strings.Contains(err.Error(), "excl_time_entries_overlap")
+}
+
+// scanner общий интерфейс для *sql.Row и *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// timeLayouts форматы времени, в которых SQLite возвращает значения TIMESTAMP.
+var timeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// timeValue читает время из колонки TIMESTAMP или из выражения над ней, для которого
+// драйвер возвращает строку. NULL читается как нулевое время.
+type timeValue struct {
+	Time time.Time
+}
+
+func (v *timeValue) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		v.Time = time.Time{}
+	case time.Time:
+		v.Time = src.UTC()
+	case string:
+		return v.parse(src)
+	case []byte:
+		return v.parse(string(src))
+	default:
+		return fmt.Errorf("unsupported time value %T", src)
+	}
+	return nil
+}
+
+func (v *timeValue) parse(s string) error {
+	s = strings.TrimSuffix(s, "Z")
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			v.Time = t.UTC()
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid time value %q", s)
+}
+
+// nullTime преобразует нулевое время в NULL, остальное время сохраняется в UTC,
+// чтобы строки сравнивались в том же порядке, что и моменты времени.
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC()
+}
+
+// formatInterval форматирует длительность как интервал PostgreSQL в часах, например 26:30:00.
+// julianday в SQLite точна до миллисекунд, погрешность вычислений с плавающей точкой отбрасывается.
+func formatInterval(seconds float64) string {
+	d := time.Duration(math.Round(seconds*1e3)) * time.Millisecond
+	h, m, s := int(d/time.Hour), int(d%time.Hour/time.Minute), d%time.Minute
+	if s%time.Second == 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", h, m, int(s/time.Second))
+	}
+	return fmt.Sprintf("%02d:%02d:%09.6f", h, m, s.Seconds())
+}
+
+// duration переводит секунды в длительность, отбрасывая погрешность julianday, как formatInterval.
+func duration(seconds float64) time.Duration {
+	return time.Duration(math.Round(seconds*1e3)) * time.Millisecond
+}
+
+// hours переводит секунды в часы с точностью до сотых.
+func hours(seconds float64) float64 {
+	return math.Round(seconds/36) / 100
+}
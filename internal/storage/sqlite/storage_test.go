@@ -0,0 +1,27 @@
+package sqlite_test
+
+import (
+	"TaskSync/internal/storage"
+	"TaskSync/internal/storage/sqlite"
+	"TaskSync/internal/storage/storagetest"
+	migrations "TaskSync/pkg/migration"
+	"path/filepath"
+	"testing"
+)
+
+// Каждая проверка получает новый файл базы с применёнными миграциями.
+func TestStorage(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) *storage.Storage {
+		db, err := sqlite.NewSQLiteDB(filepath.Join(t.TempDir(), "task-sync.db"))
+		if err != nil {
+			t.Fatalf("NewSQLiteDB: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		if err := migrations.RunSQLiteMigrations(db); err != nil {
+			t.Fatalf("RunSQLiteMigrations: %v", err)
+		}
+
+		return storage.NewSQLiteStorage(db)
+	})
+}
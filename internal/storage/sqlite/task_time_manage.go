@@ -0,0 +1,746 @@
+package sqlite
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+type TimeManageSQLite struct {
+	db *sql.DB
+}
+
+func NewTimeManage(db *sql.DB) *TimeManageSQLite {
+	return &TimeManageSQLite{db: db}
+}
+
+// secondsExpr длительность отрезка в секундах, в SQLite нет типа INTERVAL.
+const secondsExpr = `(julianday(te.end_time) - julianday(te.start_time)) * 86400.0`
+
+// timeEntryError преобразует нарушения ограничений time_entries в ошибки хранилища.
+func timeEntryError(err error) error {
+	switch {
+	case isOverlap(err):
+		return domain.ErrTimeEntryOverlap
+	case constraintCode(err) == sqlite3.SQLITE_CONSTRAINT_UNIQUE:
+		return domain.ErrTimeEntryStarted
+	case constraintCode(err) != 0:
+		return domain.ErrInputData
+	}
+	return nil
+}
+
+// StartTimeEntry открывает новую сессию работы пользователя над задачей и возвращает её ID.
+// Если у пользователя есть запись по задаче без start_time (создана вместе с задачей), сессия открывается в ней.
+// Приостановленная сессия пользователя при этом считается завершённой.
+// Изменения пересекающихся записей применяются в той же транзакции, при пометке помечается и новая запись.
+func (t *TimeManageSQLite) StartTimeEntry(ctx context.Context, taskID, peopleID int, startTime time.Time, overlaps entities.OverlapChanges) (int, error) {
+	const op = "sqlite.Time.StartTimeEntry"
+
+	if peopleID <= 0 || taskID <= 0 {
+		return 0, fmt.Errorf("%w, operation: %s", domain.ErrInputData, op)
+	}
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	id, err := startTimeEntry(ctx, tx, taskID, peopleID, startTime, overlaps)
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("%w, operation: %s", err, op)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("database error during commit: %w, operation: %s", err, op)
+	}
+
+	return id, nil
+}
+
+// startTimeEntry открывает сессию в транзакции tx: завершает приостановленную сессию пользователя,
+// применяет изменения пересекающихся записей и заполняет пустую запись по задаче или создаёт новую.
+func startTimeEntry(ctx context.Context, tx *sql.Tx, taskID, peopleID int, startTime time.Time, overlaps entities.OverlapChanges) (int, error) {
+	// Завершение приостановленной сессии
+	_, err := tx.ExecContext(ctx, `UPDATE time_entries SET paused = FALSE WHERE people_id = $1 AND paused;`, peopleID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to close paused time entry: %w", err)
+	}
+
+	if err := applyOverlaps(ctx, tx, overlaps, startTime); err != nil {
+		return 0, fmt.Errorf("overlaps error: %w", err)
+	}
+
+	// Заполнение пустой записи, созданной вместе с задачей
+	updateQuery := `UPDATE time_entries 
+		SET start_time = $1, overlaps = $4
+		WHERE id = (
+			SELECT id FROM time_entries
+			WHERE task_id = $2 AND people_id = $3 AND start_time IS NULL
+			ORDER BY id
+			LIMIT 1
+		)
+		RETURNING id;`
+
+	flag := len(overlaps.Flag) > 0
+
+	var id int
+	err = tx.QueryRowContext(ctx, updateQuery, nullTime(startTime), taskID, peopleID, flag).Scan(&id)
+	if err == sql.ErrNoRows {
+		// Пустой записи нет - открываем новую сессию
+		insertQuery := `INSERT INTO time_entries (people_id, task_id, start_time, overlaps) 
+			VALUES ($1, $2, $3, $4)
+			RETURNING id;`
+		err = tx.QueryRowContext(ctx, insertQuery, peopleID, taskID, nullTime(startTime), flag).Scan(&id)
+	}
+	if err != nil {
+		if storageErr := timeEntryError(err); storageErr != nil {
+			return 0, fmt.Errorf("%w for task ID %d", storageErr, taskID)
+		}
+		return 0, fmt.Errorf("failed to start time entry for task ID %d: %w", taskID, err)
+	}
+
+	return id, nil
+}
+
+// EndTimeEntry закрывает открытую или приостановленную сессию пользователя по задаче.
+// У приостановленной сессии время последнего отрезка не меняется.
+func (t *TimeManageSQLite) EndTimeEntry(ctx context.Context, taskID, peopleID int, endTime time.Time) error {
+	const op = "sqlite.Time.EndTimeEntry"
+
+	query := `UPDATE time_entries 
+		SET end_time = COALESCE(end_time, $1), paused = FALSE
+		WHERE task_id = $2 AND people_id = $3
+			AND start_time IS NOT NULL AND (end_time IS NULL OR paused);`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, nullTime(endTime), taskID, peopleID)
+	if err != nil {
+		if storageErr := timeEntryError(err); storageErr != nil {
+			return fmt.Errorf("%w for task ID %d, operation: %s", storageErr, taskID, op)
+		}
+		return fmt.Errorf("failed to update end time for task ID %d: %w, operation: %s", taskID, err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: no open time entry for task ID %d, operation: %s", domain.ErrNoRecordsFound, taskID, op)
+	}
+
+	return nil
+}
+
+// EndTaskTimeEntries закрывает все открытые и приостановленные сессии по задаче.
+func (t *TimeManageSQLite) EndTaskTimeEntries(ctx context.Context, taskID int, endTime time.Time) error {
+	const op = "sqlite.Time.EndTaskTimeEntries"
+
+	// MAX с несколькими аргументами в SQLite - аналог GREATEST
+	query := `UPDATE time_entries 
+		SET end_time = COALESCE(end_time, MAX($1, start_time)), paused = FALSE
+		WHERE task_id = $2 AND start_time IS NOT NULL AND (end_time IS NULL OR paused);`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.ExecContext(ctx, nullTime(endTime), taskID); err != nil {
+		return fmt.Errorf("failed to end time entries for task ID %d: %w, operation: %s", taskID, err, op)
+	}
+
+	return nil
+}
+
+// PauseTimeEntry приостанавливает запущенную сессию пользователя: текущий отрезок закрывается и помечается паузой.
+func (t *TimeManageSQLite) PauseTimeEntry(ctx context.Context, peopleID int, pauseTime time.Time) error {
+	const op = "sqlite.Time.PauseTimeEntry"
+
+	query := `UPDATE time_entries 
+		SET end_time = $1, paused = TRUE
+		WHERE people_id = $2 AND start_time IS NOT NULL AND end_time IS NULL;`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, nullTime(pauseTime), peopleID)
+	if err != nil {
+		if storageErr := timeEntryError(err); storageErr != nil {
+			return fmt.Errorf("%w for people ID %d, operation: %s", storageErr, peopleID, op)
+		}
+		return fmt.Errorf("failed to pause time entry for people ID %d: %w, operation: %s", peopleID, err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: no running time entry for people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
+	}
+
+	return nil
+}
+
+// ResumeTimeEntry продолжает приостановленную сессию пользователя новым отрезком и возвращает его ID.
+// Изменения пересекающихся записей применяются в той же транзакции, при пометке помечается и новый отрезок.
+func (t *TimeManageSQLite) ResumeTimeEntry(ctx context.Context, peopleID int, resumeTime time.Time, overlaps entities.OverlapChanges) (int, error) {
+	const op = "sqlite.Time.ResumeTimeEntry"
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	// Снятие паузы с последнего отрезка сессии
+	updateQuery := `UPDATE time_entries 
+		SET paused = FALSE
+		WHERE people_id = $1 AND paused
+		RETURNING task_id, COALESCE(session_id, id);`
+
+	var taskID, sessionID int
+	err = tx.QueryRowContext(ctx, updateQuery, peopleID).Scan(&taskID, &sessionID)
+	if err != nil {
+		tx.Rollback()
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("%w: no paused time entry for people ID %d, operation: %s", domain.ErrNoRecordsFound, peopleID, op)
+		}
+		return 0, fmt.Errorf("failed to resume time entry for people ID %d: %w, operation: %s", peopleID, err, op)
+	}
+
+	if err := applyOverlaps(ctx, tx, overlaps, resumeTime); err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("overlaps error: %w, operation: %s", err, op)
+	}
+
+	// Новый отрезок той же сессии
+	insertQuery := `INSERT INTO time_entries (people_id, task_id, start_time, session_id, overlaps) 
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id;`
+
+	var id int
+	err = tx.QueryRowContext(ctx, insertQuery, peopleID, taskID, nullTime(resumeTime), sessionID, len(overlaps.Flag) > 0).Scan(&id)
+	if err != nil {
+		tx.Rollback()
+		if storageErr := timeEntryError(err); storageErr != nil {
+			return 0, fmt.Errorf("%w for people ID %d, operation: %s", storageErr, peopleID, op)
+		}
+		return 0, fmt.Errorf("failed to insert time entry: %w, operation: %s", err, op)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("database error during commit: %w, operation: %s", err, op)
+	}
+
+	return id, nil
+}
+
+// ActiveTimeEntry возвращает запущенную или приостановленную сессию пользователя.
+// ElapsedSeconds содержит время только закрытых отрезков сессии, время текущего отрезка досчитывает вызывающий.
+// Если активной сессии нет, возвращается пустой ActiveTimer.
+func (t *TimeManageSQLite) ActiveTimeEntry(ctx context.Context, peopleID int) (entities.ActiveTimer, error) {
+	const op = "sqlite.Time.ActiveTimeEntry"
+
+	query := `SELECT a.id, a.task_id, t.title, a.people_id, a.start_time, a.paused,
+		(
+			SELECT MIN(te.start_time)
+			FROM time_entries te
+			WHERE COALESCE(te.session_id, te.id) = COALESCE(a.session_id, a.id)
+		),
+		(
+			SELECT CAST(ROUND(COALESCE(SUM(` + secondsExpr + `), 0)) AS INTEGER)
+			FROM time_entries te
+			WHERE COALESCE(te.session_id, te.id) = COALESCE(a.session_id, a.id) AND te.end_time IS NOT NULL
+		)
+	FROM time_entries a
+	JOIN tasks t ON t.id = a.task_id
+	WHERE a.people_id = $1 AND a.start_time IS NOT NULL AND (a.end_time IS NULL OR a.paused)
+	ORDER BY a.start_time DESC
+	LIMIT 1;`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return entities.ActiveTimer{}, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	var (
+		timer                      entities.ActiveTimer
+		segmentStart, sessionStart timeValue
+	)
+
+	err = stmt.QueryRowContext(ctx, peopleID).Scan(&timer.TimeEntryID, &timer.TaskID, &timer.TaskTitle, &timer.PeopleID, &segmentStart, &timer.Paused,
+		&sessionStart, &timer.ElapsedSeconds)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return entities.ActiveTimer{}, nil
+		}
+		return entities.ActiveTimer{}, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	timer.SegmentStart = segmentStart.Time
+	timer.SessionStart = sessionStart.Time
+
+	return timer, nil
+}
+
+// OverlappingTimeEntries возвращает записи пользователя, пересекающиеся с интервалом [startTime, endTime).
+// Нулевой endTime означает интервал без верхней границы, как у открытой сессии.
+// Запись excludeID в проверке не участвует.
+func (t *TimeManageSQLite) OverlappingTimeEntries(ctx context.Context, peopleID int, startTime, endTime time.Time, excludeID int) ([]entities.TimeEntry, error) {
+	const op = "sqlite.Time.OverlappingTimeEntries"
+
+	// Условия повторяют оператор && для tsrange: пустые интервалы ни с чем не пересекаются
+	query := `SELECT id, task_id, people_id, start_time, end_time, overlaps, created_at
+		FROM time_entries
+		WHERE people_id = $1 AND id <> $4 AND start_time IS NOT NULL
+			AND (end_time IS NULL OR (end_time > start_time AND end_time > $2))
+			AND ($3 IS NULL OR ($3 > $2 AND start_time < $3))
+		ORDER BY start_time, id;`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, peopleID, nullTime(startTime), nullTime(endTime), excludeID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	var entries []entities.TimeEntry
+
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return entries, nil
+}
+
+// applyOverlaps применяет в транзакции изменения записей, пересекающихся с новой записью, начатой в at:
+// обрезает записи до at и помечает записи как пересекающиеся.
+func applyOverlaps(ctx context.Context, tx *sql.Tx, changes entities.OverlapChanges, at time.Time) error {
+	if len(changes.Trim) > 0 {
+		stmt, err := tx.PrepareContext(ctx, `UPDATE time_entries 
+		SET end_time = $1
+		WHERE id = $2 AND start_time <= $1;`)
+		if err != nil {
+			return fmt.Errorf("prepare error: %w", err)
+		}
+		defer stmt.Close()
+
+		for _, entryID := range changes.Trim {
+			result, err := stmt.ExecContext(ctx, nullTime(at), entryID)
+			if err != nil {
+				return fmt.Errorf("failed to trim time entry ID %d: %w", entryID, err)
+			}
+
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return fmt.Errorf("error retrieving affected rows: %w", err)
+			}
+
+			if rowsAffected == 0 {
+				return fmt.Errorf("%w: time entry ID %d", domain.ErrNoRecordsFound, entryID)
+			}
+		}
+	}
+
+	if len(changes.Flag) > 0 {
+		// В SQLite нет массивов, список ID передаётся отдельными параметрами
+		placeholders := make([]string, len(changes.Flag))
+		args := make([]interface{}, len(changes.Flag))
+		for i, id := range changes.Flag {
+			placeholders[i] = fmt.Sprintf("$%d", i+1)
+			args[i] = id
+		}
+
+		query := `UPDATE time_entries SET overlaps = TRUE WHERE id IN (` + strings.Join(placeholders, ", ") + `);`
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to flag time entries: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListTimeEntries возвращает все сессии работы над задачей в порядке их начала.
+func (t *TimeManageSQLite) ListTimeEntries(ctx context.Context, taskID int) ([]entities.TimeEntry, error) {
+	const op = "sqlite.Time.ListTimeEntries"
+
+	query := `SELECT id, task_id, people_id, start_time, end_time, overlaps, created_at
+		FROM time_entries
+		WHERE task_id = $1
+		ORDER BY start_time IS NULL, start_time, id;`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	var entries []entities.TimeEntry
+
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return entries, nil
+}
+
+// scanTimeEntry читает запись time_entries, NULL значения остаются нулевыми.
+func scanTimeEntry(row scanner) (entities.TimeEntry, error) {
+	var (
+		entry               entities.TimeEntry
+		peopleID            sql.NullInt64
+		start, end, created timeValue
+	)
+
+	if err := row.Scan(&entry.ID, &entry.TaskID, &peopleID, &start, &end, &entry.Overlaps, &created); err != nil {
+		return entry, err
+	}
+
+	entry.PeopleID = int(peopleID.Int64)
+	entry.StartTime = start.Time
+	entry.EndTime = end.Time
+	entry.Created = created.Time
+
+	return entry, nil
+}
+
+// TasksTimeSpent возвращает время, затраченное пользователем на задачи за период, по завершённым сессиям.
+// Нулевой peopleID означает всех пользователей, ненулевой projectID ограничивает выборку задачами проекта.
+func (t *TimeManageSQLite) TasksTimeSpent(ctx context.Context, peopleID, projectID int, startTime, endTime time.Time) ([]entities.TaskTimeSpent, error) {
+	const op = "sqlite.Time.TasksTimeSpent"
+
+	query := `
+	SELECT
+		p.id AS people_id,
+		p.surname,
+		p.name,
+		COALESCE(p.patronymic, ''),
+		t.id AS task_id,
+		t.title AS task_title,
+		COALESCE(SUM(` + secondsExpr + `), 0) AS seconds
+	FROM
+		tasks t
+	JOIN
+		time_entries te ON t.id = te.task_id
+	JOIN
+		people_info p ON te.people_id = p.id
+	WHERE
+		-- Учитываются только завершённые сессии, время суммируется по всем сессиям задачи.
+		-- peopleID 0 - все пользователи
+		($1 = 0 OR p.id = $1)
+		AND te.start_time >= $2
+		AND te.end_time <= $3
+		AND te.end_time IS NOT NULL
+		AND ($4 = 0 OR t.project_id = $4)
+	GROUP BY
+		p.id, p.surname, p.name, p.patronymic, t.id, t.title
+	ORDER BY
+		p.surname, p.name, p.id, seconds DESC;
+	`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, peopleID, nullTime(startTime), nullTime(endTime), projectID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	var entries []entities.TaskTimeSpent
+
+	for rows.Next() {
+		var (
+			entry   entities.TaskTimeSpent
+			seconds float64
+		)
+		if err := rows.Scan(&entry.PeopleID, &entry.Surname, &entry.Name, &entry.Patronymic, &entry.TaskID, &entry.TaskTitle, &seconds); err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		entry.TimeSpent = formatInterval(seconds)
+		entry.Hours = hours(seconds)
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return entries, nil
+}
+
+// ProjectsTimeSpent возвращает время, затраченное на задачи каждого проекта за определённый период.
+// Нулевой peopleID означает всех пользователей.
+func (t *TimeManageSQLite) ProjectsTimeSpent(ctx context.Context, peopleID int, startTime, endTime time.Time) ([]entities.ProjectTimeSpent, error) {
+	const op = "sqlite.Time.ProjectsTimeSpent"
+
+	query := `
+	SELECT
+		pr.id AS project_id,
+		pr.name AS project_name,
+		COALESCE(SUM(` + secondsExpr + `), 0) AS seconds
+	FROM
+		projects pr
+	JOIN
+		tasks t ON t.project_id = pr.id
+	JOIN
+		time_entries te ON t.id = te.task_id
+	WHERE
+		($1 = 0 OR te.people_id = $1)
+		AND te.start_time >= $2
+		AND te.end_time <= $3
+		AND te.end_time IS NOT NULL
+	GROUP BY
+		pr.id, pr.name
+	ORDER BY
+		seconds DESC;
+	`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, peopleID, nullTime(startTime), nullTime(endTime))
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	var entries []entities.ProjectTimeSpent
+
+	for rows.Next() {
+		var (
+			entry   entities.ProjectTimeSpent
+			seconds float64
+		)
+		if err := rows.Scan(&entry.ProjectID, &entry.ProjectName, &seconds); err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		entry.TimeSpent = formatInterval(seconds)
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return entries, nil
+}
+
+// TagsTimeSpent возвращает время, затраченное на задачи с каждой меткой за определённый период.
+// Время задачи с несколькими метками учитывается в каждой из них, задачи без меток не учитываются.
+// Нулевой peopleID означает всех пользователей, ненулевой projectID ограничивает выборку задачами проекта.
+func (t *TimeManageSQLite) TagsTimeSpent(ctx context.Context, peopleID, projectID int, startTime, endTime time.Time) ([]entities.TagTimeSpent, error) {
+	const op = "sqlite.Time.TagsTimeSpent"
+
+	query := `
+	SELECT
+		tg.id AS tag_id,
+		tg.name AS tag_name,
+		COALESCE(SUM(` + secondsExpr + `), 0) AS seconds
+	FROM
+		tags tg
+	JOIN
+		task_tags tt ON tt.tag_id = tg.id
+	JOIN
+		tasks t ON t.id = tt.task_id
+	JOIN
+		time_entries te ON t.id = te.task_id
+	WHERE
+		($1 = 0 OR te.people_id = $1)
+		AND te.start_time >= $2
+		AND te.end_time <= $3
+		AND te.end_time IS NOT NULL
+		AND ($4 = 0 OR t.project_id = $4)
+	GROUP BY
+		tg.id, tg.name
+	ORDER BY
+		seconds DESC, tg.name;
+	`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, peopleID, nullTime(startTime), nullTime(endTime), projectID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	var entries []entities.TagTimeSpent
+
+	for rows.Next() {
+		var (
+			entry   entities.TagTimeSpent
+			seconds float64
+		)
+		if err := rows.Scan(&entry.TagID, &entry.TagName, &seconds); err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		entry.TimeSpent = formatInterval(seconds)
+		entry.Hours = hours(seconds)
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	return entries, nil
+}
+
+// TaskEstimates возвращает задачи с оценкой или сроком и время их завершённых сессий, всего и по пользователям.
+// Ненулевой peopleID оставляет задачи, на которые пользователь назначен или над которыми работал,
+// и из времени по пользователям только его время, общее время задачи учитывает всех.
+// Ненулевой projectID оставляет задачи проекта. Задачи упорядочены по ID.
+func (t *TimeManageSQLite) TaskEstimates(ctx context.Context, peopleID, projectID int) ([]entities.TaskEstimate, error) {
+	const op = "sqlite.Time.TaskEstimates"
+
+	// Строка на пару задача-пользователь, время записей удалённых пользователей попадает в строку без пользователя
+	query := `
+	SELECT
+		t.id,
+		t.title,
+		t.status,
+		COALESCE(t.project_id, 0),
+		COALESCE(pr.name, ''),
+		t.due_date,
+		COALESCE(t.estimate_seconds, 0),
+		p.id,
+		COALESCE(p.surname, ''),
+		COALESCE(p.name, ''),
+		COALESCE(p.patronymic, ''),
+		COALESCE(SUM(` + secondsExpr + `), 0) AS seconds
+	FROM
+		tasks t
+	LEFT JOIN
+		projects pr ON pr.id = t.project_id
+	LEFT JOIN
+		time_entries te ON te.task_id = t.id AND te.start_time IS NOT NULL AND te.end_time IS NOT NULL
+	LEFT JOIN
+		people_info p ON p.id = te.people_id
+	WHERE
+		(t.estimate_seconds IS NOT NULL OR t.due_date IS NOT NULL)
+		AND ($2 = 0 OR t.project_id = $2)
+		AND ($1 = 0
+			OR EXISTS (SELECT 1 FROM task_assignees ta WHERE ta.task_id = t.id AND ta.people_id = $1 AND ta.unassigned_at IS NULL)
+			OR EXISTS (SELECT 1 FROM time_entries w WHERE w.task_id = t.id AND w.people_id = $1))
+	GROUP BY
+		t.id, pr.name, p.id
+	ORDER BY
+		t.id, p.surname, p.name, p.id;
+	`
+
+	stmt, err := t.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, peopleID, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	estimates := []entities.TaskEstimate{}
+	var actual []float64
+
+	for rows.Next() {
+		var (
+			task     entities.TaskEstimate
+			dueDate  timeValue
+			estimate int64
+			people   entities.PeopleTimeActual
+			personID sql.NullInt64
+			seconds  float64
+		)
+		err := rows.Scan(&task.TaskID, &task.TaskTitle, &task.Status, &task.ProjectID, &task.ProjectName, &dueDate, &estimate,
+			&personID, &people.Surname, &people.Name, &people.Patronymic, &seconds)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+
+		if n := len(estimates); n == 0 || estimates[n-1].TaskID != task.TaskID {
+			task.DueDate = dueDate.Time
+			task.SetEstimate(time.Duration(estimate) * time.Second)
+			task.People = []entities.PeopleTimeActual{}
+			estimates = append(estimates, task)
+			actual = append(actual, 0)
+		}
+
+		last := len(estimates) - 1
+		actual[last] += seconds
+		if personID.Valid && (peopleID == 0 || int(personID.Int64) == peopleID) {
+			people.PeopleID = int(personID.Int64)
+			people.SetActual(duration(seconds))
+			estimates[last].People = append(estimates[last].People, people)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	for i := range estimates {
+		estimates[i].SetActual(duration(actual[i]))
+	}
+
+	return estimates, nil
+}
@@ -0,0 +1,289 @@
+package sqlite
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+type TemplateManageSQLite struct {
+	db *sql.DB
+}
+
+func NewTemplateManage(db *sql.DB) *TemplateManageSQLite {
+	return &TemplateManageSQLite{db: db}
+}
+
+// Create сохраняет шаблон задачи вместе с пунктами чек-листа.
+func (t *TemplateManageSQLite) Create(ctx context.Context, tpl entities.TaskTemplate) (int, error) {
+	const op = "sqlite.Template.Create"
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+	defer tx.Rollback()
+
+	var id int
+	err = tx.QueryRowContext(ctx, `INSERT INTO task_templates (name, title_pattern, description, project_id, assignee_id, estimate_seconds)
+	VALUES ($1, $2, $3, NULLIF($4, 0), NULLIF($5, 0), NULLIF($6, 0))
+	RETURNING id;`, tpl.Name, tpl.TitlePattern, tpl.Description, tpl.ProjectID, tpl.AssigneeID, tpl.EstimateSeconds).Scan(&id)
+	if err != nil {
+		if storageErr := templateError(err, tpl); storageErr != nil {
+			return 0, fmt.Errorf("%w, operation: %s", storageErr, op)
+		}
+		return 0, fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	if err := insertTemplateItems(ctx, tx, id, tpl.Checklist); err != nil {
+		return 0, fmt.Errorf("insertItems error: %w, operation: %s", err, op)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("database error during commit: %w, operation: %s", err, op)
+	}
+
+	return id, nil
+}
+
+func (t *TemplateManageSQLite) GetByID(ctx context.Context, templateID int) (entities.TaskTemplate, error) {
+	const op = "sqlite.Template.GetByID"
+
+	stmt, err := t.db.PrepareContext(ctx, templateSelectQuery+`
+	WHERE id = $1;`)
+	if err != nil {
+		return entities.TaskTemplate{}, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	tpl, err := scanTemplate(stmt.QueryRowContext(ctx, templateID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return tpl, fmt.Errorf("%w: template ID %d, operation: %s", domain.ErrNoRecordsFound, templateID, op)
+		}
+		return tpl, fmt.Errorf("scan error: %w, operation: %s", err, op)
+	}
+
+	templates := []entities.TaskTemplate{tpl}
+	if err := loadTemplateItems(ctx, t.db, templates); err != nil {
+		return tpl, fmt.Errorf("checklist error: %w, operation: %s", err, op)
+	}
+
+	return templates[0], nil
+}
+
+// List возвращает все шаблоны в порядке имени.
+func (t *TemplateManageSQLite) List(ctx context.Context) ([]entities.TaskTemplate, error) {
+	const op = "sqlite.Template.List"
+
+	stmt, err := t.db.PrepareContext(ctx, templateSelectQuery+`
+	ORDER BY name, id;`)
+	if err != nil {
+		return nil, fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query error: %w, operation: %s", err, op)
+	}
+	defer rows.Close()
+
+	templates := []entities.TaskTemplate{}
+	for rows.Next() {
+		tpl, err := scanTemplate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan error: %w, operation: %s", err, op)
+		}
+		templates = append(templates, tpl)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w, operation: %s", err, op)
+	}
+
+	if err := loadTemplateItems(ctx, t.db, templates); err != nil {
+		return nil, fmt.Errorf("checklist error: %w, operation: %s", err, op)
+	}
+
+	return templates, nil
+}
+
+// Update заменяет данные и чек-лист шаблона, задачи, уже созданные по шаблону, не меняются.
+func (t *TemplateManageSQLite) Update(ctx context.Context, tpl entities.TaskTemplate) error {
+	const op = "sqlite.Template.Update"
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE task_templates
+	SET name = $1, title_pattern = $2, description = $3, project_id = NULLIF($4, 0), assignee_id = NULLIF($5, 0), estimate_seconds = NULLIF($6, 0)
+	WHERE id = $7;`, tpl.Name, tpl.TitlePattern, tpl.Description, tpl.ProjectID, tpl.AssigneeID, tpl.EstimateSeconds, tpl.ID)
+	if err != nil {
+		if storageErr := templateError(err, tpl); storageErr != nil {
+			return fmt.Errorf("%w, operation: %s", storageErr, op)
+		}
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: template ID %d, operation: %s", domain.ErrNoRecordsFound, tpl.ID, op)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task_template_items WHERE template_id = $1;`, tpl.ID); err != nil {
+		return fmt.Errorf("database error during deleteItems: %w, operation: %s", err, op)
+	}
+
+	if err := insertTemplateItems(ctx, tx, tpl.ID, tpl.Checklist); err != nil {
+		return fmt.Errorf("insertItems error: %w, operation: %s", err, op)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("database error during commit: %w, operation: %s", err, op)
+	}
+
+	return nil
+}
+
+// Delete удаляет шаблон, задачи, созданные по шаблону, остаются.
+func (t *TemplateManageSQLite) Delete(ctx context.Context, templateID int) error {
+	const op = "sqlite.Template.Delete"
+
+	stmt, err := t.db.PrepareContext(ctx, `DELETE FROM task_templates WHERE id = $1;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w, operation: %s", err, op)
+	}
+	defer stmt.Close()
+
+	result, err := stmt.ExecContext(ctx, templateID)
+	if err != nil {
+		return fmt.Errorf("database error: %w, operation: %s", err, op)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error retrieving affected rows: %w, operation: %s", err, op)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: template ID %d, operation: %s", domain.ErrNoRecordsFound, templateID, op)
+	}
+
+	return nil
+}
+
+// templateSelectQuery выбирает шаблоны без чек-листа, его заполняет loadTemplateItems.
+const templateSelectQuery = `SELECT id, name, title_pattern, COALESCE(description, ''), COALESCE(project_id, 0), COALESCE(assignee_id, 0), COALESCE(estimate_seconds, 0)
+	FROM task_templates`
+
+func scanTemplate(row scanner) (entities.TaskTemplate, error) {
+	var (
+		tpl      entities.TaskTemplate
+		estimate int64
+	)
+
+	err := row.Scan(&tpl.ID, &tpl.Name, &tpl.TitlePattern, &tpl.Description, &tpl.ProjectID, &tpl.AssigneeID, &estimate)
+	if err != nil {
+		return tpl, err
+	}
+
+	tpl.SetEstimate(time.Duration(estimate) * time.Second)
+
+	return tpl, nil
+}
+
+// templateError переводит нарушения ограничений шаблона в ошибки хранилища, nil - ошибка другого рода.
+func templateError(err error, tpl entities.TaskTemplate) error {
+	switch constraintCode(err) {
+	case sqlite3.SQLITE_CONSTRAINT_UNIQUE:
+		return fmt.Errorf("%w: template %q", domain.ErrAlreadyExists, tpl.Name)
+	case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+		return fmt.Errorf("%w: project ID %d or people ID %d not found", domain.ErrInputData, tpl.ProjectID, tpl.AssigneeID)
+	}
+
+	return nil
+}
+
+// insertTemplateItems сохраняет пункты чек-листа шаблона в порядке перечисления.
+func insertTemplateItems(ctx context.Context, tx *sql.Tx, templateID int, items []string) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO task_template_items (template_id, position, title)
+	VALUES ($1, $2, $3);`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	for i, title := range items {
+		if _, err := stmt.ExecContext(ctx, templateID, i+1, title); err != nil {
+			return fmt.Errorf("database error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// loadTemplateItems заполняет чек-листы шаблонов одним запросом.
+func loadTemplateItems(ctx context.Context, db *sql.DB, templates []entities.TaskTemplate) error {
+	if len(templates) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(templates))
+	index := make(map[int]int, len(templates))
+	for i := range templates {
+		templates[i].Checklist = []string{}
+		ids[i] = templates[i].ID
+		index[templates[i].ID] = i
+	}
+
+	idList, err := json.Marshal(ids)
+	if err != nil {
+		return fmt.Errorf("marshal error: %w", err)
+	}
+
+	stmt, err := db.PrepareContext(ctx, `SELECT template_id, title FROM task_template_items
+	WHERE template_id IN (SELECT value FROM json_each($1))
+	ORDER BY template_id, position;`)
+	if err != nil {
+		return fmt.Errorf("prepare error: %w", err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.QueryContext(ctx, string(idList))
+	if err != nil {
+		return fmt.Errorf("query error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			templateID int
+			title      string
+		)
+		if err := rows.Scan(&templateID, &title); err != nil {
+			return fmt.Errorf("scan error: %w", err)
+		}
+		i := index[templateID]
+		templates[i].Checklist = append(templates[i].Checklist, title)
+	}
+
+	return rows.Err()
+}
@@ -2,7 +2,9 @@ package storage
 
 import (
 	"TaskSync/internal/entities"
+	"TaskSync/internal/storage/memory"
 	"TaskSync/internal/storage/postgres"
+	"TaskSync/internal/storage/sqlite"
 	"context"
 	"database/sql"
 	"time"
@@ -15,38 +17,211 @@ import (
 type PeopleManage interface {
 	Create(ctx context.Context, people entities.People) (int, error)
 	GetByID(ctx context.Context, peopleID int) (entities.People, error)
-	GetByFilter(ctx context.Context, filterPeople entities.People, limit, offset int) ([]entities.People, error)
-	List(ctx context.Context) ([]entities.People, error)
+	GetByFilter(ctx context.Context, filterPeople entities.PeopleFilter, page entities.PageRequest) ([]entities.People, int, error)
+	List(ctx context.Context, page entities.PageRequest) ([]entities.People, int, error)
 	Update(ctx context.Context, people entities.People) error
 	Delete(ctx context.Context, peopleID int) error
 }
 
 type TaskManage interface {
-	Create(ctx context.Context, task entities.Task) (int, error)
-	GetByID(ctx context.Context, taskID int) (entities.Task, error)
-	List(ctx context.Context) ([]entities.Task, error)
+	Create(ctx context.Context, task entities.Task, overlaps entities.OverlapChanges) (int, error)
+	// subtree - вернуть задачу вместе со всеми подзадачами и временем, просуммированным по поддереву
+	GetByID(ctx context.Context, taskID int, subtree bool) (entities.Task, error)
+	List(ctx context.Context, filter entities.TaskFilter, page entities.PageRequest) ([]entities.Task, int, error)
 	Update(ctx context.Context, taskID int, title string, description string) error
 	UpdatePeople(ctx context.Context, peopleID, taskID int) error
+	UpdateProject(ctx context.Context, projectID, taskID int) error
+	UpdateParent(ctx context.Context, parentID, taskID int) error
+	UpdateSchedule(ctx context.Context, taskID int, dueDate time.Time, estimate time.Duration) error
+	UpdateStatus(ctx context.Context, taskID int, from, to entities.TaskStatus, timer entities.TimerStart) error
 	Delete(ctx context.Context, taskID int) error
 }
 
+type ProjectManage interface {
+	Create(ctx context.Context, project entities.Project) (int, error)
+	GetByID(ctx context.Context, projectID int) (entities.Project, error)
+	List(ctx context.Context) ([]entities.Project, error)
+	Update(ctx context.Context, project entities.Project) error
+	Delete(ctx context.Context, projectID int) error
+}
+
+// метки задач
+type TagManage interface {
+	Create(ctx context.Context, tag entities.Tag) (int, error)
+	GetByID(ctx context.Context, tagID int) (entities.Tag, error)
+	List(ctx context.Context) ([]entities.Tag, error)
+	Update(ctx context.Context, tag entities.Tag) error
+	Delete(ctx context.Context, tagID int) error
+	AttachTag(ctx context.Context, taskID, tagID int) error
+	DetachTag(ctx context.Context, taskID, tagID int) error
+}
+
+// зависимости между задачами: blockerID блокирует blockedID
+type DependencyManage interface {
+	Add(ctx context.Context, blockerID, blockedID int) error
+	Remove(ctx context.Context, blockerID, blockedID int) error
+	Blockers(ctx context.Context, taskID int) ([]entities.TaskRef, error)
+	Blocked(ctx context.Context, taskID int) ([]entities.TaskRef, error)
+}
+
+// исполнители задач и история назначений
+type AssigneeManage interface {
+	Assign(ctx context.Context, taskID, peopleID int, at time.Time) error
+	Unassign(ctx context.Context, taskID, peopleID int, at time.Time) error
+	History(ctx context.Context, taskID int) ([]entities.TaskAssignment, error)
+	TimeByAssignee(ctx context.Context, taskID int) ([]entities.AssigneeTimeSpent, error)
+}
+
+// повторяющиеся задачи и создание задач по их расписанию
+type RecurrenceManage interface {
+	Create(ctx context.Context, rec entities.Recurrence) (int, error)
+	GetByID(ctx context.Context, recurrenceID int) (entities.Recurrence, error)
+	List(ctx context.Context) ([]entities.Recurrence, error)
+	Delete(ctx context.Context, recurrenceID int) error
+	Due(ctx context.Context, at time.Time) ([]entities.Recurrence, error)
+	// повторение создаётся не более одного раза, для уже созданного возвращается 0 без ошибки
+	Materialize(ctx context.Context, recurrenceID int, occurrence time.Time, task entities.Task, nextRun time.Time) (int, error)
+}
+
+// чек-листы задач, нулевой doneAt снимает отметку пункта
+type ChecklistManage interface {
+	AddItem(ctx context.Context, taskID int, title string) (int, error)
+	SetItemDone(ctx context.Context, taskID, itemID int, doneAt time.Time) error
+	DeleteItem(ctx context.Context, taskID, itemID int) error
+}
+
+// шаблоны задач
+type TemplateManage interface {
+	Create(ctx context.Context, tpl entities.TaskTemplate) (int, error)
+	GetByID(ctx context.Context, templateID int) (entities.TaskTemplate, error)
+	List(ctx context.Context) ([]entities.TaskTemplate, error)
+	Update(ctx context.Context, tpl entities.TaskTemplate) error
+	Delete(ctx context.Context, templateID int) error
+}
+
 // управление временем выполнения
 type TimeManage interface {
-	StartTimeEntry(ctx context.Context, taskID int, timeEntries time.Time) error
-	EndTimeEntry(ctx context.Context, taskID int, endTime time.Time) error
-	TasksTimeSpent(ctx context.Context, peopleID int, startTime, endTime time.Time) ([]entities.TaskTimeSpent, error)
+	StartTimeEntry(ctx context.Context, taskID, peopleID int, startTime time.Time, overlaps entities.OverlapChanges) (int, error)
+	EndTimeEntry(ctx context.Context, taskID, peopleID int, endTime time.Time) error
+	EndTaskTimeEntries(ctx context.Context, taskID int, endTime time.Time) error
+	PauseTimeEntry(ctx context.Context, peopleID int, pauseTime time.Time) error
+	ResumeTimeEntry(ctx context.Context, peopleID int, resumeTime time.Time, overlaps entities.OverlapChanges) (int, error)
+	ActiveTimeEntry(ctx context.Context, peopleID int) (entities.ActiveTimer, error)
+	OverlappingTimeEntries(ctx context.Context, peopleID int, startTime, endTime time.Time, excludeID int) ([]entities.TimeEntry, error)
+	ListTimeEntries(ctx context.Context, taskID int) ([]entities.TimeEntry, error)
+	TasksTimeSpent(ctx context.Context, peopleID, projectID int, startTime, endTime time.Time) ([]entities.TaskTimeSpent, error)
+	ProjectsTimeSpent(ctx context.Context, peopleID int, startTime, endTime time.Time) ([]entities.ProjectTimeSpent, error)
+	TagsTimeSpent(ctx context.Context, peopleID, projectID int, startTime, endTime time.Time) ([]entities.TagTimeSpent, error)
+	TaskEstimates(ctx context.Context, peopleID, projectID int) ([]entities.TaskEstimate, error)
+}
+
+// управление паролями и сессиями входа
+type AuthManage interface {
+	SetPasswordHash(ctx context.Context, peopleID int, hash string) error
+	GetPasswordHash(ctx context.Context, peopleID int) (string, error)
+	CreateSession(ctx context.Context, session entities.Session) error
+	GetSession(ctx context.Context, sessionID string) (entities.Session, error)
+	RotateSession(ctx context.Context, sessionID, oldRefreshHash, newRefreshHash string, expiresAt time.Time) error
+	RevokeSession(ctx context.Context, sessionID string, revokedAt time.Time) error
+	RevokePeopleSessions(ctx context.Context, peopleID int, revokedAt time.Time) error
+}
+
+type APIKeyManage interface {
+	CreateAPIKey(ctx context.Context, key entities.APIKey) (int, error)
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (entities.APIKey, error)
+	ListAPIKeys(ctx context.Context, peopleID int) ([]entities.APIKey, error)
+	RevokeAPIKey(ctx context.Context, keyID, peopleID int, revokedAt time.Time) error
+	TouchAPIKey(ctx context.Context, keyID int, usedAt time.Time) error
+}
+
+type TimesheetManage interface {
+	GetTimesheet(ctx context.Context, peopleID int, weekStart time.Time) (entities.Timesheet, error)
+	SubmitTimesheet(ctx context.Context, peopleID int, weekStart, submittedAt time.Time) error
+	ReviewTimesheet(ctx context.Context, peopleID int, weekStart time.Time, status entities.TimesheetStatus, comment string, reviewerID int, reviewedAt time.Time) error
+	ApprovedWeek(ctx context.Context, peopleID int, start, end time.Time) (time.Time, error)
+	TimeSegments(ctx context.Context, peopleID int, start, end, now time.Time) ([]entities.TaskTimeSegment, error)
+}
+
+// полнотекстовый поиск по пользователям и задачам
+type SearchManage interface {
+	Search(ctx context.Context, terms []string, limit int) ([]entities.SearchResult, error)
 }
 
 type Storage struct {
 	PeopleManage
 	TaskManage
+	ProjectManage
+	TagManage
+	DependencyManage
+	AssigneeManage
+	RecurrenceManage
+	ChecklistManage
+	TemplateManage
 	TimeManage
+	AuthManage
+	APIKeyManage
+	TimesheetManage
+	SearchManage
 }
 
 func NewStorage(db *sql.DB) *Storage {
 	return &Storage{
-		PeopleManage: postgres.NewPeopleManage(db),
-		TaskManage:   postgres.NewTaskManage(db),
-		TimeManage:   postgres.NewTimeManage(db),
+		PeopleManage:     postgres.NewPeopleManage(db),
+		TaskManage:       postgres.NewTaskManage(db),
+		ProjectManage:    postgres.NewProjectManage(db),
+		TagManage:        postgres.NewTagManage(db),
+		DependencyManage: postgres.NewDependencyManage(db),
+		AssigneeManage:   postgres.NewAssigneeManage(db),
+		RecurrenceManage: postgres.NewRecurrenceManage(db),
+		ChecklistManage:  postgres.NewChecklistManage(db),
+		TemplateManage:   postgres.NewTemplateManage(db),
+		TimeManage:       postgres.NewTimeManage(db),
+		AuthManage:       postgres.NewAuthManage(db),
+		APIKeyManage:     postgres.NewAPIKeyManage(db),
+		TimesheetManage:  postgres.NewTimesheetManage(db),
+		SearchManage:     postgres.NewSearchManage(db),
+	}
+}
+
+// NewMemoryStorage создает хранилище в памяти процесса, данные не сохраняются между запусками.
+// Используется для локальной разработки и тестов без PostgreSQL.
+func NewMemoryStorage() *Storage {
+	db := memory.NewDB()
+
+	return &Storage{
+		PeopleManage:     memory.NewPeopleManage(db),
+		TaskManage:       memory.NewTaskManage(db),
+		ProjectManage:    memory.NewProjectManage(db),
+		TagManage:        memory.NewTagManage(db),
+		DependencyManage: memory.NewDependencyManage(db),
+		AssigneeManage:   memory.NewAssigneeManage(db),
+		RecurrenceManage: memory.NewRecurrenceManage(db),
+		ChecklistManage:  memory.NewChecklistManage(db),
+		TemplateManage:   memory.NewTemplateManage(db),
+		TimeManage:       memory.NewTimeManage(db),
+		AuthManage:       memory.NewAuthManage(db),
+		APIKeyManage:     memory.NewAPIKeyManage(db),
+		TimesheetManage:  memory.NewTimesheetManage(db),
+		SearchManage:     memory.NewSearchManage(db),
+	}
+}
+
+// NewSQLiteStorage создает хранилище в файле SQLite для однопользовательских и офлайн установок.
+func NewSQLiteStorage(db *sql.DB) *Storage {
+	return &Storage{
+		PeopleManage:     sqlite.NewPeopleManage(db),
+		TaskManage:       sqlite.NewTaskManage(db),
+		ProjectManage:    sqlite.NewProjectManage(db),
+		TagManage:        sqlite.NewTagManage(db),
+		DependencyManage: sqlite.NewDependencyManage(db),
+		AssigneeManage:   sqlite.NewAssigneeManage(db),
+		RecurrenceManage: sqlite.NewRecurrenceManage(db),
+		ChecklistManage:  sqlite.NewChecklistManage(db),
+		TemplateManage:   sqlite.NewTemplateManage(db),
+		TimeManage:       sqlite.NewTimeManage(db),
+		AuthManage:       sqlite.NewAuthManage(db),
+		APIKeyManage:     sqlite.NewAPIKeyManage(db),
+		TimesheetManage:  sqlite.NewTimesheetManage(db),
+		SearchManage:     sqlite.NewSearchManage(db),
 	}
 }
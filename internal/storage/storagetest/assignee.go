@@ -0,0 +1,144 @@
+package storagetest
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"testing"
+)
+
+func testAssignee(t *testing.T, newStorage Factory) {
+	subtest(t, "History", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		firstID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		secondID := createPeople(t, ctx, s, newPeople("Petrov"))
+		taskID := createTask(t, ctx, s, entities.Task{Title: "Task"})
+
+		got, err := s.TaskManage.GetByID(ctx, taskID, false)
+		noError(t, err, "GetByID without assignees")
+		if got.Assignees == nil || len(got.Assignees) != 0 {
+			t.Fatalf("task without assignees has Assignees %#v, want empty list", got.Assignees)
+		}
+
+		noError(t, s.AssigneeManage.Assign(ctx, taskID, firstID, at(0)), "Assign")
+		noError(t, s.AssigneeManage.Assign(ctx, taskID, secondID, at(10)), "Assign second")
+		noError(t, s.AssigneeManage.Assign(ctx, taskID, firstID, at(20)), "Assign repeated")
+
+		isError(t, s.AssigneeManage.Assign(ctx, 999, firstID, at(0)), domain.ErrNoRecordsFound, "Assign to unknown task")
+		isError(t, s.AssigneeManage.Assign(ctx, taskID, 999, at(0)), domain.ErrNoRecordsFound, "Assign unknown people")
+
+		got, err = s.TaskManage.GetByID(ctx, taskID, false)
+		noError(t, err, "GetByID")
+		if len(got.Assignees) != 2 || got.Assignees[0].PeopleID != firstID || got.Assignees[1].PeopleID != secondID {
+			t.Fatalf("GetByID Assignees = %+v, want people %d, %d", got.Assignees, firstID, secondID)
+		}
+		if got.Assignees[0].Surname != "Ivanov" {
+			t.Fatalf("Assignees[0].Surname = %q, want Ivanov", got.Assignees[0].Surname)
+		}
+
+		noError(t, s.AssigneeManage.Unassign(ctx, taskID, firstID, at(30)), "Unassign")
+		isError(t, s.AssigneeManage.Unassign(ctx, taskID, firstID, at(40)), domain.ErrNoRecordsFound, "Unassign twice")
+
+		// Снятый исполнитель может быть назначен снова, прежнее назначение остаётся в истории
+		noError(t, s.AssigneeManage.Assign(ctx, taskID, firstID, at(50)), "Assign again")
+
+		history, err := s.AssigneeManage.History(ctx, taskID)
+		noError(t, err, "History")
+		if len(history) != 3 {
+			t.Fatalf("History = %+v, want 3 assignments", history)
+		}
+		if history[0].PeopleID != firstID || history[0].Current() {
+			t.Fatalf("History[0] = %+v, want finished assignment of people %d", history[0], firstID)
+		}
+		sameTime(t, history[0].AssignedAt, at(0), "History[0].AssignedAt")
+		sameTime(t, history[0].UnassignedAt, at(30), "History[0].UnassignedAt")
+		if history[1].PeopleID != secondID || !history[1].Current() {
+			t.Fatalf("History[1] = %+v, want current assignment of people %d", history[1], secondID)
+		}
+		if history[2].PeopleID != firstID || !history[2].Current() {
+			t.Fatalf("History[2] = %+v, want current assignment of people %d", history[2], firstID)
+		}
+
+		list, _, err := s.TaskManage.List(ctx, entities.TaskFilter{}, entities.PageRequest{})
+		noError(t, err, "List")
+		if len(list) != 1 || len(list[0].Assignees) != 2 {
+			t.Fatalf("List Assignees = %+v", list)
+		}
+
+		// Удаление пользователя снимает его назначения
+		noError(t, s.PeopleManage.Delete(ctx, secondID), "Delete people")
+		history, err = s.AssigneeManage.History(ctx, taskID)
+		noError(t, err, "History after Delete")
+		if len(history) != 2 {
+			t.Fatalf("History after Delete = %+v, want 2 assignments", history)
+		}
+	})
+
+	subtest(t, "UnassignBeforeAssign", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		peopleID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		taskID := createTask(t, ctx, s, entities.Task{Title: "Task"})
+
+		noError(t, s.AssigneeManage.Assign(ctx, taskID, peopleID, at(60)), "Assign")
+		noError(t, s.AssigneeManage.Unassign(ctx, taskID, peopleID, at(0)), "Unassign before Assign")
+
+		history, err := s.AssigneeManage.History(ctx, taskID)
+		noError(t, err, "History")
+		if len(history) != 1 {
+			t.Fatalf("History = %+v", history)
+		}
+		sameTime(t, history[0].UnassignedAt, at(60), "UnassignedAt")
+	})
+
+	subtest(t, "CreateAssigns", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		peopleID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		taskID := createTask(t, ctx, s, entities.Task{Title: "Task", TimeEntry: entities.TimeEntry{PeopleID: peopleID}})
+
+		history, err := s.AssigneeManage.History(ctx, taskID)
+		noError(t, err, "History")
+		if len(history) != 1 || history[0].PeopleID != peopleID || !history[0].Current() {
+			t.Fatalf("History = %+v, want current assignment of people %d", history, peopleID)
+		}
+	})
+
+	subtest(t, "TimeByAssignee", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		firstID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		secondID := createPeople(t, ctx, s, newPeople("Petrov"))
+		thirdID := createPeople(t, ctx, s, newPeople("Sidorov"))
+		taskID := createTask(t, ctx, s, entities.Task{Title: "Task"})
+		otherID := createTask(t, ctx, s, entities.Task{Title: "Other"})
+
+		noError(t, s.AssigneeManage.Assign(ctx, taskID, firstID, at(0)), "Assign")
+		noError(t, s.AssigneeManage.Assign(ctx, taskID, thirdID, at(0)), "Assign")
+
+		addEntry(t, ctx, s, taskID, firstID, at(0), at(30))
+		addEntry(t, ctx, s, taskID, secondID, at(0), at(90))
+		addEntry(t, ctx, s, otherID, thirdID, at(0), at(60))
+		// Открытая сессия не учитывается
+		startEntry(t, ctx, s, taskID, firstID, at(100))
+
+		// Пользователь, снятый с задачи, сохраняет своё время
+		noError(t, s.TaskManage.UpdatePeople(ctx, thirdID, taskID), "UpdatePeople")
+
+		spent, err := s.AssigneeManage.TimeByAssignee(ctx, taskID)
+		noError(t, err, "TimeByAssignee")
+		want := []entities.AssigneeTimeSpent{
+			{PeopleID: secondID, Surname: "Petrov", Name: "Name", Patronymic: "Patronymic", TimeSpent: "01:30:00", Hours: 1.5},
+			{PeopleID: firstID, Surname: "Ivanov", Name: "Name", Patronymic: "Patronymic", TimeSpent: "00:30:00", Hours: 0.5},
+			{PeopleID: thirdID, Surname: "Sidorov", Name: "Name", Patronymic: "Patronymic", Assigned: true, TimeSpent: "00:00:00"},
+		}
+		if len(spent) != len(want) {
+			t.Fatalf("TimeByAssignee = %+v, want %+v", spent, want)
+		}
+		for i := range spent {
+			if spent[i] != want[i] {
+				t.Errorf("TimeByAssignee[%d] = %+v, want %+v", i, spent[i], want[i])
+			}
+		}
+
+		spent, err = s.AssigneeManage.TimeByAssignee(ctx, 999)
+		noError(t, err, "TimeByAssignee of unknown task")
+		if spent == nil || len(spent) != 0 {
+			t.Fatalf("TimeByAssignee of unknown task = %#v, want empty list", spent)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package storagetest
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"testing"
+)
+
+func testDependency(t *testing.T, newStorage Factory) {
+	subtest(t, "AddRemove", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		design := createTask(t, ctx, s, entities.Task{Title: "Design"})
+		build := createTask(t, ctx, s, entities.Task{Title: "Build"})
+		release := createTask(t, ctx, s, entities.Task{Title: "Release"})
+
+		noError(t, s.DependencyManage.Add(ctx, design, build), "Add")
+		noError(t, s.DependencyManage.Add(ctx, build, release), "Add")
+		noError(t, s.DependencyManage.Add(ctx, design, release), "Add")
+		noError(t, s.DependencyManage.Add(ctx, design, build), "Add existing")
+
+		got, err := s.TaskManage.GetByID(ctx, build, false)
+		noError(t, err, "GetByID")
+		if len(got.BlockedBy) != 1 || got.BlockedBy[0] != (entities.TaskRef{ID: design, Title: "Design", Status: entities.StatusTodo}) {
+			t.Fatalf("BlockedBy = %+v", got.BlockedBy)
+		}
+		if len(got.Blocks) != 1 || got.Blocks[0].ID != release {
+			t.Fatalf("Blocks = %+v", got.Blocks)
+		}
+
+		blocked, err := s.DependencyManage.Blocked(ctx, design)
+		noError(t, err, "Blocked")
+		if len(blocked) != 2 || blocked[0].ID != build || blocked[1].ID != release {
+			t.Fatalf("Blocked = %+v", blocked)
+		}
+
+		blockers, err := s.DependencyManage.Blockers(ctx, release)
+		noError(t, err, "Blockers")
+		if len(blockers) != 2 || blockers[0].ID != design || blockers[1].ID != build {
+			t.Fatalf("Blockers = %+v", blockers)
+		}
+
+		// Список задач содержит зависимости, у задачи без зависимостей списки пустые
+		unrelated := createTask(t, ctx, s, entities.Task{Title: "Unrelated"})
+		tasks, _, err := s.TaskManage.List(ctx, entities.TaskFilter{}, entities.PageRequest{})
+		noError(t, err, "List")
+		equalIDs(t, taskIDs(tasks), []int{design, build, release, unrelated}, "tasks")
+		if len(tasks[0].Blocks) != 2 || tasks[3].BlockedBy == nil || len(tasks[3].BlockedBy) != 0 {
+			t.Fatalf("List dependencies = %+v", tasks)
+		}
+
+		noError(t, s.DependencyManage.Remove(ctx, design, release), "Remove")
+		isError(t, s.DependencyManage.Remove(ctx, design, release), domain.ErrNoRecordsFound, "Remove twice")
+
+		isError(t, s.DependencyManage.Add(ctx, design, 999), domain.ErrNoRecordsFound, "Add unknown task")
+		isError(t, s.DependencyManage.Add(ctx, design, design), domain.ErrInputData, "Add self")
+	})
+
+	subtest(t, "Cycle", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		design := createTask(t, ctx, s, entities.Task{Title: "Design"})
+		build := createTask(t, ctx, s, entities.Task{Title: "Build"})
+		release := createTask(t, ctx, s, entities.Task{Title: "Release"})
+
+		noError(t, s.DependencyManage.Add(ctx, design, build), "Add")
+		noError(t, s.DependencyManage.Add(ctx, build, release), "Add")
+
+		isError(t, s.DependencyManage.Add(ctx, build, design), domain.ErrDependencyCycle, "Add reverse")
+		isError(t, s.DependencyManage.Add(ctx, release, design), domain.ErrDependencyCycle, "Add closing chain")
+
+		blockers, err := s.DependencyManage.Blockers(ctx, design)
+		noError(t, err, "Blockers")
+		if len(blockers) != 0 {
+			t.Fatalf("Blockers after rejected cycle = %+v", blockers)
+		}
+	})
+
+	subtest(t, "DeleteTask", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		blocker := createTask(t, ctx, s, entities.Task{Title: "Blocker"})
+		blocked := createTask(t, ctx, s, entities.Task{Title: "Blocked"})
+		noError(t, s.DependencyManage.Add(ctx, blocker, blocked), "Add")
+
+		noError(t, s.TaskManage.Delete(ctx, blocker), "Delete")
+
+		// Зависимость удаляется вместе с задачей
+		blockers, err := s.DependencyManage.Blockers(ctx, blocked)
+		noError(t, err, "Blockers")
+		if len(blockers) != 0 {
+			t.Fatalf("Blockers after Delete = %+v", blockers)
+		}
+	})
+}
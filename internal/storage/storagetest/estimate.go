@@ -0,0 +1,121 @@
+package storagetest
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"testing"
+	"time"
+)
+
+func testEstimate(t *testing.T, newStorage Factory) {
+	subtest(t, "Schedule", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		due := at(24 * 60)
+		id := createTask(t, ctx, s, entities.Task{Title: "Task", DueDate: due, EstimateSeconds: 5400})
+
+		got, err := s.TaskManage.GetByID(ctx, id, false)
+		noError(t, err, "GetByID")
+		sameTime(t, got.DueDate, due, "DueDate")
+		if got.Estimate != "1h30m0s" || got.EstimateSeconds != 5400 {
+			t.Fatalf("Estimate = %q (%d), want 1h30m0s (5400)", got.Estimate, got.EstimateSeconds)
+		}
+
+		plain := createTask(t, ctx, s, entities.Task{Title: "Plain"})
+		got, err = s.TaskManage.GetByID(ctx, plain, false)
+		noError(t, err, "GetByID without schedule")
+		if !got.DueDate.IsZero() || got.Estimate != "" || got.EstimateSeconds != 0 {
+			t.Fatalf("task without schedule = %+v", got)
+		}
+
+		noError(t, s.TaskManage.UpdateSchedule(ctx, plain, due, 2*time.Hour), "UpdateSchedule")
+		list, _, err := s.TaskManage.List(ctx, entities.TaskFilter{}, entities.PageRequest{})
+		noError(t, err, "List")
+		if len(list) != 2 || list[1].EstimateSeconds != 7200 {
+			t.Fatalf("List = %+v, want estimate of task %d", list, plain)
+		}
+		sameTime(t, list[1].DueDate, due, "List DueDate")
+
+		noError(t, s.TaskManage.UpdateSchedule(ctx, plain, time.Time{}, 0), "UpdateSchedule clear")
+		got, err = s.TaskManage.GetByID(ctx, plain, false)
+		noError(t, err, "GetByID after clear")
+		if !got.DueDate.IsZero() || got.EstimateSeconds != 0 {
+			t.Fatalf("GetByID after clear = %+v", got)
+		}
+
+		isError(t, s.TaskManage.UpdateSchedule(ctx, 999, due, time.Hour), domain.ErrNoRecordsFound, "UpdateSchedule unknown")
+		isError(t, s.TaskManage.UpdateSchedule(ctx, plain, due, -time.Hour), domain.ErrInputData, "UpdateSchedule negative")
+	})
+
+	subtest(t, "TaskEstimates", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		firstID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		secondID := createPeople(t, ctx, s, newPeople("Petrov"))
+		projectID := createProject(t, ctx, s, "Project")
+
+		over := createTask(t, ctx, s, entities.Task{Title: "Over", ProjectID: projectID, EstimateSeconds: 3600})
+		within := createTask(t, ctx, s, entities.Task{Title: "Within", EstimateSeconds: 7200})
+		dated := createTask(t, ctx, s, entities.Task{Title: "Dated", ProjectID: projectID, DueDate: at(0)})
+		createTask(t, ctx, s, entities.Task{Title: "Plain"})
+
+		addEntry(t, ctx, s, over, firstID, at(0), at(60))
+		addEntry(t, ctx, s, over, secondID, at(0), at(30))
+		addEntry(t, ctx, s, within, firstID, at(60), at(90))
+		// Открытая сессия не учитывается
+		startEntry(t, ctx, s, within, secondID, at(100))
+
+		estimates, err := s.TimeManage.TaskEstimates(ctx, 0, 0)
+		noError(t, err, "TaskEstimates")
+		equalIDs(t, estimateIDs(estimates), []int{over, within, dated}, "TaskEstimates IDs")
+
+		got := estimates[0]
+		if got.ProjectName != "Project" || got.Estimate != "1h0m0s" || got.Actual != "1h30m0s" || got.ActualSeconds != 5400 || !got.Overrun {
+			t.Fatalf("TaskEstimates[0] = %+v, want overrun of 1h30m0s", got)
+		}
+		if len(got.People) != 2 || got.People[0].PeopleID != firstID || got.People[0].Actual != "1h0m0s" ||
+			got.People[1].PeopleID != secondID || got.People[1].Actual != "30m0s" {
+			t.Fatalf("TaskEstimates[0].People = %+v", got.People)
+		}
+
+		got = estimates[1]
+		if got.Actual != "30m0s" || got.Overrun || len(got.People) != 1 {
+			t.Fatalf("TaskEstimates[1] = %+v, want 30m0s within estimate", got)
+		}
+
+		got = estimates[2]
+		if got.EstimateSeconds != 0 || got.ActualSeconds != 0 || got.Overrun || got.People == nil || len(got.People) != 0 {
+			t.Fatalf("TaskEstimates[2] = %#v, want task without estimate and time", got)
+		}
+		sameTime(t, got.DueDate, at(0), "TaskEstimates[2].DueDate")
+
+		estimates, err = s.TimeManage.TaskEstimates(ctx, 0, projectID)
+		noError(t, err, "TaskEstimates by project")
+		equalIDs(t, estimateIDs(estimates), []int{over, dated}, "TaskEstimates by project IDs")
+
+		// Пользователь видит задачи, над которыми работал, и задачи, на которые назначен
+		noError(t, s.AssigneeManage.Assign(ctx, dated, secondID, at(0)), "Assign")
+		estimates, err = s.TimeManage.TaskEstimates(ctx, secondID, 0)
+		noError(t, err, "TaskEstimates by people")
+		equalIDs(t, estimateIDs(estimates), []int{over, within, dated}, "TaskEstimates by people IDs")
+
+		// В отчёте пользователя из времени по пользователям остаётся только его время
+		got = estimates[0]
+		if got.ActualSeconds != 5400 || len(got.People) != 1 || got.People[0].PeopleID != secondID || got.People[0].Actual != "30m0s" {
+			t.Fatalf("TaskEstimates by people [0] = %+v, want only people ID %d", got, secondID)
+		}
+		if got = estimates[1]; got.ActualSeconds != 1800 || len(got.People) != 0 {
+			t.Fatalf("TaskEstimates by people [1] = %+v, want no time of other people", got)
+		}
+
+		estimates, err = s.TimeManage.TaskEstimates(ctx, firstID, projectID)
+		noError(t, err, "TaskEstimates by people and project")
+		equalIDs(t, estimateIDs(estimates), []int{over}, "TaskEstimates by people and project IDs")
+	})
+}
+
+func estimateIDs(estimates []entities.TaskEstimate) []int {
+	ids := make([]int, 0, len(estimates))
+	for _, e := range estimates {
+		ids = append(ids, e.TaskID)
+	}
+	return ids
+}
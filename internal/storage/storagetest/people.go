@@ -0,0 +1,232 @@
+package storagetest
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"testing"
+)
+
+func testPeople(t *testing.T, newStorage Factory) {
+	subtest(t, "CreateGet", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		want := newPeople("Ivanov")
+		id := createPeople(t, ctx, s, want)
+
+		got, err := s.PeopleManage.GetByID(ctx, id)
+		noError(t, err, "GetByID")
+
+		// Роль по умолчанию - участник
+		want.ID, want.Role = id, entities.RoleMember
+		if got != want {
+			t.Fatalf("GetByID = %+v, want %+v", got, want)
+		}
+	})
+
+	subtest(t, "CreateInvalid", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		people := newPeople("Ivanov")
+		createPeople(t, ctx, s, people)
+
+		_, err := s.PeopleManage.Create(ctx, people)
+		isError(t, err, domain.ErrAlreadyExists, "Create with duplicate passport")
+		isError(t, err, domain.ErrConflict, "Create with duplicate passport")
+
+		short := newPeople("Petrov")
+		short.PassportSeries = 12
+		_, err = s.PeopleManage.Create(ctx, short)
+		isError(t, err, domain.ErrValidation, "Create with 2-digit passport series")
+
+		orphan := newPeople("Sidorov")
+		orphan.ManagerID = 999
+		_, err = s.PeopleManage.Create(ctx, orphan)
+		isError(t, err, domain.ErrInputData, "Create with unknown manager")
+	})
+
+	subtest(t, "Missing", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		_, err := s.PeopleManage.GetByID(ctx, 999)
+		isError(t, err, domain.ErrNoRecordsFound, "GetByID")
+		isError(t, err, domain.ErrNotFound, "GetByID")
+
+		err = s.PeopleManage.Update(ctx, entities.People{ID: 999, Surname: "Ivanov"})
+		isError(t, err, domain.ErrNoRecordsFound, "Update")
+
+		err = s.PeopleManage.Delete(ctx, 999)
+		isError(t, err, domain.ErrNoRecordsFound, "Delete")
+	})
+
+	subtest(t, "ListFilter", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		list, total, err := s.PeopleManage.List(ctx, entities.PageRequest{})
+		noError(t, err, "List on empty storage")
+		if len(list) != 0 || total != 0 {
+			t.Fatalf("List on empty storage = %+v, total %d", list, total)
+		}
+
+		manager := newPeople("Ivanov")
+		manager.Role = entities.RoleManager
+		managerID := createPeople(t, ctx, s, manager)
+
+		first := newPeople("Petrov")
+		first.ManagerID = managerID
+		firstID := createPeople(t, ctx, s, first)
+
+		second := newPeople("Petrov")
+		secondID := createPeople(t, ctx, s, second)
+
+		list, total, err = s.PeopleManage.List(ctx, entities.PageRequest{})
+		noError(t, err, "List")
+		equalIDs(t, peopleIDs(list), []int{managerID, firstID, secondID}, "List IDs")
+		equalTotal(t, total, 3, "List")
+
+		list, total, err = s.PeopleManage.GetByFilter(ctx, entities.PeopleFilter{People: entities.People{Surname: "Petrov"}}, entities.PageRequest{})
+		noError(t, err, "GetByFilter by surname")
+		equalIDs(t, peopleIDs(list), []int{firstID, secondID}, "GetByFilter by surname IDs")
+		equalTotal(t, total, 2, "GetByFilter by surname")
+
+		list, _, err = s.PeopleManage.GetByFilter(ctx, entities.PeopleFilter{People: entities.People{Role: entities.RoleManager}}, entities.PageRequest{})
+		noError(t, err, "GetByFilter by role")
+		equalIDs(t, peopleIDs(list), []int{managerID}, "GetByFilter by role IDs")
+
+		list, _, err = s.PeopleManage.GetByFilter(ctx, entities.PeopleFilter{People: entities.People{ManagerID: managerID}}, entities.PageRequest{})
+		noError(t, err, "GetByFilter by manager")
+		equalIDs(t, peopleIDs(list), []int{firstID}, "GetByFilter by manager IDs")
+
+		list, _, err = s.PeopleManage.GetByFilter(ctx, entities.PeopleFilter{People: entities.People{Surname: "Sidorov"}}, entities.PageRequest{})
+		noError(t, err, "GetByFilter without matches")
+		if len(list) != 0 {
+			t.Fatalf("GetByFilter without matches = %+v", list)
+		}
+	})
+
+	subtest(t, "Match", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		ivanov := createPeople(t, ctx, s, newPeople("Ivanov"))
+		ivanova := createPeople(t, ctx, s, newPeople("Ivanova"))
+		petrov := createPeople(t, ctx, s, newPeople("Petrov"))
+
+		filter := func(surname string, op entities.MatchOp) entities.PeopleFilter {
+			return entities.PeopleFilter{People: entities.People{Surname: surname}, Match: map[string]entities.MatchOp{"surname": op}}
+		}
+
+		list, _, err := s.PeopleManage.GetByFilter(ctx, filter("ivanov", entities.MatchExact), entities.PageRequest{})
+		noError(t, err, "GetByFilter exact")
+		equalIDs(t, peopleIDs(list), nil, "GetByFilter exact IDs")
+
+		list, _, err = s.PeopleManage.GetByFilter(ctx, filter("ivanov", entities.MatchIExact), entities.PageRequest{})
+		noError(t, err, "GetByFilter iexact")
+		equalIDs(t, peopleIDs(list), []int{ivanov}, "GetByFilter iexact IDs")
+
+		list, total, err := s.PeopleManage.GetByFilter(ctx, filter("ivan", entities.MatchPrefix), entities.PageRequest{})
+		noError(t, err, "GetByFilter prefix")
+		equalIDs(t, peopleIDs(list), []int{ivanov, ivanova}, "GetByFilter prefix IDs")
+		equalTotal(t, total, 2, "GetByFilter prefix")
+
+		list, _, err = s.PeopleManage.GetByFilter(ctx, filter("OV", entities.MatchContains), entities.PageRequest{})
+		noError(t, err, "GetByFilter contains")
+		equalIDs(t, peopleIDs(list), []int{ivanov, ivanova, petrov}, "GetByFilter contains IDs")
+
+		// Регистр не учитывается и для кириллицы
+		sidorov := createPeople(t, ctx, s, newPeople("Сидоров"))
+
+		list, _, err = s.PeopleManage.GetByFilter(ctx, filter("сид", entities.MatchPrefix), entities.PageRequest{})
+		noError(t, err, "GetByFilter prefix cyrillic")
+		equalIDs(t, peopleIDs(list), []int{sidorov}, "GetByFilter prefix cyrillic IDs")
+
+		// Спецсимволы LIKE сравниваются как обычные символы
+		list, _, err = s.PeopleManage.GetByFilter(ctx, filter("%", entities.MatchContains), entities.PageRequest{})
+		noError(t, err, "GetByFilter contains percent")
+		equalIDs(t, peopleIDs(list), nil, "GetByFilter contains percent IDs")
+	})
+
+	subtest(t, "Page", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		petrov := createPeople(t, ctx, s, newPeople("Petrov"))
+		ivanov := createPeople(t, ctx, s, newPeople("Ivanov"))
+		petrov2 := createPeople(t, ctx, s, newPeople("Petrov"))
+		abramov := createPeople(t, ctx, s, newPeople("Abramov"))
+
+		// Сортировка по фамилии, при равной фамилии - по убыванию ID.
+		sort := []entities.SortField{{Field: "surname"}, {Field: "id", Desc: true}}
+
+		list, total, err := s.PeopleManage.List(ctx, entities.PageRequest{Limit: 2, Sort: sort})
+		noError(t, err, "List first page")
+		equalIDs(t, peopleIDs(list), []int{abramov, ivanov}, "List first page IDs")
+		equalTotal(t, total, 4, "List first page")
+
+		list, total, err = s.PeopleManage.List(ctx, entities.PageRequest{Limit: 2, Sort: sort, After: []any{"Ivanov", ivanov}})
+		noError(t, err, "List second page")
+		equalIDs(t, peopleIDs(list), []int{petrov2, petrov}, "List second page IDs")
+		equalTotal(t, total, 4, "List second page")
+
+		list, _, err = s.PeopleManage.List(ctx, entities.PageRequest{Limit: 2, Sort: sort, After: []any{"Petrov", petrov2}})
+		noError(t, err, "List inside equal surnames")
+		equalIDs(t, peopleIDs(list), []int{petrov}, "List inside equal surnames IDs")
+
+		list, _, err = s.PeopleManage.List(ctx, entities.PageRequest{Sort: sort, After: []any{"Petrov", petrov}})
+		noError(t, err, "List after last")
+		if len(list) != 0 {
+			t.Fatalf("List after last = %+v", list)
+		}
+
+		list, total, err = s.PeopleManage.GetByFilter(ctx, entities.PeopleFilter{People: entities.People{Surname: "Petrov"}},
+			entities.PageRequest{Limit: 1, Sort: []entities.SortField{{Field: "id"}}, After: []any{petrov}})
+		noError(t, err, "GetByFilter page")
+		equalIDs(t, peopleIDs(list), []int{petrov2}, "GetByFilter page IDs")
+		equalTotal(t, total, 2, "GetByFilter page")
+
+		_, _, err = s.PeopleManage.List(ctx, entities.PageRequest{Sort: []entities.SortField{{Field: "address"}}})
+		isError(t, err, domain.ErrInputData, "List by unknown field")
+	})
+
+	subtest(t, "Update", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		people := newPeople("Ivanov")
+		id := createPeople(t, ctx, s, people)
+
+		err := s.PeopleManage.Update(ctx, entities.People{ID: id})
+		isError(t, err, domain.ErrInputData, "Update without values")
+
+		err = s.PeopleManage.Update(ctx, entities.People{ID: id, Address: "Moscow", Role: entities.RoleManager})
+		noError(t, err, "Update")
+
+		got, err := s.PeopleManage.GetByID(ctx, id)
+		noError(t, err, "GetByID")
+
+		// Незаданные поля не меняются
+		people.ID, people.Address, people.Role = id, "Moscow", entities.RoleManager
+		if got != people {
+			t.Fatalf("GetByID after Update = %+v, want %+v", got, people)
+		}
+
+		other := newPeople("Petrov")
+		createPeople(t, ctx, s, other)
+		err = s.PeopleManage.Update(ctx, entities.People{ID: id, PassportSeries: other.PassportSeries, PassportNumber: other.PassportNumber})
+		isError(t, err, domain.ErrAlreadyExists, "Update with duplicate passport")
+	})
+
+	subtest(t, "Delete", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		managerID := createPeople(t, ctx, s, newPeople("Ivanov"))
+
+		member := newPeople("Petrov")
+		member.ManagerID = managerID
+		memberID := createPeople(t, ctx, s, member)
+
+		taskID := createTask(t, ctx, s, entities.Task{Title: "Task"})
+		addEntry(t, ctx, s, taskID, managerID, at(0), at(60))
+
+		noError(t, s.PeopleManage.Delete(ctx, managerID), "Delete")
+
+		_, err := s.PeopleManage.GetByID(ctx, managerID)
+		isError(t, err, domain.ErrNoRecordsFound, "GetByID after Delete")
+
+		// Подчинённые остаются без руководителя
+		got, err := s.PeopleManage.GetByID(ctx, memberID)
+		noError(t, err, "GetByID of subordinate")
+		if got.ManagerID != 0 {
+			t.Errorf("subordinate ManagerID = %d, want 0", got.ManagerID)
+		}
+
+		// Записи времени сохраняются без пользователя
+		entries, err := s.TimeManage.ListTimeEntries(ctx, taskID)
+		noError(t, err, "ListTimeEntries")
+		if len(entries) != 1 || entries[0].PeopleID != 0 {
+			t.Fatalf("time entries after Delete = %+v, want one entry without people", entries)
+		}
+	})
+}
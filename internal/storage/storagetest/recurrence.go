@@ -0,0 +1,153 @@
+package storagetest
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"testing"
+	"time"
+)
+
+func testRecurrence(t *testing.T, newStorage Factory) {
+	subtest(t, "CRUD", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		firstID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		secondID := createPeople(t, ctx, s, newPeople("Petrov"))
+		projectID := createProject(t, ctx, s, "Project")
+
+		rec := entities.Recurrence{
+			Title:           "Stand-up notes",
+			Description:     "Weekly notes",
+			ProjectID:       projectID,
+			EstimateSeconds: 1800,
+			AssigneeIDs:     []int{secondID, firstID},
+			Rule:            "FREQ=WEEKLY;BYDAY=MO",
+			Start:           at(0),
+			NextRun:         at(60),
+		}
+		id := createRecurrence(t, ctx, s, rec)
+
+		got, err := s.RecurrenceManage.GetByID(ctx, id)
+		noError(t, err, "GetByID")
+		if got.Title != rec.Title || got.Description != rec.Description || got.ProjectID != projectID || got.Rule != rec.Rule {
+			t.Fatalf("GetByID = %+v, want %+v", got, rec)
+		}
+		if got.EstimateSeconds != 1800 || got.Estimate != "30m0s" {
+			t.Fatalf("GetByID estimate = %q (%d s), want 30m0s", got.Estimate, got.EstimateSeconds)
+		}
+		equalIDs(t, got.AssigneeIDs, []int{firstID, secondID}, "AssigneeIDs")
+		sameTime(t, got.Start, at(0), "Start")
+		sameTime(t, got.NextRun, at(60), "NextRun")
+		if !got.LastRun.IsZero() {
+			t.Fatalf("LastRun = %s, want zero", got.LastRun)
+		}
+
+		_, err = s.RecurrenceManage.GetByID(ctx, 999)
+		isError(t, err, domain.ErrNoRecordsFound, "GetByID unknown")
+
+		_, err = s.RecurrenceManage.Create(ctx, entities.Recurrence{Title: "Task", Rule: "FREQ=DAILY", Start: at(0), ProjectID: 999})
+		isError(t, err, domain.ErrInputData, "Create with unknown project")
+		_, err = s.RecurrenceManage.Create(ctx, entities.Recurrence{Title: "Task", Rule: "FREQ=DAILY", Start: at(0), AssigneeIDs: []int{999}})
+		isError(t, err, domain.ErrInputData, "Create with unknown assignee")
+
+		list, err := s.RecurrenceManage.List(ctx)
+		noError(t, err, "List")
+		if len(list) != 1 || list[0].ID != id {
+			t.Fatalf("List = %+v, want recurrence %d", list, id)
+		}
+
+		// Удаление пользователя и проекта снимает их с повторяющейся задачи
+		noError(t, s.PeopleManage.Delete(ctx, secondID), "Delete people")
+		noError(t, s.ProjectManage.Delete(ctx, projectID), "Delete project")
+		got, err = s.RecurrenceManage.GetByID(ctx, id)
+		noError(t, err, "GetByID after Delete")
+		equalIDs(t, got.AssigneeIDs, []int{firstID}, "AssigneeIDs after Delete")
+		if got.ProjectID != 0 {
+			t.Fatalf("ProjectID after Delete = %d, want 0", got.ProjectID)
+		}
+
+		noError(t, s.RecurrenceManage.Delete(ctx, id), "Delete")
+		isError(t, s.RecurrenceManage.Delete(ctx, id), domain.ErrNoRecordsFound, "Delete twice")
+	})
+
+	subtest(t, "Materialize", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		peopleID := createPeople(t, ctx, s, newPeople("Ivanov"))
+
+		dueID := createRecurrence(t, ctx, s, entities.Recurrence{Title: "Due", Rule: "FREQ=DAILY", Start: at(0),
+			NextRun: at(0), EstimateSeconds: 600, AssigneeIDs: []int{peopleID}})
+		laterID := createRecurrence(t, ctx, s, entities.Recurrence{Title: "Later", Rule: "FREQ=DAILY", Start: at(120), NextRun: at(120)})
+
+		due, err := s.RecurrenceManage.Due(ctx, at(60))
+		noError(t, err, "Due")
+		if len(due) != 1 || due[0].ID != dueID {
+			t.Fatalf("Due = %+v, want recurrence %d", due, dueID)
+		}
+
+		task := entities.Task{Title: "Due", Status: entities.StatusTodo, EstimateSeconds: 600}
+		next := at(0).AddDate(0, 0, 1)
+		taskID, err := s.RecurrenceManage.Materialize(ctx, dueID, at(0), task, next)
+		noError(t, err, "Materialize")
+		if taskID <= 0 {
+			t.Fatalf("Materialize returned task ID %d", taskID)
+		}
+
+		got, err := s.TaskManage.GetByID(ctx, taskID, false)
+		noError(t, err, "GetByID task")
+		if got.Title != "Due" || got.Status != entities.StatusTodo || got.EstimateSeconds != 600 {
+			t.Fatalf("materialized task = %+v", got)
+		}
+		if len(got.Assignees) != 1 || got.Assignees[0].PeopleID != peopleID {
+			t.Fatalf("materialized task Assignees = %+v, want people %d", got.Assignees, peopleID)
+		}
+
+		rec, err := s.RecurrenceManage.GetByID(ctx, dueID)
+		noError(t, err, "GetByID after Materialize")
+		sameTime(t, rec.NextRun, next, "NextRun")
+		sameTime(t, rec.LastRun, at(0), "LastRun")
+
+		// Повторение создаётся один раз, в том числе после перезапуска с устаревшим next_run
+		again, err := s.RecurrenceManage.Materialize(ctx, dueID, at(0), task, next)
+		noError(t, err, "Materialize repeated")
+		if again != 0 {
+			t.Fatalf("Materialize repeated returned task ID %d, want 0", again)
+		}
+		_, total, err := s.TaskManage.List(ctx, entities.TaskFilter{}, entities.PageRequest{})
+		noError(t, err, "List tasks")
+		equalTotal(t, total, 1, "tasks")
+
+		due, err = s.RecurrenceManage.Due(ctx, at(60))
+		noError(t, err, "Due after Materialize")
+		if len(due) != 0 {
+			t.Fatalf("Due after Materialize = %+v, want none", due)
+		}
+
+		// Нулевое следующее повторение завершает расписание
+		_, err = s.RecurrenceManage.Materialize(ctx, laterID, at(120), entities.Task{Title: "Later"}, time.Time{})
+		noError(t, err, "Materialize last")
+		due, err = s.RecurrenceManage.Due(ctx, at(0).AddDate(1, 0, 0))
+		noError(t, err, "Due next year")
+		if len(due) != 1 || due[0].ID != dueID {
+			t.Fatalf("Due next year = %+v, want recurrence %d", due, dueID)
+		}
+
+		_, err = s.RecurrenceManage.Materialize(ctx, 999, at(0), task, next)
+		isError(t, err, domain.ErrNoRecordsFound, "Materialize unknown")
+
+		// Созданные задачи остаются после удаления повторяющейся задачи
+		noError(t, s.RecurrenceManage.Delete(ctx, dueID), "Delete")
+		_, err = s.TaskManage.GetByID(ctx, taskID, false)
+		noError(t, err, "GetByID task after Delete")
+	})
+}
+
+func createRecurrence(t *testing.T, ctx context.Context, s *storage.Storage, rec entities.Recurrence) int {
+	t.Helper()
+	id, err := s.RecurrenceManage.Create(ctx, rec)
+	if err != nil {
+		t.Fatalf("RecurrenceManage.Create(%+v): %v", rec, err)
+	}
+	if id <= 0 {
+		t.Fatalf("RecurrenceManage.Create returned ID %d", id)
+	}
+	return id
+}
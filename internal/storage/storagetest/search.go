@@ -0,0 +1,75 @@
+package storagetest
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"testing"
+)
+
+func testSearch(t *testing.T, newStorage Factory) {
+	subtest(t, "Prefix", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		people := newPeople("Петров")
+		people.Name = "Иван"
+		peopleID := createPeople(t, ctx, s, people)
+		createPeople(t, ctx, s, newPeople("Сидоров"))
+
+		taskID := createTask(t, ctx, s, entities.Task{Title: "Отчёт Петрова", Description: "Квартальный"})
+		createTask(t, ctx, s, entities.Task{Title: "Другая задача"})
+
+		// Регистр не учитывается, слово запроса совпадает с началом слова
+		got, err := s.SearchManage.Search(ctx, []string{"петр"}, 10)
+		noError(t, err, "Search")
+		if len(got) != 2 || !hasResult(got, entities.SearchPeople, peopleID) || !hasResult(got, entities.SearchTask, taskID) {
+			t.Fatalf("Search(петр) = %+v", got)
+		}
+
+		// Все слова запроса должны совпасть
+		got, err = s.SearchManage.Search(ctx, []string{"петр", "ив"}, 10)
+		noError(t, err, "Search with two terms")
+		if len(got) != 1 || !hasResult(got, entities.SearchPeople, peopleID) {
+			t.Fatalf("Search(петр ив) = %+v", got)
+		}
+
+		got, err = s.SearchManage.Search(ctx, []string{"квартал"}, 10)
+		noError(t, err, "Search by description")
+		if len(got) != 1 || !hasResult(got, entities.SearchTask, taskID) {
+			t.Fatalf("Search(квартал) = %+v", got)
+		}
+
+		got, err = s.SearchManage.Search(ctx, []string{"етров"}, 10)
+		noError(t, err, "Search by word middle")
+		if len(got) != 0 {
+			t.Fatalf("Search(етров) = %+v, want no results", got)
+		}
+	})
+
+	subtest(t, "Rank", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		inDescription := createTask(t, ctx, s, entities.Task{Title: "Задача", Description: "Подготовить релиз"})
+		inTitle := createTask(t, ctx, s, entities.Task{Title: "Релиз", Description: "Подготовить"})
+
+		got, err := s.SearchManage.Search(ctx, []string{"релиз"}, 10)
+		noError(t, err, "Search")
+		if len(got) != 2 || got[0].ID != inTitle || got[1].ID != inDescription {
+			t.Fatalf("Search(релиз) = %+v, want task %d ranked above task %d", got, inTitle, inDescription)
+		}
+		if got[0].Rank < got[1].Rank {
+			t.Errorf("ranks are not descending: %+v", got)
+		}
+
+		got, err = s.SearchManage.Search(ctx, []string{"релиз"}, 1)
+		noError(t, err, "Search with limit")
+		if len(got) != 1 || got[0].ID != inTitle {
+			t.Fatalf("Search(релиз, limit 1) = %+v", got)
+		}
+	})
+}
+
+func hasResult(results []entities.SearchResult, typ entities.SearchType, id int) bool {
+	for _, result := range results {
+		if result.Type == typ && result.ID == id {
+			return true
+		}
+	}
+	return false
+}
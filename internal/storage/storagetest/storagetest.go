@@ -0,0 +1,193 @@
+// Package storagetest проверяет реализации хранилища на соответствие общему контракту.
+// Набор не зависит от бэкенда, каждая реализация подключает его в своём тесте:
+//
+//	func TestStorage(t *testing.T) {
+//		storagetest.Run(t, func(t *testing.T) *storage.Storage {
+//			return storage.NewMemoryStorage()
+//		})
+//	}
+//
+// Фабрика вызывается для каждого подтеста и должна возвращать пустое хранилище.
+package storagetest
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// Factory создает пустое хранилище для одного подтеста.
+type Factory func(t *testing.T) *storage.Storage
+
+// Run проверяет PeopleManage, TaskManage, TagManage, DependencyManage, AssigneeManage, RecurrenceManage,
+// TemplateManage, ChecklistManage, TimeManage, TimesheetManage и SearchManage хранилища.
+func Run(t *testing.T, newStorage Factory) {
+	t.Run("People", func(t *testing.T) { testPeople(t, newStorage) })
+	t.Run("Task", func(t *testing.T) { testTask(t, newStorage) })
+	t.Run("Subtask", func(t *testing.T) { testSubtask(t, newStorage) })
+	t.Run("Tag", func(t *testing.T) { testTag(t, newStorage) })
+	t.Run("Dependency", func(t *testing.T) { testDependency(t, newStorage) })
+	t.Run("Assignee", func(t *testing.T) { testAssignee(t, newStorage) })
+	t.Run("Recurrence", func(t *testing.T) { testRecurrence(t, newStorage) })
+	t.Run("Template", func(t *testing.T) { testTemplate(t, newStorage) })
+	t.Run("Time", func(t *testing.T) { testTime(t, newStorage) })
+	t.Run("Estimate", func(t *testing.T) { testEstimate(t, newStorage) })
+	t.Run("Timesheet", func(t *testing.T) { testTimesheet(t, newStorage) })
+	t.Run("Search", func(t *testing.T) { testSearch(t, newStorage) })
+}
+
+// base начало рабочего дня, от которого отсчитываются интервалы в проверках.
+var base = time.Date(2024, 8, 1, 8, 0, 0, 0, time.UTC)
+
+// at возвращает момент через заданное число минут после base.
+func at(minutes int) time.Time {
+	return base.Add(time.Duration(minutes) * time.Minute)
+}
+
+// subtest запускает проверку на новом хранилище.
+func subtest(t *testing.T, name string, newStorage Factory, fn func(t *testing.T, ctx context.Context, s *storage.Storage)) {
+	t.Helper()
+	t.Run(name, func(t *testing.T) {
+		fn(t, context.Background(), newStorage(t))
+	})
+}
+
+// passportNumber последний выданный номер паспорта, номера не повторяются между подтестами.
+var passportNumber = 100000
+
+// newPeople возвращает пользователя с уникальным паспортом.
+func newPeople(surname string) entities.People {
+	passportNumber++
+	return entities.People{
+		PassportSeries: 1000,
+		PassportNumber: passportNumber,
+		Surname:        surname,
+		Name:           "Name",
+		Patronymic:     "Patronymic",
+		Address:        "Address",
+	}
+}
+
+func createPeople(t *testing.T, ctx context.Context, s *storage.Storage, people entities.People) int {
+	t.Helper()
+	id, err := s.PeopleManage.Create(ctx, people)
+	if err != nil {
+		t.Fatalf("PeopleManage.Create(%+v): %v", people, err)
+	}
+	if id <= 0 {
+		t.Fatalf("PeopleManage.Create returned ID %d", id)
+	}
+	return id
+}
+
+func createTask(t *testing.T, ctx context.Context, s *storage.Storage, task entities.Task) int {
+	t.Helper()
+	id, err := s.TaskManage.Create(ctx, task, entities.OverlapChanges{})
+	if err != nil {
+		t.Fatalf("TaskManage.Create(%+v): %v", task, err)
+	}
+	if id <= 0 {
+		t.Fatalf("TaskManage.Create returned ID %d", id)
+	}
+	return id
+}
+
+func createProject(t *testing.T, ctx context.Context, s *storage.Storage, name string) int {
+	t.Helper()
+	id, err := s.ProjectManage.Create(ctx, entities.Project{Name: name})
+	if err != nil {
+		t.Fatalf("ProjectManage.Create(%q): %v", name, err)
+	}
+	return id
+}
+
+func startEntry(t *testing.T, ctx context.Context, s *storage.Storage, taskID, peopleID int, start time.Time) int {
+	t.Helper()
+	id, err := s.TimeManage.StartTimeEntry(ctx, taskID, peopleID, start, entities.OverlapChanges{})
+	if err != nil {
+		t.Fatalf("StartTimeEntry(task %d, people %d, %s): %v", taskID, peopleID, start, err)
+	}
+	return id
+}
+
+func endEntry(t *testing.T, ctx context.Context, s *storage.Storage, taskID, peopleID int, end time.Time) {
+	t.Helper()
+	if err := s.TimeManage.EndTimeEntry(ctx, taskID, peopleID, end); err != nil {
+		t.Fatalf("EndTimeEntry(task %d, people %d, %s): %v", taskID, peopleID, end, err)
+	}
+}
+
+// addEntry записывает завершённую сессию [start, end).
+func addEntry(t *testing.T, ctx context.Context, s *storage.Storage, taskID, peopleID int, start, end time.Time) int {
+	t.Helper()
+	id := startEntry(t, ctx, s, taskID, peopleID, start)
+	endEntry(t, ctx, s, taskID, peopleID, end)
+	return id
+}
+
+func noError(t *testing.T, err error, call string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: unexpected error: %v", call, err)
+	}
+}
+
+func isError(t *testing.T, err, target error, call string) {
+	t.Helper()
+	if !errors.Is(err, target) {
+		t.Fatalf("%s: got error %v, want %v", call, err, target)
+	}
+}
+
+func sameTime(t *testing.T, got, want time.Time, field string) {
+	t.Helper()
+	if !got.Equal(want) {
+		t.Errorf("%s = %s, want %s", field, got, want)
+	}
+}
+
+func entryIDs(entries []entities.TimeEntry) []int {
+	ids := make([]int, 0, len(entries))
+	for _, e := range entries {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
+
+func peopleIDs(people []entities.People) []int {
+	ids := make([]int, 0, len(people))
+	for _, p := range people {
+		ids = append(ids, p.ID)
+	}
+	return ids
+}
+
+func taskIDs(tasks []entities.Task) []int {
+	ids := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func equalTotal(t *testing.T, got, want int, what string) {
+	t.Helper()
+	if got != want {
+		t.Fatalf("%s total = %d, want %d", what, got, want)
+	}
+}
+
+func equalIDs(t *testing.T, got, want []int, what string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s = %v, want %v", what, got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("%s = %v, want %v", what, got, want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package storagetest
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"testing"
+)
+
+func testSubtask(t *testing.T, newStorage Factory) {
+	subtest(t, "CreateChildren", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		parent := createTask(t, ctx, s, entities.Task{Title: "Parent"})
+		first := createTask(t, ctx, s, entities.Task{Title: "First", ParentID: parent})
+		second := createTask(t, ctx, s, entities.Task{Title: "Second", ParentID: parent})
+		createTask(t, ctx, s, entities.Task{Title: "Grandchild", ParentID: first})
+
+		got, err := s.TaskManage.GetByID(ctx, first, false)
+		noError(t, err, "GetByID")
+		if got.ParentID != parent || got.Subtasks != nil || got.Rollup != nil {
+			t.Fatalf("GetByID without subtree = %+v", got)
+		}
+
+		// В список попадают только прямые подзадачи
+		tasks, total, err := s.TaskManage.List(ctx, entities.TaskFilter{ParentID: parent}, entities.PageRequest{})
+		noError(t, err, "List by parent")
+		equalTotal(t, total, 2, "children")
+		equalIDs(t, taskIDs(tasks), []int{first, second}, "children")
+
+		_, err = s.TaskManage.Create(ctx, entities.Task{Title: "Orphan", ParentID: 999}, entities.OverlapChanges{})
+		isError(t, err, domain.ErrInputData, "Create with unknown parent")
+	})
+
+	subtest(t, "Move", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		root := createTask(t, ctx, s, entities.Task{Title: "Root"})
+		child := createTask(t, ctx, s, entities.Task{Title: "Child", ParentID: root})
+		grandchild := createTask(t, ctx, s, entities.Task{Title: "Grandchild", ParentID: child})
+		other := createTask(t, ctx, s, entities.Task{Title: "Other"})
+
+		noError(t, s.TaskManage.UpdateParent(ctx, other, child), "UpdateParent")
+		got, err := s.TaskManage.GetByID(ctx, child, false)
+		noError(t, err, "GetByID")
+		if got.ParentID != other {
+			t.Fatalf("ParentID after move = %d, want %d", got.ParentID, other)
+		}
+
+		// Задачу нельзя перенести под себя или под свою подзадачу
+		isError(t, s.TaskManage.UpdateParent(ctx, child, child), domain.ErrTaskCycle, "UpdateParent under itself")
+		isError(t, s.TaskManage.UpdateParent(ctx, grandchild, other), domain.ErrTaskCycle, "UpdateParent under descendant")
+
+		isError(t, s.TaskManage.UpdateParent(ctx, 999, child), domain.ErrInputData, "UpdateParent under unknown task")
+		isError(t, s.TaskManage.UpdateParent(ctx, root, 999), domain.ErrNoRecordsFound, "UpdateParent of unknown task")
+
+		noError(t, s.TaskManage.UpdateParent(ctx, 0, child), "UpdateParent to top level")
+		got, err = s.TaskManage.GetByID(ctx, child, false)
+		noError(t, err, "GetByID")
+		if got.ParentID != 0 {
+			t.Fatalf("ParentID after move to top level = %d", got.ParentID)
+		}
+	})
+
+	subtest(t, "Subtree", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		peopleID := createPeople(t, ctx, s, newPeople("Ivanov"))
+
+		root := createTask(t, ctx, s, entities.Task{Title: "Root"})
+		child := createTask(t, ctx, s, entities.Task{Title: "Child", ParentID: root})
+		leaf := createTask(t, ctx, s, entities.Task{Title: "Leaf", ParentID: child})
+		sibling := createTask(t, ctx, s, entities.Task{Title: "Sibling", ParentID: root})
+
+		// Родитель создан позже подзадачи: поддерево не зависит от порядка ID
+		late := createTask(t, ctx, s, entities.Task{Title: "Late"})
+		noError(t, s.TaskManage.UpdateParent(ctx, late, sibling), "UpdateParent")
+		noError(t, s.TaskManage.UpdateParent(ctx, child, late), "UpdateParent")
+
+		addEntry(t, ctx, s, root, peopleID, at(0), at(10))
+		addEntry(t, ctx, s, child, peopleID, at(10), at(40))
+		addEntry(t, ctx, s, leaf, peopleID, at(40), at(100))
+		addEntry(t, ctx, s, sibling, peopleID, at(100), at(105))
+		// Незавершённая сессия не учитывается
+		startEntry(t, ctx, s, leaf, peopleID, at(200))
+
+		got, err := s.TaskManage.GetByID(ctx, root, true)
+		noError(t, err, "GetByID with subtree")
+
+		if got.Rollup == nil || got.Rollup.TimeSpentSeconds != 600 || got.Rollup.TotalTimeSpentSeconds != 6300 {
+			t.Fatalf("root rollup = %+v, want 600 own and 6300 total seconds", got.Rollup)
+		}
+		if got.Rollup.TotalTimeSpent != "1h45m0s" {
+			t.Errorf("root TotalTimeSpent = %q, want 1h45m0s", got.Rollup.TotalTimeSpent)
+		}
+		equalIDs(t, taskIDs(got.Subtasks), []int{child}, "root subtasks")
+
+		childTask := got.Subtasks[0]
+		if childTask.Rollup.TimeSpentSeconds != 1800 || childTask.Rollup.TotalTimeSpentSeconds != 5700 {
+			t.Fatalf("child rollup = %+v, want 1800 own and 5700 total seconds", childTask.Rollup)
+		}
+		equalIDs(t, taskIDs(childTask.Subtasks), []int{leaf, late}, "child subtasks")
+
+		lateTask := childTask.Subtasks[1]
+		equalIDs(t, taskIDs(lateTask.Subtasks), []int{sibling}, "late subtasks")
+		if lateTask.Rollup.TimeSpentSeconds != 0 || lateTask.Rollup.TotalTimeSpentSeconds != 300 {
+			t.Fatalf("late rollup = %+v, want 0 own and 300 total seconds", lateTask.Rollup)
+		}
+		if leafTask := childTask.Subtasks[0]; leafTask.Subtasks != nil || leafTask.Rollup.TotalTimeSpentSeconds != 3600 {
+			t.Fatalf("leaf = %+v, want no subtasks and 3600 seconds", leafTask)
+		}
+	})
+
+	subtest(t, "DeleteParent", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		parent := createTask(t, ctx, s, entities.Task{Title: "Parent"})
+		child := createTask(t, ctx, s, entities.Task{Title: "Child", ParentID: parent})
+
+		noError(t, s.TaskManage.Delete(ctx, parent), "Delete")
+
+		// Подзадача остаётся и становится задачей верхнего уровня
+		got, err := s.TaskManage.GetByID(ctx, child, false)
+		noError(t, err, "GetByID of child after Delete")
+		if got.ParentID != 0 {
+			t.Fatalf("ParentID after parent Delete = %d", got.ParentID)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+package storagetest
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"testing"
+)
+
+func testTag(t *testing.T, newStorage Factory) {
+	subtest(t, "CRUD", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		feature := createTag(t, ctx, s, "feature")
+		bugfix := createTag(t, ctx, s, "bugfix")
+
+		_, err := s.TagManage.Create(ctx, entities.Tag{Name: "bugfix"})
+		isError(t, err, domain.ErrAlreadyExists, "Create with taken name")
+
+		tags, err := s.TagManage.List(ctx)
+		noError(t, err, "List")
+		if len(tags) != 2 || tags[0] != (entities.Tag{ID: bugfix, Name: "bugfix"}) || tags[1] != (entities.Tag{ID: feature, Name: "feature"}) {
+			t.Fatalf("List = %+v, want tags ordered by name", tags)
+		}
+
+		noError(t, s.TagManage.Update(ctx, entities.Tag{ID: feature, Name: "story"}), "Update")
+		got, err := s.TagManage.GetByID(ctx, feature)
+		noError(t, err, "GetByID")
+		if got.Name != "story" {
+			t.Fatalf("GetByID after Update = %+v", got)
+		}
+
+		isError(t, s.TagManage.Update(ctx, entities.Tag{ID: feature, Name: "bugfix"}), domain.ErrAlreadyExists, "Update to taken name")
+		isError(t, s.TagManage.Update(ctx, entities.Tag{ID: 999, Name: "other"}), domain.ErrNoRecordsFound, "Update unknown")
+
+		noError(t, s.TagManage.Delete(ctx, feature), "Delete")
+		_, err = s.TagManage.GetByID(ctx, feature)
+		isError(t, err, domain.ErrNoRecordsFound, "GetByID after Delete")
+		isError(t, s.TagManage.Delete(ctx, feature), domain.ErrNoRecordsFound, "Delete twice")
+	})
+
+	subtest(t, "Attach", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		feature := createTag(t, ctx, s, "feature")
+		bugfix := createTag(t, ctx, s, "bugfix")
+		taskID := createTask(t, ctx, s, entities.Task{Title: "Task"})
+		otherID := createTask(t, ctx, s, entities.Task{Title: "Other"})
+
+		got, err := s.TaskManage.GetByID(ctx, taskID, false)
+		noError(t, err, "GetByID without tags")
+		if got.Tags == nil || len(got.Tags) != 0 {
+			t.Fatalf("task without tags has Tags %#v, want empty list", got.Tags)
+		}
+
+		noError(t, s.TagManage.AttachTag(ctx, taskID, feature), "AttachTag")
+		noError(t, s.TagManage.AttachTag(ctx, taskID, bugfix), "AttachTag second")
+		noError(t, s.TagManage.AttachTag(ctx, taskID, bugfix), "AttachTag repeated")
+
+		isError(t, s.TagManage.AttachTag(ctx, 999, feature), domain.ErrNoRecordsFound, "AttachTag to unknown task")
+		isError(t, s.TagManage.AttachTag(ctx, taskID, 999), domain.ErrNoRecordsFound, "AttachTag unknown tag")
+
+		got, err = s.TaskManage.GetByID(ctx, taskID, false)
+		noError(t, err, "GetByID")
+		if len(got.Tags) != 2 || got.Tags[0].Name != "bugfix" || got.Tags[1].Name != "feature" {
+			t.Fatalf("GetByID Tags = %+v, want bugfix, feature", got.Tags)
+		}
+
+		list, total, err := s.TaskManage.List(ctx, entities.TaskFilter{Tag: "bugfix"}, entities.PageRequest{})
+		noError(t, err, "List by tag")
+		equalIDs(t, taskIDs(list), []int{taskID}, "List by tag IDs")
+		equalTotal(t, total, 1, "List by tag")
+		if len(list[0].Tags) != 2 {
+			t.Fatalf("List Tags = %+v", list[0].Tags)
+		}
+
+		list, _, err = s.TaskManage.List(ctx, entities.TaskFilter{}, entities.PageRequest{})
+		noError(t, err, "List")
+		equalIDs(t, taskIDs(list), []int{taskID, otherID}, "List IDs")
+		if len(list[1].Tags) != 0 {
+			t.Fatalf("List Tags of untagged task = %+v", list[1].Tags)
+		}
+
+		noError(t, s.TagManage.DetachTag(ctx, taskID, feature), "DetachTag")
+		isError(t, s.TagManage.DetachTag(ctx, taskID, feature), domain.ErrNoRecordsFound, "DetachTag twice")
+
+		// Удаление метки снимает её с задач
+		noError(t, s.TagManage.Delete(ctx, bugfix), "Delete")
+		got, err = s.TaskManage.GetByID(ctx, taskID, false)
+		noError(t, err, "GetByID after Delete")
+		if len(got.Tags) != 0 {
+			t.Fatalf("GetByID Tags after Delete = %+v", got.Tags)
+		}
+	})
+
+	subtest(t, "TimeSpent", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		peopleID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		projectID := createProject(t, ctx, s, "Project")
+		feature := createTag(t, ctx, s, "feature")
+		bugfix := createTag(t, ctx, s, "bugfix")
+
+		both := createTask(t, ctx, s, entities.Task{Title: "Both", ProjectID: projectID})
+		fix := createTask(t, ctx, s, entities.Task{Title: "Fix"})
+		untagged := createTask(t, ctx, s, entities.Task{Title: "Untagged"})
+		noError(t, s.TagManage.AttachTag(ctx, both, feature), "AttachTag")
+		noError(t, s.TagManage.AttachTag(ctx, both, bugfix), "AttachTag")
+		noError(t, s.TagManage.AttachTag(ctx, fix, bugfix), "AttachTag")
+
+		addEntry(t, ctx, s, both, peopleID, at(0), at(60))
+		addEntry(t, ctx, s, fix, peopleID, at(60), at(90))
+		addEntry(t, ctx, s, untagged, peopleID, at(90), at(300))
+
+		// Время задачи с двумя метками учитывается в обеих
+		spent, err := s.TimeManage.TagsTimeSpent(ctx, 0, 0, at(0), at(600))
+		noError(t, err, "TagsTimeSpent")
+		want := []entities.TagTimeSpent{
+			{TagID: bugfix, TagName: "bugfix", TimeSpent: "01:30:00", Hours: 1.5},
+			{TagID: feature, TagName: "feature", TimeSpent: "01:00:00", Hours: 1},
+		}
+		if len(spent) != len(want) {
+			t.Fatalf("TagsTimeSpent = %+v, want %+v", spent, want)
+		}
+		for i := range spent {
+			if spent[i] != want[i] {
+				t.Errorf("TagsTimeSpent[%d] = %+v, want %+v", i, spent[i], want[i])
+			}
+		}
+
+		spent, err = s.TimeManage.TagsTimeSpent(ctx, 0, projectID, at(0), at(600))
+		noError(t, err, "TagsTimeSpent by project")
+		if len(spent) != 2 || spent[0].TimeSpent != "01:00:00" || spent[1].TimeSpent != "01:00:00" {
+			t.Fatalf("TagsTimeSpent by project = %+v", spent)
+		}
+
+		spent, err = s.TimeManage.TagsTimeSpent(ctx, peopleID+1, 0, at(0), at(600))
+		noError(t, err, "TagsTimeSpent by other people")
+		if len(spent) != 0 {
+			t.Fatalf("TagsTimeSpent by other people = %+v", spent)
+		}
+	})
+}
+
+func createTag(t *testing.T, ctx context.Context, s *storage.Storage, name string) int {
+	t.Helper()
+	id, err := s.TagManage.Create(ctx, entities.Tag{Name: name})
+	if err != nil {
+		t.Fatalf("TagManage.Create(%q): %v", name, err)
+	}
+	return id
+}
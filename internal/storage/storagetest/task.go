@@ -0,0 +1,266 @@
+package storagetest
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"testing"
+)
+
+func testTask(t *testing.T, newStorage Factory) {
+	subtest(t, "CreateGet", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		projectID := createProject(t, ctx, s, "Project")
+		peopleID := createPeople(t, ctx, s, newPeople("Ivanov"))
+
+		id := createTask(t, ctx, s, entities.Task{Title: "Task", Description: "Description", ProjectID: projectID})
+
+		got, err := s.TaskManage.GetByID(ctx, id, false)
+		noError(t, err, "GetByID")
+		if got.ID != id || got.Title != "Task" || got.Description != "Description" ||
+			got.ProjectID != projectID || got.Status != entities.StatusTodo {
+			t.Fatalf("GetByID = %+v", got)
+		}
+		if got.TimeEntry.ID != 0 {
+			t.Errorf("task without people has time entry %+v", got.TimeEntry)
+		}
+
+		// С исполнителем создаётся пустая запись времени
+		assignedID := createTask(t, ctx, s, entities.Task{Title: "Assigned", TimeEntry: entities.TimeEntry{PeopleID: peopleID}})
+
+		got, err = s.TaskManage.GetByID(ctx, assignedID, false)
+		noError(t, err, "GetByID of assigned task")
+		if got.TimeEntry.ID == 0 || got.TimeEntry.PeopleID != peopleID || !got.TimeEntry.StartTime.IsZero() {
+			t.Fatalf("assigned task time entry = %+v", got.TimeEntry)
+		}
+	})
+
+	subtest(t, "CreateInvalid", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		_, err := s.TaskManage.Create(ctx, entities.Task{Title: "Task", ProjectID: 999}, entities.OverlapChanges{})
+		isError(t, err, domain.ErrInputData, "Create with unknown project")
+
+		_, err = s.TaskManage.Create(ctx, entities.Task{Title: "Task", TimeEntry: entities.TimeEntry{PeopleID: 999}}, entities.OverlapChanges{})
+		isError(t, err, domain.ErrInputData, "Create with unknown people")
+
+		tasks, _, err := s.TaskManage.List(ctx, entities.TaskFilter{}, entities.PageRequest{})
+		noError(t, err, "List")
+		if len(tasks) != 0 {
+			t.Fatalf("failed Create left tasks %+v", tasks)
+		}
+	})
+
+	subtest(t, "Missing", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		projectID := createProject(t, ctx, s, "Project")
+
+		_, err := s.TaskManage.GetByID(ctx, 999, false)
+		isError(t, err, domain.ErrNoRecordsFound, "GetByID")
+
+		err = s.TaskManage.Update(ctx, 999, "Title", "")
+		isError(t, err, domain.ErrNoRecordsFound, "Update")
+
+		err = s.TaskManage.UpdateProject(ctx, projectID, 999)
+		isError(t, err, domain.ErrNoRecordsFound, "UpdateProject")
+
+		err = s.TaskManage.UpdateStatus(ctx, 999, entities.StatusTodo, entities.StatusDone, entities.TimerStart{})
+		isError(t, err, domain.ErrNoRecordsFound, "UpdateStatus")
+
+		err = s.TaskManage.Delete(ctx, 999)
+		isError(t, err, domain.ErrNoRecordsFound, "Delete")
+	})
+
+	subtest(t, "List", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		projectID := createProject(t, ctx, s, "Project")
+
+		first := createTask(t, ctx, s, entities.Task{Title: "First", ProjectID: projectID})
+		second := createTask(t, ctx, s, entities.Task{Title: "Second"})
+		third := createTask(t, ctx, s, entities.Task{Title: "Third", ProjectID: projectID})
+
+		tasks, total, err := s.TaskManage.List(ctx, entities.TaskFilter{}, entities.PageRequest{})
+		noError(t, err, "List")
+		equalIDs(t, taskIDs(tasks), []int{first, second, third}, "List IDs")
+		equalTotal(t, total, 3, "List")
+
+		tasks, total, err = s.TaskManage.List(ctx, entities.TaskFilter{ProjectID: projectID}, entities.PageRequest{})
+		noError(t, err, "List by project")
+		equalIDs(t, taskIDs(tasks), []int{first, third}, "List by project IDs")
+		equalTotal(t, total, 2, "List by project")
+
+		sort := []entities.SortField{{Field: "title", Desc: true}, {Field: "id"}}
+
+		tasks, _, err = s.TaskManage.List(ctx, entities.TaskFilter{}, entities.PageRequest{Limit: 2, Sort: sort})
+		noError(t, err, "List first page")
+		equalIDs(t, taskIDs(tasks), []int{third, second}, "List first page IDs")
+
+		tasks, total, err = s.TaskManage.List(ctx, entities.TaskFilter{}, entities.PageRequest{Limit: 2, Sort: sort, After: []any{"Second", second}})
+		noError(t, err, "List second page")
+		equalIDs(t, taskIDs(tasks), []int{first}, "List second page IDs")
+		equalTotal(t, total, 3, "List second page")
+	})
+
+	subtest(t, "Update", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		id := createTask(t, ctx, s, entities.Task{Title: "Title", Description: "Description"})
+
+		isError(t, s.TaskManage.Update(ctx, id, "", ""), domain.ErrInputData, "Update without values")
+
+		noError(t, s.TaskManage.Update(ctx, id, "New title", ""), "Update title")
+		noError(t, s.TaskManage.Update(ctx, id, "", "New description"), "Update description")
+
+		got, err := s.TaskManage.GetByID(ctx, id, false)
+		noError(t, err, "GetByID")
+		if got.Title != "New title" || got.Description != "New description" {
+			t.Fatalf("GetByID after Update = %+v", got)
+		}
+	})
+
+	subtest(t, "UpdatePeople", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		firstID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		secondID := createPeople(t, ctx, s, newPeople("Petrov"))
+
+		empty := createTask(t, ctx, s, entities.Task{Title: "Empty"})
+		noError(t, s.TaskManage.UpdatePeople(ctx, firstID, empty), "UpdatePeople of task without entries")
+
+		err := s.TaskManage.UpdatePeople(ctx, firstID, 999)
+		isError(t, err, domain.ErrNoRecordsFound, "UpdatePeople of unknown task")
+
+		id := createTask(t, ctx, s, entities.Task{Title: "Task", TimeEntry: entities.TimeEntry{PeopleID: firstID}})
+
+		err = s.TaskManage.UpdatePeople(ctx, 999, id)
+		isError(t, err, domain.ErrInputData, "UpdatePeople with unknown people")
+
+		err = s.TaskManage.UpdatePeople(ctx, 0, id)
+		isError(t, err, domain.ErrInputData, "UpdatePeople with zero people")
+
+		noError(t, s.TaskManage.UpdatePeople(ctx, secondID, id), "UpdatePeople")
+
+		got, err := s.TaskManage.GetByID(ctx, id, false)
+		noError(t, err, "GetByID")
+		if got.TimeEntry.PeopleID != secondID {
+			t.Fatalf("time entry PeopleID = %d, want %d", got.TimeEntry.PeopleID, secondID)
+		}
+		if len(got.Assignees) != 1 || got.Assignees[0].PeopleID != secondID {
+			t.Fatalf("Assignees = %+v, want only people %d", got.Assignees, secondID)
+		}
+
+		// Начатые сессии остаются за тем, кто над ними работал
+		worked := createTask(t, ctx, s, entities.Task{Title: "Worked"})
+		addEntry(t, ctx, s, worked, firstID, at(0), at(60))
+		noError(t, s.TaskManage.UpdatePeople(ctx, secondID, worked), "UpdatePeople of worked task")
+
+		entries, err := s.TimeManage.ListTimeEntries(ctx, worked)
+		noError(t, err, "ListTimeEntries")
+		if len(entries) != 1 || entries[0].PeopleID != firstID {
+			t.Fatalf("worked entries = %+v, want people %d", entries, firstID)
+		}
+	})
+
+	subtest(t, "UpdateProject", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		projectID := createProject(t, ctx, s, "Project")
+		id := createTask(t, ctx, s, entities.Task{Title: "Task"})
+
+		err := s.TaskManage.UpdateProject(ctx, 999, id)
+		isError(t, err, domain.ErrInputData, "UpdateProject with unknown project")
+
+		noError(t, s.TaskManage.UpdateProject(ctx, projectID, id), "UpdateProject")
+		got, err := s.TaskManage.GetByID(ctx, id, false)
+		noError(t, err, "GetByID")
+		if got.ProjectID != projectID {
+			t.Fatalf("ProjectID = %d, want %d", got.ProjectID, projectID)
+		}
+
+		// Нулевой проект убирает задачу из проекта
+		noError(t, s.TaskManage.UpdateProject(ctx, 0, id), "UpdateProject to no project")
+		got, err = s.TaskManage.GetByID(ctx, id, false)
+		noError(t, err, "GetByID")
+		if got.ProjectID != 0 {
+			t.Fatalf("ProjectID = %d, want 0", got.ProjectID)
+		}
+	})
+
+	subtest(t, "UpdateStatus", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		id := createTask(t, ctx, s, entities.Task{Title: "Task"})
+
+		noError(t, s.TaskManage.UpdateStatus(ctx, id, entities.StatusTodo, entities.StatusInProgress, entities.TimerStart{}), "UpdateStatus")
+
+		// Статус уже изменился, переход из прежнего статуса не выполняется
+		err := s.TaskManage.UpdateStatus(ctx, id, entities.StatusTodo, entities.StatusDone, entities.TimerStart{})
+		isError(t, err, domain.ErrNoRecordsFound, "UpdateStatus from stale status")
+
+		got, err := s.TaskManage.GetByID(ctx, id, false)
+		noError(t, err, "GetByID")
+		if got.Status != entities.StatusInProgress {
+			t.Fatalf("Status = %q, want %q", got.Status, entities.StatusInProgress)
+		}
+	})
+
+	subtest(t, "UpdateStatus with timer", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		peopleID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		pausedTaskID := createTask(t, ctx, s, entities.Task{Title: "Paused"})
+		id := createTask(t, ctx, s, entities.Task{Title: "Task"})
+		addEntry(t, ctx, s, pausedTaskID, peopleID, at(-60), at(-30))
+
+		paused := startEntry(t, ctx, s, pausedTaskID, peopleID, at(0))
+		noError(t, s.TimeManage.PauseTimeEntry(ctx, peopleID, at(30)), "PauseTimeEntry")
+
+		checkUntouched := func(call string) {
+			t.Helper()
+
+			got, err := s.TaskManage.GetByID(ctx, id, false)
+			noError(t, err, "GetByID")
+			if got.Status != entities.StatusTodo {
+				t.Fatalf("%s: Status = %q, want %q", call, got.Status, entities.StatusTodo)
+			}
+
+			entries, err := s.TimeManage.ListTimeEntries(ctx, id)
+			noError(t, err, "ListTimeEntries")
+			equalIDs(t, entryIDs(entries), []int{}, call+": time entries")
+
+			timer, err := s.TimeManage.ActiveTimeEntry(ctx, peopleID)
+			noError(t, err, "ActiveTimeEntry")
+			if timer.TimeEntryID != paused || !timer.Paused {
+				t.Fatalf("%s: active timer = %+v, want paused entry %d", call, timer, paused)
+			}
+		}
+
+		// Статус уже изменился - таймер не запускается, приостановленная сессия остаётся
+		err := s.TaskManage.UpdateStatus(ctx, id, entities.StatusReview, entities.StatusInProgress, entities.TimerStart{PeopleID: peopleID, StartTime: at(60)})
+		isError(t, err, domain.ErrNoRecordsFound, "UpdateStatus from stale status")
+		checkUntouched("UpdateStatus from stale status")
+
+		// Таймер не запустился - статус не меняется
+		err = s.TaskManage.UpdateStatus(ctx, id, entities.StatusTodo, entities.StatusInProgress, entities.TimerStart{PeopleID: peopleID, StartTime: at(-45)})
+		isError(t, err, domain.ErrTimeEntryOverlap, "UpdateStatus with overlapping timer")
+		checkUntouched("UpdateStatus with overlapping timer")
+
+		noError(t, s.TaskManage.UpdateStatus(ctx, id, entities.StatusTodo, entities.StatusInProgress, entities.TimerStart{PeopleID: peopleID, StartTime: at(60)}), "UpdateStatus")
+
+		got, err := s.TaskManage.GetByID(ctx, id, false)
+		noError(t, err, "GetByID")
+		if got.Status != entities.StatusInProgress {
+			t.Fatalf("Status = %q, want %q", got.Status, entities.StatusInProgress)
+		}
+
+		timer, err := s.TimeManage.ActiveTimeEntry(ctx, peopleID)
+		noError(t, err, "ActiveTimeEntry")
+		if timer.TaskID != id || timer.Paused {
+			t.Fatalf("active timer = %+v, want running timer on task ID %d", timer, id)
+		}
+	})
+
+	subtest(t, "Delete", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		peopleID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		id := createTask(t, ctx, s, entities.Task{Title: "Task"})
+		addEntry(t, ctx, s, id, peopleID, at(0), at(60))
+
+		noError(t, s.TaskManage.Delete(ctx, id), "Delete")
+
+		_, err := s.TaskManage.GetByID(ctx, id, false)
+		isError(t, err, domain.ErrNoRecordsFound, "GetByID after Delete")
+
+		// Записи времени удаляются вместе с задачей
+		entries, err := s.TimeManage.ListTimeEntries(ctx, id)
+		noError(t, err, "ListTimeEntries")
+		if len(entries) != 0 {
+			t.Fatalf("time entries after Delete = %+v", entries)
+		}
+	})
+}
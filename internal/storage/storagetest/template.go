@@ -0,0 +1,170 @@
+package storagetest
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func testTemplate(t *testing.T, newStorage Factory) {
+	subtest(t, "CRUD", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		peopleID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		projectID := createProject(t, ctx, s, "Project")
+
+		tpl := entities.TaskTemplate{
+			Name:            "Release",
+			TitlePattern:    "Release {version} checklist",
+			Description:     "Steps before a release",
+			ProjectID:       projectID,
+			AssigneeID:      peopleID,
+			EstimateSeconds: 7200,
+			Checklist:       []string{"Tag", "Build", "Announce"},
+		}
+		id := createTemplate(t, ctx, s, tpl)
+
+		got, err := s.TemplateManage.GetByID(ctx, id)
+		noError(t, err, "GetByID")
+		if got.Name != tpl.Name || got.TitlePattern != tpl.TitlePattern || got.Description != tpl.Description ||
+			got.ProjectID != projectID || got.AssigneeID != peopleID {
+			t.Fatalf("GetByID = %+v, want %+v", got, tpl)
+		}
+		if got.EstimateSeconds != 7200 || got.Estimate != "2h0m0s" {
+			t.Fatalf("GetByID estimate = %q (%d s), want 2h0m0s", got.Estimate, got.EstimateSeconds)
+		}
+		if !slices.Equal(got.Checklist, tpl.Checklist) {
+			t.Fatalf("GetByID Checklist = %q, want %q", got.Checklist, tpl.Checklist)
+		}
+
+		_, err = s.TemplateManage.GetByID(ctx, 999)
+		isError(t, err, domain.ErrNoRecordsFound, "GetByID unknown")
+
+		_, err = s.TemplateManage.Create(ctx, entities.TaskTemplate{Name: "Release", TitlePattern: "Other"})
+		isError(t, err, domain.ErrAlreadyExists, "Create duplicate")
+		_, err = s.TemplateManage.Create(ctx, entities.TaskTemplate{Name: "Other", TitlePattern: "Other", ProjectID: 999})
+		isError(t, err, domain.ErrInputData, "Create with unknown project")
+		_, err = s.TemplateManage.Create(ctx, entities.TaskTemplate{Name: "Other", TitlePattern: "Other", AssigneeID: 999})
+		isError(t, err, domain.ErrInputData, "Create with unknown assignee")
+
+		otherID := createTemplate(t, ctx, s, entities.TaskTemplate{Name: "Audit", TitlePattern: "Audit {month}"})
+		list, err := s.TemplateManage.List(ctx)
+		noError(t, err, "List")
+		if len(list) != 2 || list[0].ID != otherID || list[1].ID != id {
+			t.Fatalf("List = %+v, want templates %d and %d by name", list, otherID, id)
+		}
+		if list[0].Checklist == nil || len(list[0].Checklist) != 0 {
+			t.Fatalf("List Checklist = %#v, want empty", list[0].Checklist)
+		}
+
+		// Обновление заменяет чек-лист целиком
+		tpl.ID = id
+		tpl.Name = "Release v2"
+		tpl.EstimateSeconds = 0
+		tpl.Checklist = []string{"Build"}
+		noError(t, s.TemplateManage.Update(ctx, tpl), "Update")
+		got, err = s.TemplateManage.GetByID(ctx, id)
+		noError(t, err, "GetByID after Update")
+		if got.Name != "Release v2" || got.EstimateSeconds != 0 || got.Estimate != "" || !slices.Equal(got.Checklist, []string{"Build"}) {
+			t.Fatalf("GetByID after Update = %+v", got)
+		}
+
+		tpl.Name = "Audit"
+		isError(t, s.TemplateManage.Update(ctx, tpl), domain.ErrAlreadyExists, "Update to duplicate name")
+		isError(t, s.TemplateManage.Update(ctx, entities.TaskTemplate{ID: 999, Name: "Missing", TitlePattern: "Missing"}),
+			domain.ErrNoRecordsFound, "Update unknown")
+
+		// Удаление пользователя и проекта снимает их с шаблона
+		noError(t, s.PeopleManage.Delete(ctx, peopleID), "Delete people")
+		noError(t, s.ProjectManage.Delete(ctx, projectID), "Delete project")
+		got, err = s.TemplateManage.GetByID(ctx, id)
+		noError(t, err, "GetByID after Delete")
+		if got.ProjectID != 0 || got.AssigneeID != 0 {
+			t.Fatalf("GetByID after Delete = %+v, want no project and assignee", got)
+		}
+
+		noError(t, s.TemplateManage.Delete(ctx, id), "Delete")
+		isError(t, s.TemplateManage.Delete(ctx, id), domain.ErrNoRecordsFound, "Delete twice")
+	})
+
+	subtest(t, "Checklist", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		taskID := createTask(t, ctx, s, entities.Task{Title: "Release", Checklist: []entities.ChecklistItem{{Title: "Tag"}, {Title: "Build"}}})
+
+		task, err := s.TaskManage.GetByID(ctx, taskID, false)
+		noError(t, err, "GetByID")
+		if len(task.Checklist) != 2 || task.Checklist[0].Title != "Tag" || task.Checklist[1].Title != "Build" {
+			t.Fatalf("Checklist = %+v, want Tag and Build", task.Checklist)
+		}
+		checkProgress(t, task, 2, 0, 0)
+
+		itemID, err := s.ChecklistManage.AddItem(ctx, taskID, "Announce")
+		noError(t, err, "AddItem")
+		_, err = s.ChecklistManage.AddItem(ctx, 999, "Missing")
+		isError(t, err, domain.ErrNoRecordsFound, "AddItem to unknown task")
+
+		first := task.Checklist[0].ID
+		noError(t, s.ChecklistManage.SetItemDone(ctx, taskID, first, at(0)), "SetItemDone")
+		// Повторная отметка сохраняет время первой
+		noError(t, s.ChecklistManage.SetItemDone(ctx, taskID, first, at(30)), "SetItemDone again")
+
+		task, err = s.TaskManage.GetByID(ctx, taskID, false)
+		noError(t, err, "GetByID after SetItemDone")
+		if len(task.Checklist) != 3 || task.Checklist[2].ID != itemID || task.Checklist[2].Position != 3 {
+			t.Fatalf("Checklist = %+v, want Announce at position 3", task.Checklist)
+		}
+		if !task.Checklist[0].Done || task.Checklist[1].Done {
+			t.Fatalf("Checklist = %+v, want only the first item done", task.Checklist)
+		}
+		sameTime(t, task.Checklist[0].DoneAt, at(0), "DoneAt")
+		checkProgress(t, task, 3, 1, 33)
+
+		noError(t, s.ChecklistManage.SetItemDone(ctx, taskID, first, time.Time{}), "SetItemDone untick")
+		isError(t, s.ChecklistManage.SetItemDone(ctx, taskID+1, first, at(0)), domain.ErrNoRecordsFound, "SetItemDone of other task")
+
+		noError(t, s.ChecklistManage.DeleteItem(ctx, taskID, itemID), "DeleteItem")
+		isError(t, s.ChecklistManage.DeleteItem(ctx, taskID, itemID), domain.ErrNoRecordsFound, "DeleteItem twice")
+
+		task, err = s.TaskManage.GetByID(ctx, taskID, false)
+		noError(t, err, "GetByID after DeleteItem")
+		if task.Checklist[0].Done || !task.Checklist[0].DoneAt.IsZero() {
+			t.Fatalf("Checklist = %+v, want the first item unticked", task.Checklist)
+		}
+		checkProgress(t, task, 2, 0, 0)
+
+		// Задача без чек-листа возвращается без выполнения
+		plainID := createTask(t, ctx, s, entities.Task{Title: "Plain"})
+		plain, err := s.TaskManage.GetByID(ctx, plainID, false)
+		noError(t, err, "GetByID plain")
+		if len(plain.Checklist) != 0 || plain.Progress != nil {
+			t.Fatalf("plain task Checklist = %+v, Progress = %+v, want none", plain.Checklist, plain.Progress)
+		}
+
+		noError(t, s.TaskManage.Delete(ctx, taskID), "Delete task")
+		_, err = s.ChecklistManage.AddItem(ctx, taskID, "Orphan")
+		isError(t, err, domain.ErrNoRecordsFound, "AddItem to deleted task")
+	})
+}
+
+func createTemplate(t *testing.T, ctx context.Context, s *storage.Storage, tpl entities.TaskTemplate) int {
+	t.Helper()
+	id, err := s.TemplateManage.Create(ctx, tpl)
+	if err != nil {
+		t.Fatalf("TemplateManage.Create(%+v): %v", tpl, err)
+	}
+	if id <= 0 {
+		t.Fatalf("TemplateManage.Create returned ID %d", id)
+	}
+	return id
+}
+
+func checkProgress(t *testing.T, task entities.Task, total, done, percent int) {
+	t.Helper()
+	if task.Progress == nil {
+		t.Fatalf("Progress = nil, want %d of %d", done, total)
+	}
+	if task.Progress.Total != total || task.Progress.Done != done || task.Progress.Percent != percent {
+		t.Fatalf("Progress = %+v, want %d of %d (%d%%)", *task.Progress, done, total, percent)
+	}
+}
@@ -0,0 +1,378 @@
+package storagetest
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"testing"
+	"time"
+)
+
+func testTime(t *testing.T, newStorage Factory) {
+	subtest(t, "StartEnd", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		peopleID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		taskID := createTask(t, ctx, s, entities.Task{Title: "Task", TimeEntry: entities.TimeEntry{PeopleID: peopleID}})
+
+		task, err := s.TaskManage.GetByID(ctx, taskID, false)
+		noError(t, err, "GetByID")
+
+		// Сессия открывается в пустой записи, созданной вместе с задачей
+		id := startEntry(t, ctx, s, taskID, peopleID, at(0))
+		if id != task.TimeEntry.ID {
+			t.Fatalf("StartTimeEntry = %d, want empty entry %d", id, task.TimeEntry.ID)
+		}
+
+		_, err = s.TimeManage.StartTimeEntry(ctx, taskID, peopleID, at(10), entities.OverlapChanges{})
+		isError(t, err, domain.ErrTimeEntryStarted, "StartTimeEntry with running timer")
+		isError(t, err, domain.ErrConflict, "StartTimeEntry with running timer")
+
+		err = s.TimeManage.EndTimeEntry(ctx, taskID, peopleID, at(-10))
+		isError(t, err, domain.ErrInputData, "EndTimeEntry before start")
+
+		endEntry(t, ctx, s, taskID, peopleID, at(30))
+
+		err = s.TimeManage.EndTimeEntry(ctx, taskID, peopleID, at(40))
+		isError(t, err, domain.ErrNoRecordsFound, "EndTimeEntry without open entry")
+
+		entries, err := s.TimeManage.ListTimeEntries(ctx, taskID)
+		noError(t, err, "ListTimeEntries")
+		if len(entries) != 1 {
+			t.Fatalf("ListTimeEntries = %+v, want one entry", entries)
+		}
+		if entries[0].ID != id || entries[0].TaskID != taskID || entries[0].PeopleID != peopleID {
+			t.Errorf("entry = %+v", entries[0])
+		}
+		sameTime(t, entries[0].StartTime, at(0), "StartTime")
+		sameTime(t, entries[0].EndTime, at(30), "EndTime")
+	})
+
+	subtest(t, "StartInvalid", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		peopleID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		taskID := createTask(t, ctx, s, entities.Task{Title: "Task"})
+
+		_, err := s.TimeManage.StartTimeEntry(ctx, 0, peopleID, at(0), entities.OverlapChanges{})
+		isError(t, err, domain.ErrInputData, "StartTimeEntry with zero task")
+
+		_, err = s.TimeManage.StartTimeEntry(ctx, taskID, 0, at(0), entities.OverlapChanges{})
+		isError(t, err, domain.ErrInputData, "StartTimeEntry with zero people")
+
+		_, err = s.TimeManage.StartTimeEntry(ctx, 999, peopleID, at(0), entities.OverlapChanges{})
+		isError(t, err, domain.ErrInputData, "StartTimeEntry with unknown task")
+
+		_, err = s.TimeManage.StartTimeEntry(ctx, taskID, 999, at(0), entities.OverlapChanges{})
+		isError(t, err, domain.ErrInputData, "StartTimeEntry with unknown people")
+	})
+
+	subtest(t, "PauseResume", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		peopleID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		taskID := createTask(t, ctx, s, entities.Task{Title: "Task"})
+
+		timer, err := s.TimeManage.ActiveTimeEntry(ctx, peopleID)
+		noError(t, err, "ActiveTimeEntry without timer")
+		if timer != (entities.ActiveTimer{}) {
+			t.Fatalf("ActiveTimeEntry without timer = %+v", timer)
+		}
+
+		err = s.TimeManage.PauseTimeEntry(ctx, peopleID, at(0))
+		isError(t, err, domain.ErrNoRecordsFound, "PauseTimeEntry without timer")
+
+		_, err = s.TimeManage.ResumeTimeEntry(ctx, peopleID, at(0), entities.OverlapChanges{})
+		isError(t, err, domain.ErrNoRecordsFound, "ResumeTimeEntry without paused timer")
+
+		first := startEntry(t, ctx, s, taskID, peopleID, at(0))
+
+		timer, err = s.TimeManage.ActiveTimeEntry(ctx, peopleID)
+		noError(t, err, "ActiveTimeEntry")
+		if timer.TimeEntryID != first || timer.TaskID != taskID || timer.TaskTitle != "Task" ||
+			timer.PeopleID != peopleID || timer.Paused || timer.ElapsedSeconds != 0 {
+			t.Fatalf("running timer = %+v", timer)
+		}
+
+		err = s.TimeManage.PauseTimeEntry(ctx, peopleID, at(-10))
+		isError(t, err, domain.ErrInputData, "PauseTimeEntry before start")
+
+		noError(t, s.TimeManage.PauseTimeEntry(ctx, peopleID, at(20)), "PauseTimeEntry")
+
+		timer, err = s.TimeManage.ActiveTimeEntry(ctx, peopleID)
+		noError(t, err, "ActiveTimeEntry when paused")
+		if timer.TimeEntryID != first || !timer.Paused || timer.ElapsedSeconds != 20*60 {
+			t.Fatalf("paused timer = %+v", timer)
+		}
+
+		second, err := s.TimeManage.ResumeTimeEntry(ctx, peopleID, at(30), entities.OverlapChanges{})
+		noError(t, err, "ResumeTimeEntry")
+		if second == first {
+			t.Fatalf("ResumeTimeEntry reused entry %d", first)
+		}
+
+		// Новый отрезок продолжает ту же сессию
+		timer, err = s.TimeManage.ActiveTimeEntry(ctx, peopleID)
+		noError(t, err, "ActiveTimeEntry after resume")
+		if timer.TimeEntryID != second || timer.Paused || timer.ElapsedSeconds != 20*60 {
+			t.Fatalf("resumed timer = %+v", timer)
+		}
+		sameTime(t, timer.SessionStart, at(0), "SessionStart")
+		sameTime(t, timer.SegmentStart, at(30), "SegmentStart")
+
+		// Завершение приостановленной сессии не меняет время её отрезка
+		noError(t, s.TimeManage.PauseTimeEntry(ctx, peopleID, at(50)), "PauseTimeEntry")
+		endEntry(t, ctx, s, taskID, peopleID, at(90))
+
+		timer, err = s.TimeManage.ActiveTimeEntry(ctx, peopleID)
+		noError(t, err, "ActiveTimeEntry after end")
+		if timer != (entities.ActiveTimer{}) {
+			t.Fatalf("ActiveTimeEntry after end = %+v", timer)
+		}
+
+		entries, err := s.TimeManage.ListTimeEntries(ctx, taskID)
+		noError(t, err, "ListTimeEntries")
+		equalIDs(t, entryIDs(entries), []int{first, second}, "ListTimeEntries IDs")
+		sameTime(t, entries[1].EndTime, at(50), "EndTime of paused segment")
+	})
+
+	subtest(t, "StartAfterPause", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		peopleID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		firstTask := createTask(t, ctx, s, entities.Task{Title: "First"})
+		secondTask := createTask(t, ctx, s, entities.Task{Title: "Second"})
+
+		startEntry(t, ctx, s, firstTask, peopleID, at(0))
+		noError(t, s.TimeManage.PauseTimeEntry(ctx, peopleID, at(10)), "PauseTimeEntry")
+
+		// Новая сессия завершает приостановленную
+		id := startEntry(t, ctx, s, secondTask, peopleID, at(20))
+
+		_, err := s.TimeManage.ResumeTimeEntry(ctx, peopleID, at(30), entities.OverlapChanges{})
+		isError(t, err, domain.ErrNoRecordsFound, "ResumeTimeEntry after new session")
+
+		timer, err := s.TimeManage.ActiveTimeEntry(ctx, peopleID)
+		noError(t, err, "ActiveTimeEntry")
+		if timer.TimeEntryID != id || timer.TaskID != secondTask || timer.Paused {
+			t.Fatalf("timer = %+v", timer)
+		}
+	})
+
+	subtest(t, "Overlap", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		peopleID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		otherID := createPeople(t, ctx, s, newPeople("Petrov"))
+		taskID := createTask(t, ctx, s, entities.Task{Title: "Task"})
+
+		first := addEntry(t, ctx, s, taskID, peopleID, at(0), at(60))
+		second := addEntry(t, ctx, s, taskID, peopleID, at(60), at(120))
+		addEntry(t, ctx, s, taskID, otherID, at(30), at(90))
+
+		// Сессия внутри уже записанного интервала
+		_, err := s.TimeManage.StartTimeEntry(ctx, taskID, peopleID, at(30), entities.OverlapChanges{})
+		isError(t, err, domain.ErrTimeEntryOverlap, "StartTimeEntry inside closed entry")
+
+		// Открытая сессия пересекается с записью, начатой позже
+		_, err = s.TimeManage.StartTimeEntry(ctx, taskID, peopleID, at(-30), entities.OverlapChanges{})
+		isError(t, err, domain.ErrTimeEntryOverlap, "StartTimeEntry before closed entry")
+
+		entries, err := s.TimeManage.OverlappingTimeEntries(ctx, peopleID, at(30), at(90), 0)
+		noError(t, err, "OverlappingTimeEntries")
+		equalIDs(t, entryIDs(entries), []int{first, second}, "OverlappingTimeEntries IDs")
+
+		// Соприкасающиеся интервалы не пересекаются
+		entries, err = s.TimeManage.OverlappingTimeEntries(ctx, peopleID, at(120), at(180), 0)
+		noError(t, err, "OverlappingTimeEntries touching")
+		if len(entries) != 0 {
+			t.Fatalf("OverlappingTimeEntries touching = %+v", entries)
+		}
+
+		entries, err = s.TimeManage.OverlappingTimeEntries(ctx, peopleID, at(30), at(90), first)
+		noError(t, err, "OverlappingTimeEntries with exclude")
+		equalIDs(t, entryIDs(entries), []int{second}, "OverlappingTimeEntries with exclude IDs")
+
+		// Нулевое окончание - интервал без верхней границы
+		entries, err = s.TimeManage.OverlappingTimeEntries(ctx, peopleID, at(90), time.Time{}, 0)
+		noError(t, err, "OverlappingTimeEntries unbounded")
+		equalIDs(t, entryIDs(entries), []int{second}, "OverlappingTimeEntries unbounded IDs")
+	})
+
+	subtest(t, "TrimFlag", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		peopleID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		taskID := createTask(t, ctx, s, entities.Task{Title: "Task"})
+
+		first := addEntry(t, ctx, s, taskID, peopleID, at(0), at(60))
+
+		// Ошибка в изменениях отменяет и их, и новую запись
+		_, err := s.TimeManage.StartTimeEntry(ctx, taskID, peopleID, at(-10), entities.OverlapChanges{Trim: []int{first}})
+		isError(t, err, domain.ErrNoRecordsFound, "StartTimeEntry trimming entry started later")
+
+		_, err = s.TimeManage.StartTimeEntry(ctx, taskID, peopleID, at(10), entities.OverlapChanges{Trim: []int{first, 999}})
+		isError(t, err, domain.ErrNoRecordsFound, "StartTimeEntry trimming unknown entry")
+
+		entries, err := s.TimeManage.ListTimeEntries(ctx, taskID)
+		noError(t, err, "ListTimeEntries after failed trim")
+		equalIDs(t, entryIDs(entries), []int{first}, "ListTimeEntries IDs after failed trim")
+		sameTime(t, entries[0].EndTime, at(60), "EndTime after failed trim")
+
+		second, err := s.TimeManage.StartTimeEntry(ctx, taskID, peopleID, at(30), entities.OverlapChanges{Trim: []int{first}})
+		noError(t, err, "StartTimeEntry with trim")
+		endEntry(t, ctx, s, taskID, peopleID, at(90))
+
+		// Помеченные записи не проверяются на пересечение
+		third, err := s.TimeManage.StartTimeEntry(ctx, taskID, peopleID, at(45), entities.OverlapChanges{Flag: []int{second}})
+		noError(t, err, "StartTimeEntry with flag")
+		endEntry(t, ctx, s, taskID, peopleID, at(75))
+
+		entries, err = s.TimeManage.ListTimeEntries(ctx, taskID)
+		noError(t, err, "ListTimeEntries")
+		equalIDs(t, entryIDs(entries), []int{first, second, third}, "ListTimeEntries IDs")
+		sameTime(t, entries[0].EndTime, at(30), "EndTime of trimmed entry")
+		if entries[0].Overlaps || !entries[1].Overlaps || !entries[2].Overlaps {
+			t.Errorf("Overlaps flags = %v, %v, %v, want false, true, true", entries[0].Overlaps, entries[1].Overlaps, entries[2].Overlaps)
+		}
+
+		// Запись о времени новой задачи сохраняется вместе с изменениями
+		otherTask, err := s.TaskManage.Create(ctx, entities.Task{Title: "Other", TimeEntry: entities.TimeEntry{PeopleID: peopleID, StartTime: at(80), EndTime: at(100)}},
+			entities.OverlapChanges{Trim: []int{second}})
+		noError(t, err, "TaskManage.Create with trim")
+
+		entries, err = s.TimeManage.ListTimeEntries(ctx, taskID)
+		noError(t, err, "ListTimeEntries after task create")
+		sameTime(t, entries[1].EndTime, at(80), "EndTime of entry trimmed by task create")
+
+		entries, err = s.TimeManage.ListTimeEntries(ctx, otherTask)
+		noError(t, err, "ListTimeEntries of new task")
+		if len(entries) != 1 || entries[0].Overlaps {
+			t.Fatalf("entries of new task = %+v", entries)
+		}
+	})
+
+	subtest(t, "EndTaskTimeEntries", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		firstID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		secondID := createPeople(t, ctx, s, newPeople("Petrov"))
+		taskID := createTask(t, ctx, s, entities.Task{Title: "Task"})
+
+		startEntry(t, ctx, s, taskID, firstID, at(0))
+		startEntry(t, ctx, s, taskID, secondID, at(10))
+		noError(t, s.TimeManage.PauseTimeEntry(ctx, secondID, at(20)), "PauseTimeEntry")
+
+		noError(t, s.TimeManage.EndTaskTimeEntries(ctx, taskID, at(60)), "EndTaskTimeEntries")
+
+		for _, peopleID := range []int{firstID, secondID} {
+			timer, err := s.TimeManage.ActiveTimeEntry(ctx, peopleID)
+			noError(t, err, "ActiveTimeEntry")
+			if timer != (entities.ActiveTimer{}) {
+				t.Fatalf("ActiveTimeEntry of people %d after EndTaskTimeEntries = %+v", peopleID, timer)
+			}
+		}
+
+		entries, err := s.TimeManage.ListTimeEntries(ctx, taskID)
+		noError(t, err, "ListTimeEntries")
+		if len(entries) != 2 {
+			t.Fatalf("ListTimeEntries = %+v, want two entries", entries)
+		}
+		sameTime(t, entries[0].EndTime, at(60), "EndTime of running entry")
+		sameTime(t, entries[1].EndTime, at(20), "EndTime of paused entry")
+	})
+
+	subtest(t, "ListTimeEntries", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		firstID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		secondID := createPeople(t, ctx, s, newPeople("Petrov"))
+		taskID := createTask(t, ctx, s, entities.Task{Title: "Task", TimeEntry: entities.TimeEntry{PeopleID: firstID}})
+
+		task, err := s.TaskManage.GetByID(ctx, taskID, false)
+		noError(t, err, "GetByID")
+
+		thirdID := createPeople(t, ctx, s, newPeople("Sidorov"))
+
+		late := addEntry(t, ctx, s, taskID, secondID, at(60), at(90))
+		early := addEntry(t, ctx, s, taskID, thirdID, at(0), at(30))
+
+		// Записи без начала идут последними
+		entries, err := s.TimeManage.ListTimeEntries(ctx, taskID)
+		noError(t, err, "ListTimeEntries")
+		equalIDs(t, entryIDs(entries), []int{early, late, task.TimeEntry.ID}, "ListTimeEntries IDs")
+
+		entries, err = s.TimeManage.ListTimeEntries(ctx, 999)
+		noError(t, err, "ListTimeEntries of unknown task")
+		if len(entries) != 0 {
+			t.Fatalf("ListTimeEntries of unknown task = %+v", entries)
+		}
+	})
+
+	subtest(t, "TimeSpent", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		ivanov := newPeople("Ivanov")
+		ivanovID := createPeople(t, ctx, s, ivanov)
+		petrovID := createPeople(t, ctx, s, newPeople("Petrov"))
+
+		alpha := createProject(t, ctx, s, "Alpha")
+		beta := createProject(t, ctx, s, "Beta")
+
+		short := createTask(t, ctx, s, entities.Task{Title: "Short", ProjectID: alpha})
+		long := createTask(t, ctx, s, entities.Task{Title: "Long", ProjectID: beta})
+		free := createTask(t, ctx, s, entities.Task{Title: "Free"})
+
+		// Сессия за пределами периода и незавершённая сессия не учитываются
+		addEntry(t, ctx, s, free, ivanovID, at(-120), at(-60))
+		addEntry(t, ctx, s, short, ivanovID, at(0), at(30))
+		addEntry(t, ctx, s, short, ivanovID, at(60), at(120))
+		addEntry(t, ctx, s, long, ivanovID, at(120), at(300))
+		addEntry(t, ctx, s, free, petrovID, at(0), at(60))
+		startEntry(t, ctx, s, free, petrovID, at(300))
+
+		spent, err := s.TimeManage.TasksTimeSpent(ctx, 0, 0, at(0), at(600))
+		noError(t, err, "TasksTimeSpent")
+
+		want := []entities.TaskTimeSpent{
+			{PeopleID: ivanovID, TaskID: long, TaskTitle: "Long", TimeSpent: "03:00:00", Hours: 3},
+			{PeopleID: ivanovID, TaskID: short, TaskTitle: "Short", TimeSpent: "01:30:00", Hours: 1.5},
+			{PeopleID: petrovID, TaskID: free, TaskTitle: "Free", TimeSpent: "01:00:00", Hours: 1},
+		}
+		if len(spent) != len(want) {
+			t.Fatalf("TasksTimeSpent = %+v, want %d rows", spent, len(want))
+		}
+		for i, row := range spent {
+			w := want[i]
+			if row.PeopleID != w.PeopleID || row.TaskID != w.TaskID || row.TaskTitle != w.TaskTitle ||
+				row.TimeSpent != w.TimeSpent || row.Hours != w.Hours {
+				t.Errorf("TasksTimeSpent[%d] = %+v, want %+v", i, row, w)
+			}
+		}
+		if spent[0].Surname != ivanov.Surname || spent[0].Name != ivanov.Name || spent[0].Patronymic != ivanov.Patronymic {
+			t.Errorf("TasksTimeSpent[0] people = %+v, want %+v", spent[0], ivanov)
+		}
+
+		spent, err = s.TimeManage.TasksTimeSpent(ctx, petrovID, 0, at(0), at(600))
+		noError(t, err, "TasksTimeSpent by people")
+		if len(spent) != 1 || spent[0].TaskID != free {
+			t.Fatalf("TasksTimeSpent by people = %+v", spent)
+		}
+
+		spent, err = s.TimeManage.TasksTimeSpent(ctx, 0, alpha, at(0), at(600))
+		noError(t, err, "TasksTimeSpent by project")
+		if len(spent) != 1 || spent[0].TaskID != short {
+			t.Fatalf("TasksTimeSpent by project = %+v", spent)
+		}
+
+		spent, err = s.TimeManage.TasksTimeSpent(ctx, 0, 0, at(600), at(700))
+		noError(t, err, "TasksTimeSpent for empty period")
+		if len(spent) != 0 {
+			t.Fatalf("TasksTimeSpent for empty period = %+v", spent)
+		}
+
+		projects, err := s.TimeManage.ProjectsTimeSpent(ctx, 0, at(0), at(600))
+		noError(t, err, "ProjectsTimeSpent")
+		wantProjects := []entities.ProjectTimeSpent{
+			{ProjectID: beta, ProjectName: "Beta", TimeSpent: "03:00:00"},
+			{ProjectID: alpha, ProjectName: "Alpha", TimeSpent: "01:30:00"},
+		}
+		if len(projects) != len(wantProjects) {
+			t.Fatalf("ProjectsTimeSpent = %+v, want %+v", projects, wantProjects)
+		}
+		for i := range projects {
+			if projects[i] != wantProjects[i] {
+				t.Errorf("ProjectsTimeSpent[%d] = %+v, want %+v", i, projects[i], wantProjects[i])
+			}
+		}
+
+		projects, err = s.TimeManage.ProjectsTimeSpent(ctx, petrovID, at(0), at(600))
+		noError(t, err, "ProjectsTimeSpent by people")
+		if len(projects) != 0 {
+			t.Fatalf("ProjectsTimeSpent by people = %+v", projects)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package storagetest
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/internal/storage"
+	"context"
+	"testing"
+	"time"
+)
+
+func testTimesheet(t *testing.T, newStorage Factory) {
+	subtest(t, "ApprovedWeek", newStorage, func(t *testing.T, ctx context.Context, s *storage.Storage) {
+		peopleID := createPeople(t, ctx, s, newPeople("Ivanov"))
+		otherID := createPeople(t, ctx, s, newPeople("Petrov"))
+
+		// Понедельник недели, в которую попадает base
+		week := time.Date(2024, 7, 29, 0, 0, 0, 0, time.UTC)
+		nextWeek := week.AddDate(0, 0, 7)
+
+		noError(t, s.TimesheetManage.SubmitTimesheet(ctx, peopleID, week, nextWeek), "SubmitTimesheet")
+		noError(t, s.TimesheetManage.SubmitTimesheet(ctx, otherID, week, nextWeek), "SubmitTimesheet of other people")
+
+		// Отправленная, но не согласованная неделя не блокирует время
+		approved, err := s.TimesheetManage.ApprovedWeek(ctx, peopleID, at(0), at(60))
+		noError(t, err, "ApprovedWeek before review")
+		sameTime(t, approved, time.Time{}, "ApprovedWeek before review")
+
+		noError(t, s.TimesheetManage.ReviewTimesheet(ctx, peopleID, week, entities.TimesheetApproved, "", otherID, nextWeek), "ReviewTimesheet")
+
+		cases := []struct {
+			name       string
+			peopleID   int
+			start, end time.Time
+			want       time.Time
+		}{
+			{"inside", peopleID, at(0), at(60), week},
+			{"moment", peopleID, at(0), at(0), week},
+			{"started before", peopleID, week.Add(-time.Hour), week.Add(time.Hour), week},
+			{"ended after", peopleID, nextWeek.Add(-time.Hour), nextWeek.Add(time.Hour), week},
+			{"before", peopleID, week.Add(-2 * time.Hour), week.Add(-time.Hour), time.Time{}},
+			{"after", peopleID, nextWeek, nextWeek.Add(time.Hour), time.Time{}},
+			{"other people", otherID, at(0), at(60), time.Time{}},
+		}
+
+		for _, tc := range cases {
+			approved, err := s.TimesheetManage.ApprovedWeek(ctx, tc.peopleID, tc.start, tc.end)
+			noError(t, err, "ApprovedWeek "+tc.name)
+			sameTime(t, approved, tc.want, "ApprovedWeek "+tc.name)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/pkg/logger"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+// Handler methods for API keys
+
+type apiKeyRequest struct {
+	Name   string           `json:"name" example:"ci-bot"`
+	Scopes []entities.Scope `json:"scopes" example:"time:write,reports:read"`
+}
+
+// @Summary Create API key
+// @Description Create a long-lived API key for the current person. The key is returned only once, send it in the X-API-Key header. Scopes: people:read, people:write, tasks:read, tasks:write, projects:read, projects:write, time:read, time:write, reports:read.
+// @Tags APIKey
+// @Accept json
+// @Produce json
+// @Param key body apiKeyRequest true "Key name and scopes"
+// @Success 201 {object} entities.APIKeyCreated
+// @Failure 400 {object} Problem
+// @Failure 403 {object} Problem "Not available with API key"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Router /apikeys [post]
+func (h *Handler) apiKeyCreate(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.apiKeyCreate"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var req apiKeyRequest
+	if err := decodeJSON(r, &req); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	key, err := h.services.APIKey.Create(r.Context(), req.Name, req.Scopes)
+	if err != nil {
+		log.Error("Failed to create api key", logger.Err(err))
+		writeError(w, r, err, "Failed to create API key")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(key); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary List API keys
+// @Description List API keys of the current person, including revoked ones. Admin can list keys of another person.
+// @Tags APIKey
+// @Accept json
+// @Produce json
+// @Param people_id query int false "People ID, defaults to the current person"
+// @Success 200 {array} entities.APIKey
+// @Failure 400 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Router /apikeys [get]
+func (h *Handler) apiKeyList(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.apiKeyList"
+	log := h.Logs.With(slog.String("operation", op))
+
+	peopleID, err := parseQueryID(r, "people_id")
+	if err != nil {
+		log.Error("Invalid people ID", logger.Err(err))
+		writeError(w, r, err, "Invalid people ID")
+		return
+	}
+
+	keys, err := h.services.APIKey.List(r.Context(), peopleID)
+	if err != nil {
+		log.Error("Failed to list api keys", logger.Err(err))
+		writeError(w, r, err, "Failed to list API keys")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(keys); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary Revoke API key
+// @Description Revoke an API key. People can revoke their own keys, admin can revoke any key.
+// @Tags APIKey
+// @Accept json
+// @Produce json
+// @Param keyID path int true "API key ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem "Active API key not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Router /apikeys/{keyID} [delete]
+func (h *Handler) apiKeyRevoke(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.apiKeyRevoke"
+	log := h.Logs.With(slog.String("operation", op))
+
+	keyID, err := parsePathID(r, "keyID")
+	if err != nil {
+		log.Error("Invalid api key ID", logger.Err(err))
+		writeError(w, r, err, "Invalid API key ID")
+		return
+	}
+
+	if err := h.services.APIKey.Revoke(r.Context(), keyID); err != nil {
+		log.Error("Failed to revoke api key", logger.Err(err))
+		writeError(w, r, err, "Failed to revoke API key")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"TaskSync/internal/service"
+	"TaskSync/pkg/logger"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// Handler methods for Auth
+
+type authLogin struct {
+	PeopleID int    `json:"people_id"`
+	Password string `json:"password"`
+}
+
+// @Summary Login
+// @Description Log in with people ID and password. Returns a short-lived access token and a refresh token.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param credentials body authLogin true "People ID and password"
+// @Success 200 {object} entities.TokenPair
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem "Invalid credentials"
+// @Failure 500 {object} Problem
+// @Router /auth/login [post]
+func (h *Handler) authLogin(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.authLogin"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var credentials authLogin
+	if err := decodeJSON(r, &credentials); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	tokens, err := h.services.Auth.Login(r.Context(), credentials.PeopleID, credentials.Password)
+	if err != nil {
+		log.Info("Failed to log in", slog.Int("people_id", credentials.PeopleID), logger.Err(err))
+		if errors.Is(err, service.ErrUnauthorized) {
+			writeErrorResponse(w, r, http.StatusUnauthorized, "Invalid credentials")
+			return
+		}
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to log in")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(tokens); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+type authRefresh struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// @Summary Refresh Tokens
+// @Description Exchange a refresh token for a new token pair. The presented refresh token can not be used again.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param token body authRefresh true "Refresh token"
+// @Success 200 {object} entities.TokenPair
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem "Invalid refresh token"
+// @Failure 500 {object} Problem
+// @Router /auth/refresh [post]
+func (h *Handler) authRefresh(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.authRefresh"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var token authRefresh
+	if err := decodeJSON(r, &token); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	tokens, err := h.services.Auth.Refresh(r.Context(), token.RefreshToken)
+	if err != nil {
+		log.Info("Failed to refresh tokens", logger.Err(err))
+		if errors.Is(err, service.ErrUnauthorized) {
+			writeErrorResponse(w, r, http.StatusUnauthorized, "Invalid refresh token")
+			return
+		}
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to refresh tokens")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(tokens); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary Logout
+// @Description Revoke the current session. Its access and refresh tokens stop being accepted.
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {string} string "OK"
+// @Failure 401 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /auth/logout [post]
+func (h *Handler) authLogout(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.authLogout"
+	log := h.Logs.With(slog.String("operation", op))
+
+	caller, _ := service.CallerFromContext(r.Context())
+
+	if err := h.services.Auth.Logout(r.Context(), caller.SessionID); err != nil {
+		log.Error("Failed to log out", logger.Err(err))
+		writeError(w, r, err, "Failed to log out")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+type authPassword struct {
+	PeopleID        int    `json:"people_id"`
+	CurrentPassword string `json:"current_password"`
+	Password        string `json:"password"`
+}
+
+// @Summary Set Password
+// @Description Set a password. A person may change their own password and must confirm it with current_password, an admin may set the password of any other person without it. All sessions of the person are revoked.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param password body authPassword true "People ID, current password when changing own password and new password, at least 8 characters"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /auth/password [put]
+func (h *Handler) authSetPassword(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.authSetPassword"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var values authPassword
+	if err := decodeJSON(r, &values); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	if err := h.services.Auth.SetPassword(r.Context(), values.PeopleID, values.CurrentPassword, values.Password); err != nil {
+		log.Error("Failed to set password", logger.Err(err))
+		writeError(w, r, err, "Failed to set password")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
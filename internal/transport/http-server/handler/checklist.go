@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"TaskSync/pkg/logger"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+// Handler methods for task checklists
+
+type checklistItem struct {
+	Title string `json:"title"`
+}
+
+type checklistCheck struct {
+	Done bool `json:"done"`
+}
+
+// @Summary Add Checklist Item
+// @Description Append an item to the checklist of a task. Completion of the checklist is returned with the task.
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param taskID path int true "Task ID"
+// @Param item body checklistItem true "Checklist item"
+// @Success 201 {integer} int "ID of the created checklist item"
+// @Failure 400 {object} Problem "Invalid task ID or title"
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /task/{taskID}/checklist [post]
+func (h *Handler) taskChecklistAdd(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.taskChecklistAdd"
+	log := h.Logs.With(slog.String("operation", op))
+
+	taskID, err := parsePathID(r, "taskID")
+	if err != nil {
+		log.Error("Invalid task ID", logger.Err(err))
+		writeError(w, r, err, "Invalid task ID")
+		return
+	}
+
+	var item checklistItem
+	if err := decodeJSON(r, &item); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	id, err := h.services.Checklist.Add(r.Context(), taskID, item.Title)
+	if err != nil {
+		log.Error("Failed to add checklist item", logger.Err(err))
+		writeError(w, r, err, "Failed to add checklist item")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(id); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary Check Checklist Item
+// @Description Tick or untick a checklist item. Items are ticked independently of each other, ticking an already ticked item keeps the original time.
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param taskID path int true "Task ID"
+// @Param itemID path int true "Checklist item ID"
+// @Param check body checklistCheck true "Whether the item is done"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem "Invalid task ID or item ID"
+// @Failure 404 {object} Problem "Checklist item not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /task/{taskID}/checklist/{itemID} [put]
+func (h *Handler) taskChecklistCheck(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.taskChecklistCheck"
+	log := h.Logs.With(slog.String("operation", op))
+
+	taskID, err := parsePathID(r, "taskID")
+	if err != nil {
+		log.Error("Invalid task ID", logger.Err(err))
+		writeError(w, r, err, "Invalid task ID")
+		return
+	}
+
+	itemID, err := parsePathID(r, "itemID")
+	if err != nil {
+		log.Error("Invalid checklist item ID", logger.Err(err))
+		writeError(w, r, err, "Invalid checklist item ID")
+		return
+	}
+
+	var check checklistCheck
+	if err := decodeJSON(r, &check); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	if err := h.services.Checklist.Check(r.Context(), taskID, itemID, check.Done); err != nil {
+		log.Error("Failed to check checklist item", logger.Err(err))
+		writeError(w, r, err, "Failed to check checklist item")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+// @Summary Remove Checklist Item
+// @Description Remove an item from the checklist of a task
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param taskID path int true "Task ID"
+// @Param itemID path int true "Checklist item ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem "Invalid task ID or item ID"
+// @Failure 404 {object} Problem "Checklist item not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /task/{taskID}/checklist/{itemID} [delete]
+func (h *Handler) taskChecklistRemove(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.taskChecklistRemove"
+	log := h.Logs.With(slog.String("operation", op))
+
+	taskID, err := parsePathID(r, "taskID")
+	if err != nil {
+		log.Error("Invalid task ID", logger.Err(err))
+		writeError(w, r, err, "Invalid task ID")
+		return
+	}
+
+	itemID, err := parsePathID(r, "itemID")
+	if err != nil {
+		log.Error("Invalid checklist item ID", logger.Err(err))
+		writeError(w, r, err, "Invalid checklist item ID")
+		return
+	}
+
+	if err := h.services.Checklist.Remove(r.Context(), taskID, itemID); err != nil {
+		log.Error("Failed to remove checklist item", logger.Err(err))
+		writeError(w, r, err, "Failed to remove checklist item")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
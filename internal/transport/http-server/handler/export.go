@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"TaskSync/internal/entities"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Форматы выгрузки отчётов.
+const (
+	formatJSON = "json"
+	formatCSV  = "csv"
+	formatXLSX = "xlsx"
+
+	mimeCSV  = "text/csv"
+	mimeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// reportFormat определяет формат отчёта: параметр format имеет приоритет над заголовком Accept.
+// Возвращает false, если запрошен неизвестный формат.
+func reportFormat(r *http.Request) (string, bool) {
+	switch format := strings.ToLower(r.URL.Query().Get("format")); format {
+	case formatJSON, formatCSV, formatXLSX:
+		return format, true
+	case "":
+	default:
+		return "", false
+	}
+
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case mimeCSV:
+			return formatCSV, true
+		case mimeXLSX:
+			return formatXLSX, true
+		}
+	}
+
+	return formatJSON, true
+}
+
+// report табличный отчёт: строка заголовков и строки значений.
+// Числа в строках сохраняются числами, чтобы таблицы могли их суммировать.
+type report struct {
+	name   string
+	header []string
+	rows   [][]any
+}
+
+// timeSpentReport строит отчёт о трудозатратах с итогом по каждому пользователю и общим итогом.
+// Итоги записываются в отдельную колонку, чтобы сумма колонки Hours совпадала с общим итогом.
+// Записи должны быть сгруппированы по пользователю.
+func timeSpentReport(name string, entries []entities.TaskTimeSpent) report {
+	rep := report{
+		name:   name,
+		header: []string{"People ID", "Surname", "Name", "Patronymic", "Task ID", "Task", "Hours", "Total hours"},
+	}
+
+	var personTotal, grandTotal float64
+	for i, e := range entries {
+		rep.rows = append(rep.rows, []any{e.PeopleID, e.Surname, e.Name, e.Patronymic, e.TaskID, e.TaskTitle, e.Hours, ""})
+		personTotal += e.Hours
+		grandTotal += e.Hours
+
+		if i == len(entries)-1 || entries[i+1].PeopleID != e.PeopleID {
+			rep.rows = append(rep.rows, []any{e.PeopleID, e.Surname, e.Name, e.Patronymic, "", "Total", "", roundHours(personTotal)})
+			personTotal = 0
+		}
+	}
+
+	rep.rows = append(rep.rows, []any{"", "", "", "", "", "Grand total", "", roundHours(grandTotal)})
+
+	return rep
+}
+
+func roundHours(h float64) float64 {
+	return math.Round(h*100) / 100
+}
+
+// writeReport отправляет отчёт файлом в формате CSV или XLSX.
+func writeReport(w http.ResponseWriter, format string, rep report) error {
+	switch format {
+	case formatCSV:
+		w.Header().Set("Content-Type", mimeCSV+"; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, rep.name))
+		return writeCSV(w, rep)
+	case formatXLSX:
+		w.Header().Set("Content-Type", mimeXLSX)
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.xlsx"`, rep.name))
+		return writeXLSX(w, rep)
+	default:
+		return fmt.Errorf("unsupported report format %q", format)
+	}
+}
+
+func writeCSV(w http.ResponseWriter, rep report) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write(rep.header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(rep.header))
+	for _, row := range rep.rows {
+		for i, v := range row {
+			switch v := v.(type) {
+			case float64:
+				record[i] = strconv.FormatFloat(v, 'f', 2, 64)
+			case string:
+				record[i] = escapeFormula(v)
+			default:
+				record[i] = fmt.Sprint(v)
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// escapeFormula защищает ячейку CSV от выполнения как формулы в табличном редакторе:
+// значения, начинающиеся с =, +, -, @, табуляции или возврата каретки, получают префикс '.
+// В XLSX строки сохраняются строковыми ячейками и формулами не становятся.
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func writeXLSX(w http.ResponseWriter, rep report) error {
+	const sheet = "Sheet1"
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+
+	header := make([]any, len(rep.header))
+	for i, h := range rep.header {
+		header[i] = h
+	}
+
+	if err := sw.SetRow("A1", header); err != nil {
+		return err
+	}
+
+	for i, row := range rep.rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return err
+		}
+		if err := sw.SetRow(cell, row); err != nil {
+			return err
+		}
+	}
+
+	if err := sw.Flush(); err != nil {
+		return err
+	}
+
+	return f.Write(w)
+}
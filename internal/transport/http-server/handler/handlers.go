@@ -2,6 +2,7 @@ package handler
 
 import (
 	_ "TaskSync/docs"
+	"TaskSync/internal/entities"
 	"TaskSync/internal/service"
 	"log/slog"
 
@@ -26,6 +27,8 @@ func (h *Handler) InitLogger(l *slog.Logger) {
 
 func (h *Handler) InitRouter() *chi.Mux {
 	r := chi.NewRouter()
+	r.Use(middleware.RequestID) // ID запроса для ответов об ошибках
+	r.Use(exposeRequestID)
 	r.Use(middleware.Recoverer) // Recovery из panic
 	r.Use(middleware.CleanPath) // Исправление путей
 
@@ -33,7 +36,8 @@ func (h *Handler) InitRouter() *chi.Mux {
 		AllowedOrigins: []string{"http://localhost:8080"}, // Разрешаем запросы только с этого домена
 		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "Content-Length", "Cache-Control",
-			"Connection", "Host", "Origin"},
+			"Connection", "Host", "Origin", apiKeyHeader},
+		ExposedHeaders:   []string{requestIDHeader, totalCountHeader},
 		AllowCredentials: true,
 		MaxAge:           300,
 	})
@@ -44,31 +48,142 @@ func (h *Handler) InitRouter() *chi.Mux {
 		httpSwagger.URL("http://localhost:8080/swagger/doc.json"),
 	))
 
-	// API people
-	r.Route("/people", func(r chi.Router) {
-		r.Get("/", h.peopleList)
-		r.Post("/", h.peopleCreate)
-		r.Get("/{peopleID}", h.peopleGetByID)
-		r.Get("/filter", h.peopleGetByFilter)
-		r.Put("/", h.peopleUpdate)
-		r.Delete("/{peopleID}", h.peopleDelete)
-	})
+	// API auth, вход и обновление токенов доступны без авторизации
+	r.Route("/auth", func(r chi.Router) {
+		r.Post("/login", h.authLogin)
+		r.Post("/refresh", h.authRefresh)
 
-	// API task
-	r.Route("/task", func(r chi.Router) {
-		r.Get("/", h.taskList)
-		r.Post("/", h.taskCreate)
-		r.Get("/{taskID}", h.taskGetByID)
-		r.Put("/", h.taskUpdate)
-		r.Put("/update-people", h.taskUpdatePeople)
-		r.Delete("/{taskID}", h.taskDelete)
+		r.Group(func(r chi.Router) {
+			r.Use(h.authenticate, h.requireSession)
+			r.Post("/logout", h.authLogout)
+			r.Put("/password", h.authSetPassword)
+		})
 	})
 
-	// API time
-	r.Route("/time", func(r chi.Router) {
-		r.Post("/start", h.timeStartTimeEntry)
-		r.Post("/end", h.timeEndTimeEntry)
-		r.Post("/spent", h.TasksTimeSpent)
+	// Остальные маршруты требуют bearer token или API ключ с нужной областью доступа
+	r.Group(func(r chi.Router) {
+		r.Use(h.authenticate)
+
+		// API ключи, управление только после входа по паролю
+		r.Route("/apikeys", func(r chi.Router) {
+			r.Use(h.requireSession)
+			r.Get("/", h.apiKeyList)
+			r.Post("/", h.apiKeyCreate)
+			r.Delete("/{keyID}", h.apiKeyRevoke)
+		})
+
+		// API people
+		r.Route("/people", func(r chi.Router) {
+			r.Use(h.requireScopeByMethod(entities.ScopePeopleRead, entities.ScopePeopleWrite))
+			r.Get("/", h.peopleList)
+			r.Post("/", h.peopleCreate)
+			r.Get("/{peopleID}", h.peopleGetByID)
+			r.Get("/filter", h.peopleGetByFilter)
+			r.Put("/", h.peopleUpdate)
+			r.Delete("/{peopleID}", h.peopleDelete)
+		})
+
+		// API task
+		r.Route("/task", func(r chi.Router) {
+			r.Use(h.requireScopeByMethod(entities.ScopeTasksRead, entities.ScopeTasksWrite))
+			r.Get("/", h.taskList)
+			r.Post("/", h.taskCreate)
+			r.Post("/from-template/{templateID}", h.taskFromTemplate)
+			r.Get("/{taskID}", h.taskGetByID)
+			r.Get("/{taskID}/children", h.taskChildren)
+			r.Put("/", h.taskUpdate)
+			r.Put("/update-people", h.taskUpdatePeople)
+			r.Put("/update-project", h.taskUpdateProject)
+			r.Put("/{taskID}/parent", h.taskUpdateParent)
+			r.Put("/{taskID}/schedule", h.taskUpdateSchedule)
+			r.Post("/{taskID}/transition", h.taskTransition)
+			r.Post("/{taskID}/tags/{tagID}", h.taskAttachTag)
+			r.Delete("/{taskID}/tags/{tagID}", h.taskDetachTag)
+			r.Post("/{taskID}/blocks/{blockedID}", h.taskAddDependency)
+			r.Delete("/{taskID}/blocks/{blockedID}", h.taskRemoveDependency)
+			r.Get("/{taskID}/assignees", h.taskAssignees)
+			r.Post("/{taskID}/assignees/{peopleID}", h.taskAssign)
+			r.Delete("/{taskID}/assignees/{peopleID}", h.taskUnassign)
+			r.With(h.requireScope(entities.ScopeReportsRead)).Get("/{taskID}/time", h.taskTimeByAssignee)
+			r.Post("/{taskID}/checklist", h.taskChecklistAdd)
+			r.Put("/{taskID}/checklist/{itemID}", h.taskChecklistCheck)
+			r.Delete("/{taskID}/checklist/{itemID}", h.taskChecklistRemove)
+			r.Delete("/{taskID}", h.taskDelete)
+		})
+
+		// API tag, метки задач
+		r.Route("/tag", func(r chi.Router) {
+			r.Use(h.requireScopeByMethod(entities.ScopeTasksRead, entities.ScopeTasksWrite))
+			r.Get("/", h.tagList)
+			r.Post("/", h.tagCreate)
+			r.Get("/{tagID}", h.tagGetByID)
+			r.Put("/", h.tagUpdate)
+			r.Delete("/{tagID}", h.tagDelete)
+		})
+
+		// API recurrence, повторяющиеся задачи
+		r.Route("/recurrence", func(r chi.Router) {
+			r.Use(h.requireScopeByMethod(entities.ScopeTasksRead, entities.ScopeTasksWrite))
+			r.Get("/", h.recurrenceList)
+			r.Post("/", h.recurrenceCreate)
+			r.Get("/{recurrenceID}", h.recurrenceGetByID)
+			r.Delete("/{recurrenceID}", h.recurrenceDelete)
+		})
+
+		// API template, шаблоны задач
+		r.Route("/template", func(r chi.Router) {
+			r.Use(h.requireScopeByMethod(entities.ScopeTasksRead, entities.ScopeTasksWrite))
+			r.Get("/", h.templateList)
+			r.Post("/", h.templateCreate)
+			r.Get("/{templateID}", h.templateGetByID)
+			r.Put("/", h.templateUpdate)
+			r.Delete("/{templateID}", h.templateDelete)
+		})
+
+		// API search, результаты включают и пользователей, и задачи
+		r.With(h.requireScope(entities.ScopePeopleRead), h.requireScope(entities.ScopeTasksRead)).Get("/search", h.search)
+
+		// API project
+		r.Route("/project", func(r chi.Router) {
+			r.Use(h.requireScopeByMethod(entities.ScopeProjectsRead, entities.ScopeProjectsWrite))
+			r.Get("/", h.projectList)
+			r.Post("/", h.projectCreate)
+			r.Get("/{projectID}", h.projectGetByID)
+			r.Put("/", h.projectUpdate)
+			r.Delete("/{projectID}", h.projectDelete)
+		})
+
+		// API time
+		r.Route("/time", func(r chi.Router) {
+			r.Group(func(r chi.Router) {
+				r.Use(h.requireScope(entities.ScopeTimeWrite))
+				r.Post("/start", h.timeStartTimeEntry)
+				r.Post("/end", h.timeEndTimeEntry)
+				r.Post("/pause", h.timePause)
+				r.Post("/resume", h.timeResume)
+			})
+			r.Group(func(r chi.Router) {
+				r.Use(h.requireScope(entities.ScopeTimeRead))
+				r.Get("/active", h.timeActive)
+				r.Get("/entries", h.timeListEntries)
+			})
+			r.Group(func(r chi.Router) {
+				r.Use(h.requireScope(entities.ScopeReportsRead))
+				r.Post("/spent", h.TasksTimeSpent)
+				r.Post("/spent/projects", h.projectsTimeSpent)
+				r.Post("/spent/tags", h.tagsTimeSpent)
+				r.Get("/estimates", h.timeEstimates)
+			})
+		})
+
+		// API timesheet
+		r.Route("/timesheet", func(r chi.Router) {
+			r.Use(h.requireScopeByMethod(entities.ScopeTimeRead, entities.ScopeTimeWrite))
+			r.Get("/", h.timesheetGet)
+			r.Post("/submit", h.timesheetSubmit)
+			r.Post("/approve", h.timesheetApprove)
+			r.Post("/reject", h.timesheetReject)
+		})
 	})
 
 	return r
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/internal/service"
+	"TaskSync/pkg/logger"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// apiKeyHeader заголовок с API ключом интеграции, альтернатива bearer token.
+const apiKeyHeader = "X-API-Key"
+
+// requestIDHeader заголовок ответа с ID запроса, тот же ID возвращается в поле request_id ошибок.
+const requestIDHeader = "X-Request-Id"
+
+// exposeRequestID возвращает клиенту ID запроса, назначенный middleware.RequestID.
+func exposeRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(requestIDHeader, middleware.GetReqID(r.Context()))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authenticate проверяет API ключ из заголовка X-API-Key или bearer token из заголовка Authorization
+// и сохраняет пользователя, выполняющего запрос, в контексте.
+func (h *Handler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const op = "handler.authenticate"
+		log := h.Logs.With(slog.String("operation", op))
+
+		if key := r.Header.Get(apiKeyHeader); key != "" {
+			caller, err := h.services.APIKey.Authenticate(r.Context(), key)
+			if err != nil {
+				log.Info("Invalid api key", logger.Err(err))
+				writeErrorResponse(w, r, http.StatusUnauthorized, "Invalid or revoked API key")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(service.ContextWithCaller(r.Context(), caller)))
+			return
+		}
+
+		scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeErrorResponse(w, r, http.StatusUnauthorized, "Missing bearer token")
+			return
+		}
+
+		caller, err := h.services.Auth.Authenticate(r.Context(), token)
+		if err != nil {
+			log.Info("Invalid bearer token", logger.Err(err))
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			writeErrorResponse(w, r, http.StatusUnauthorized, "Invalid or expired token")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(service.ContextWithCaller(r.Context(), caller)))
+	})
+}
+
+// requireSession пропускает только запросы с bearer token, API ключом такие маршруты недоступны.
+func (h *Handler) requireSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if caller, ok := service.CallerFromContext(r.Context()); ok && caller.APIKeyID != 0 {
+			writeErrorResponse(w, r, http.StatusForbidden, "Not available with API key")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireScope проверяет, что API ключ имеет область доступа scope.
+// Запросы с bearer token не ограничиваются областями доступа.
+func (h *Handler) requireScope(scope entities.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if caller, ok := service.CallerFromContext(r.Context()); ok && caller.APIKeyID != 0 &&
+				!slices.Contains(caller.Scopes, scope) {
+				writeErrorResponse(w, r, http.StatusForbidden, "API key has no scope "+string(scope))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requireScopeByMethod проверяет область доступа read для GET запросов и write для остальных.
+func (h *Handler) requireScopeByMethod(read, write entities.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		readNext, writeNext := h.requireScope(read)(next), h.requireScope(write)(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				readNext.ServeHTTP(w, r)
+				return
+			}
+
+			writeNext.ServeHTTP(w, r)
+		})
+	}
+}
@@ -1,6 +1,16 @@
 package handler
 
-import "strconv"
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
 
 func parseQueryInt(value string) int {
 	if value == "" {
@@ -12,3 +22,74 @@ func parseQueryInt(value string) int {
 	}
 	return parsedValue
 }
+
+// parsePathID читает целочисленный параметр пути name, ошибка относится к полю name.
+func parsePathID(r *http.Request, name string) (int, error) {
+	id, err := strconv.Atoi(chi.URLParam(r, name))
+	if err != nil {
+		return 0, domain.NewFieldError(name, "must be an integer")
+	}
+	return id, nil
+}
+
+// parseQueryID читает параметр запроса name с ID, отсутствующий параметр - 0.
+// Нечисловое или отрицательное значение отклоняется, а не заменяется нулём,
+// чтобы запрос с опечаткой в ID не вернул данные пользователя, выполняющего запрос.
+func parseQueryID(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("%w: %w", domain.ErrInputData, domain.NewFieldError(name, "must be a positive integer"))
+	}
+	return id, nil
+}
+
+// parseQueryBool читает логический параметр запроса name, отсутствующий параметр - false.
+func parseQueryBool(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+	parsedValue, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, domain.NewFieldError(name, "must be a boolean")
+	}
+	return parsedValue, nil
+}
+
+// parsePageQuery читает параметры страницы списка limit, cursor и sort.
+func parsePageQuery(r *http.Request) (entities.PageQuery, error) {
+	query := entities.PageQuery{
+		Cursor: r.URL.Query().Get("cursor"),
+		Sort:   r.URL.Query().Get("sort"),
+	}
+
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return query, domain.NewFieldError("limit", "must be an integer")
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
+
+// decodeJSON читает тело запроса в v. Ошибка типа значения относится к соответствующему полю,
+// остальные ошибки разбора - к телу запроса целиком.
+func decodeJSON(r *http.Request, v any) error {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err == nil {
+		return nil
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return domain.NewFieldError(typeErr.Field, fmt.Sprintf("must be of type %s", typeErr.Type))
+	}
+
+	return fmt.Errorf("%w: invalid request payload: %v", domain.ErrValidation, err)
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"TaskSync/internal/domain"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseQueryID(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    int
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"?people_id=", 0, false},
+		{"?people_id=0", 0, false},
+		{"?people_id=42", 42, false},
+		{"?people_id=abc", 0, true},
+		{"?people_id=4x", 0, true},
+		{"?people_id=1.5", 0, true},
+		{"?people_id=-1", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/timesheet"+tt.query, nil)
+
+			got, err := parseQueryID(r, "people_id")
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInputData) {
+					t.Fatalf("parseQueryID error = %v, want %v", err, domain.ErrInputData)
+				}
+				var validationErr *domain.ValidationError
+				if !errors.As(err, &validationErr) || validationErr.Fields[0].Field != "people_id" {
+					t.Fatalf("parseQueryID error = %v, want people_id field error", err)
+				}
+				if status := errorStatus(err); status != http.StatusBadRequest {
+					t.Fatalf("errorStatus = %d, want %d", status, http.StatusBadRequest)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("parseQueryID = %d, %v, want %d", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -6,22 +6,23 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
-	"strconv"
-
-	"github.com/go-chi/chi/v5"
 )
 
 // Handler methods for People
 
 // @Summary Create a new people
-// @Description Create a new person record. Passport number should be 6 digits and passport series should be 4 digits.
+// @Description Create a new person record. Passport number should be 6 digits and passport series should be 4 digits, address is required. Admin only, role defaults to member.
 // @Tags People
 // @Accept json
 // @Produce json
 // @Param people body entities.People true "Details of the person to create"
 // @Success 201 {integer} int "ID of the created people"
-// @Failure 422 {object} ErrorResponse "Invalid request payload"
-// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 400 {object} Problem "Invalid person data"
+// @Failure 403 {object} Problem "Forbidden"
+// @Failure 409 {object} Problem "Passport already exists"
+// @Failure 500 {object} Problem "Internal server error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /people [post]
 func (h *Handler) peopleCreate(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.peopleCreate"
@@ -29,50 +30,68 @@ func (h *Handler) peopleCreate(w http.ResponseWriter, r *http.Request) {
 
 	var people entities.People
 
-	if err := json.NewDecoder(r.Body).Decode(&people); err != nil {
+	if err := decodeJSON(r, &people); err != nil {
 		log.Error("Failed to decode request body", logger.Err(err))
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		writeError(w, r, err, "Invalid request payload")
 		return
 	}
 	id, err := h.services.People.Create(r.Context(), people)
 	if err != nil {
 		log.Error("Failed to create person", logger.Err(err))
-		writeErrorResponse(w, http.StatusUnprocessableEntity, "Failed to create person")
+		writeError(w, r, err, "Failed to create person")
 		return
 	}
 	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(id); err != nil {
 		log.Error("Failed to encode response", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to encode response")
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
 	}
 
 }
 
+// peoplePage страница пользователей в ответе, описывает entities.Page[entities.People] для swagger.
+type peoplePage struct {
+	Items      []entities.People `json:"items"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
 // @Summary List People
-// @Description Get all people
+// @Description Get a page of people. Sort fields: id, surname, name, patronymic.
 // @Tags People
 // @Accept json
 // @Produce json
-// @Success 200 {array} entities.People
-// @Failure 500 {object} ErrorResponse
+// @Param limit query int false "Page size, 50 by default, at most 500"
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param sort query string false "Comma separated sort fields, a leading minus sorts descending, e.g. surname,-id"
+// @Success 200 {object} peoplePage
+// @Header 200 {integer} X-Total-Count "Total number of people"
+// @Failure 400 {object} Problem "Invalid page parameters"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /people [get]
 func (h *Handler) peopleList(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.peopleList"
 	log := h.Logs.With(slog.String("operation", op))
 
-	people, err := h.services.People.List(r.Context())
+	query, err := parsePageQuery(r)
+	if err != nil {
+		log.Error("Invalid page parameters", logger.Err(err))
+		writeError(w, r, err, "Invalid page parameters")
+		return
+	}
+
+	people, err := h.services.People.List(r.Context(), query)
 	if err != nil {
 		log.Error("Failed to fetch people list", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch people list")
+		writeError(w, r, err, "Failed to fetch people list")
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(people); err != nil {
+	if err := writePage(w, people); err != nil {
 		log.Error("Failed to encode response", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to encode response")
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
 	}
-
-	w.WriteHeader(http.StatusOK)
 }
 
 // @Summary Get People by ID
@@ -82,36 +101,39 @@ func (h *Handler) peopleList(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param peopleID path int true "People ID"
 // @Success 200 {object} entities.People
-// @Failure 400 {object} ErrorResponse "Failed to fetch person by ID"
-// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Failure 400 {object} Problem "Invalid people ID"
+// @Failure 404 {object} Problem "Person not found"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /people/{peopleID} [get]
 func (h *Handler) peopleGetByID(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.peopleGetByID"
 	log := h.Logs.With(slog.String("operation", op))
 
-	peopleID := chi.URLParam(r, "peopleID")
-	id, err := strconv.Atoi(peopleID)
+	id, err := parsePathID(r, "peopleID")
 	if err != nil {
 		log.Error("Invalid people ID", logger.Err(err))
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid people ID")
+		writeError(w, r, err, "Invalid people ID")
 		return
 	}
 
 	people, err := h.services.People.GetByID(r.Context(), id)
 	if err != nil {
 		log.Error("Failed to fetch person by ID", logger.Err(err))
-		writeErrorResponse(w, http.StatusBadRequest, "Failed to fetch person by ID")
+		writeError(w, r, err, "Failed to fetch person by ID")
 		return
 	}
 
 	if err := json.NewEncoder(w).Encode(people); err != nil {
 		log.Error("Failed to encode response", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to encode response")
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
 	}
 }
 
 // @Summary Get People by Filter
-// @Description Get people based on filters
+// @Description Get a page of people based on filters. Sort fields: id, surname, name, patronymic.
+// @Description iexact, prefix and contains match ignore case.
 // @Tags People
 // @Accept json
 // @Produce json
@@ -122,66 +144,97 @@ func (h *Handler) peopleGetByID(w http.ResponseWriter, r *http.Request) {
 // @Param name query string false "Name"
 // @Param patronymic query string false "Patronymic"
 // @Param address query string false "Address"
-// @Param limit query int false "Limit"
-// @Param offset query int false "Offset"
-// @Success 200 {array} entities.People
-// @Failure 422 {object} ErrorResponse "Failed to fetch people by filter"
-// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Param surname_match query string false "How surname is compared, exact by default" Enums(exact, iexact, prefix, contains)
+// @Param name_match query string false "How name is compared, exact by default" Enums(exact, iexact, prefix, contains)
+// @Param patronymic_match query string false "How patronymic is compared, exact by default" Enums(exact, iexact, prefix, contains)
+// @Param address_match query string false "How address is compared, exact by default" Enums(exact, iexact, prefix, contains)
+// @Param role query string false "Role" Enums(admin, manager, member)
+// @Param manager_id query int false "Manager ID, lists the manager's team"
+// @Param limit query int false "Page size, 50 by default, at most 500"
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param sort query string false "Comma separated sort fields, a leading minus sorts descending, e.g. surname,-id"
+// @Success 200 {object} peoplePage
+// @Header 200 {integer} X-Total-Count "Total number of people matching the filter"
+// @Failure 400 {object} Problem "Invalid filter or page parameters"
+// @Failure 500 {object} Problem "Internal Server Error"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /people/filter [get]
 func (h *Handler) peopleGetByFilter(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.peopleGetByFilter"
 	log := h.Logs.With(slog.String("operation", op))
 
-	filter := entities.People{
-		ID:             parseQueryInt(r.URL.Query().Get("id")),
-		PassportSeries: parseQueryInt(r.URL.Query().Get("passport_series")),
-		PassportNumber: parseQueryInt(r.URL.Query().Get("passport_number")),
-		Surname:        chi.URLParam(r, "surname"),
-		Name:           chi.URLParam(r, "name"),
-		Patronymic:     chi.URLParam(r, "patronymic"),
-		Address:        chi.URLParam(r, "address"),
+	filter := entities.PeopleFilter{
+		People: entities.People{
+			ID:             parseQueryInt(r.URL.Query().Get("id")),
+			PassportSeries: parseQueryInt(r.URL.Query().Get("passport_series")),
+			PassportNumber: parseQueryInt(r.URL.Query().Get("passport_number")),
+			Surname:        r.URL.Query().Get("surname"),
+			Name:           r.URL.Query().Get("name"),
+			Patronymic:     r.URL.Query().Get("patronymic"),
+			Address:        r.URL.Query().Get("address"),
+			Role:           entities.Role(r.URL.Query().Get("role")),
+			ManagerID:      parseQueryInt(r.URL.Query().Get("manager_id")),
+		},
+		Match: make(map[string]entities.MatchOp),
+	}
+
+	// Способ сравнения поля задаётся параметром <поле>_match
+	for _, field := range []string{"surname", "name", "patronymic", "address"} {
+		if op := r.URL.Query().Get(field + "_match"); op != "" {
+			filter.Match[field] = entities.MatchOp(op)
+		}
 	}
 
-	limit := parseQueryInt(r.URL.Query().Get("limit"))
-	offset := parseQueryInt(r.URL.Query().Get("offset"))
+	query, err := parsePageQuery(r)
+	if err != nil {
+		log.Error("Invalid page parameters", logger.Err(err))
+		writeError(w, r, err, "Invalid page parameters")
+		return
+	}
 
-	people, err := h.services.People.GetByFilter(r.Context(), filter, limit, offset)
+	people, err := h.services.People.GetByFilter(r.Context(), filter, query)
 	if err != nil {
 		log.Error("Failed to fetch people by filter", logger.Err(err))
-		writeErrorResponse(w, http.StatusUnprocessableEntity, "Failed to fetch people by filter")
+		writeError(w, r, err, "Failed to fetch people by filter")
 		return
 	}
 
-	if err := json.NewEncoder(w).Encode(people); err != nil {
+	if err := writePage(w, people); err != nil {
 		log.Error("Failed to encode response", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to encode response")
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
 	}
 }
 
 // @Summary Update People
-// @Description Update an existing person
+// @Description Update an existing person. Admin can change any fields, other people only their own name and address.
 // @Tags People
 // @Accept json
 // @Produce json
 // @Param person body entities.People true "Person to update"
 // @Success 200 {string} string "OK"
-// @Failure 422 {object} ErrorResponse "Invalid request payload"
-// @Failure 500 {object} ErrorResponse "Failed to update person"
+// @Failure 400 {object} Problem "Invalid person data"
+// @Failure 403 {object} Problem "Forbidden"
+// @Failure 404 {object} Problem "Person not found"
+// @Failure 409 {object} Problem "Passport already exists"
+// @Failure 500 {object} Problem "Failed to update person"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /people [put]
 func (h *Handler) peopleUpdate(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.peopleUpdate"
 	log := h.Logs.With(slog.String("operation", op))
 
 	var people entities.People
-	if err := json.NewDecoder(r.Body).Decode(&people); err != nil {
+	if err := decodeJSON(r, &people); err != nil {
 		log.Error("Failed to decode request body", logger.Err(err))
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		writeError(w, r, err, "Invalid request payload")
 		return
 	}
 
 	if err := h.services.People.Update(r.Context(), people); err != nil {
 		log.Error("Failed to update person", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to update person")
+		writeError(w, r, err, "Failed to update person")
 		return
 	}
 
@@ -195,24 +248,28 @@ func (h *Handler) peopleUpdate(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param peopleID path int true "People ID"
 // @Success 200 {string} string "OK"
-// @Failure 422 {object} ErrorResponse "Invalid people ID
-// @Failure 500 {object} ErrorResponse  "Failed to delete person"
+// @Failure 400 {object} Problem "Invalid people ID"
+// @Failure 403 {object} Problem "Forbidden"
+// @Failure 404 {object} Problem "Person not found"
+// @Failure 409 {object} Problem "Person has approved timesheets"
+// @Failure 500 {object} Problem "Failed to delete person"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /people/{peopleID} [delete]
 func (h *Handler) peopleDelete(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.peopleDelete"
 	log := h.Logs.With(slog.String("operation", op))
 
-	peopleID := chi.URLParam(r, "peopleID")
-	id, err := strconv.Atoi(peopleID)
+	id, err := parsePathID(r, "peopleID")
 	if err != nil {
 		log.Error("Invalid people ID", logger.Err(err))
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid people ID")
+		writeError(w, r, err, "Invalid people ID")
 		return
 	}
 
 	if err := h.services.People.Delete(r.Context(), id); err != nil {
 		log.Error("Failed to delete person", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to delete person")
+		writeError(w, r, err, "Failed to delete person")
 		return
 	}
 
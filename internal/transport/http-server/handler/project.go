@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/pkg/logger"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+// Handler methods for Project
+
+// @Summary Create Project
+// @Description Create a new project. Project name must be unique.
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Param project body entities.Project true "Project to create"
+// @Success 201 {integer} int "ID of the created project"
+// @Failure 400 {object} Problem "Invalid request payload"
+// @Failure 403 {object} Problem "Forbidden"
+// @Failure 409 {object} Problem "Project already exists"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /project [post]
+func (h *Handler) projectCreate(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.projectCreate"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var project entities.Project
+	if err := decodeJSON(r, &project); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	id, err := h.services.Project.Create(r.Context(), project)
+	if err != nil {
+		log.Error("Failed to create project", logger.Err(err))
+		writeError(w, r, err, "Failed to create project")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(id); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary List Projects
+// @Description Get all projects
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Success 200 {array} entities.Project
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /project [get]
+func (h *Handler) projectList(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.projectList"
+	log := h.Logs.With(slog.String("operation", op))
+
+	projects, err := h.services.Project.List(r.Context())
+	if err != nil {
+		log.Error("Failed to list projects", logger.Err(err))
+		writeError(w, r, err, "Failed to list projects")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(projects); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary Get Project by ID
+// @Description Get a project by its ID
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Param projectID path int true "Project ID"
+// @Success 200 {object} entities.Project
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem "Project not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /project/{projectID} [get]
+func (h *Handler) projectGetByID(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.projectGetByID"
+	log := h.Logs.With(slog.String("operation", op))
+
+	id, err := parsePathID(r, "projectID")
+	if err != nil {
+		log.Error("Invalid project ID", logger.Err(err))
+		writeError(w, r, err, "Invalid project ID")
+		return
+	}
+
+	project, err := h.services.Project.GetByID(r.Context(), id)
+	if err != nil {
+		log.Error("Failed to get project by ID", logger.Err(err))
+		writeError(w, r, err, "Failed to get project by ID")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(project); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary Update Project
+// @Description Update an existing project
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Param project body entities.Project true "Project to update"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem
+// @Failure 403 {object} Problem "Forbidden"
+// @Failure 404 {object} Problem "Project not found"
+// @Failure 409 {object} Problem "Project already exists"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /project [put]
+func (h *Handler) projectUpdate(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.projectUpdate"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var project entities.Project
+	if err := decodeJSON(r, &project); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	if err := h.services.Project.Update(r.Context(), project); err != nil {
+		log.Error("Failed to update project", logger.Err(err))
+		writeError(w, r, err, "Failed to update project")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+// @Summary Delete Project
+// @Description Delete a project by its ID. Tasks of the project are kept without a project.
+// @Tags Project
+// @Accept json
+// @Produce json
+// @Param projectID path int true "Project ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem
+// @Failure 403 {object} Problem "Forbidden"
+// @Failure 404 {object} Problem "Project not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /project/{projectID} [delete]
+func (h *Handler) projectDelete(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.projectDelete"
+	log := h.Logs.With(slog.String("operation", op))
+
+	id, err := parsePathID(r, "projectID")
+	if err != nil {
+		log.Error("Invalid project ID", logger.Err(err))
+		writeError(w, r, err, "Invalid project ID")
+		return
+	}
+
+	if err := h.services.Project.Delete(r.Context(), id); err != nil {
+		log.Error("Failed to delete project", logger.Err(err))
+		writeError(w, r, err, "Failed to delete project")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
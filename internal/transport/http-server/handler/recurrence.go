@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/pkg/logger"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+// Handler methods for Recurrence
+
+// @Summary Create Recurrence
+// @Description Create a recurring task: a task template with an RRULE (RFC 5545 subset: FREQ=DAILY, WEEKLY or MONTHLY with INTERVAL, BYDAY, UNTIL or COUNT), e.g. FREQ=WEEKLY;BYDAY=MO.
+// @Description Occurrences take the time of day of start. The scheduler creates a task with the listed assignees for each occurrence; after downtime only the latest missed occurrence is created.
+// @Description Only admins and managers can create recurring tasks, managers only for their team.
+// @Tags Recurrence
+// @Accept json
+// @Produce json
+// @Param recurrence body entities.Recurrence true "Recurring task to create"
+// @Success 201 {integer} int "ID of the created recurring task"
+// @Failure 400 {object} Problem "Invalid request payload, rule or unknown project or assignee"
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /recurrence [post]
+func (h *Handler) recurrenceCreate(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.recurrenceCreate"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var rec entities.Recurrence
+	if err := decodeJSON(r, &rec); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	id, err := h.services.Recurrence.Create(r.Context(), rec)
+	if err != nil {
+		log.Error("Failed to create recurrence", logger.Err(err))
+		writeError(w, r, err, "Failed to create recurrence")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(id); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary List Recurrences
+// @Description Get all recurring tasks with their next and last occurrences
+// @Tags Recurrence
+// @Accept json
+// @Produce json
+// @Success 200 {array} entities.Recurrence
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /recurrence [get]
+func (h *Handler) recurrenceList(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.recurrenceList"
+	log := h.Logs.With(slog.String("operation", op))
+
+	recs, err := h.services.Recurrence.List(r.Context())
+	if err != nil {
+		log.Error("Failed to list recurrences", logger.Err(err))
+		writeError(w, r, err, "Failed to list recurrences")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(recs); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary Get Recurrence by ID
+// @Description Get a recurring task by its ID
+// @Tags Recurrence
+// @Accept json
+// @Produce json
+// @Param recurrenceID path int true "Recurrence ID"
+// @Success 200 {object} entities.Recurrence
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem "Recurrence not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /recurrence/{recurrenceID} [get]
+func (h *Handler) recurrenceGetByID(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.recurrenceGetByID"
+	log := h.Logs.With(slog.String("operation", op))
+
+	id, err := parsePathID(r, "recurrenceID")
+	if err != nil {
+		log.Error("Invalid recurrence ID", logger.Err(err))
+		writeError(w, r, err, "Invalid recurrence ID")
+		return
+	}
+
+	rec, err := h.services.Recurrence.GetByID(r.Context(), id)
+	if err != nil {
+		log.Error("Failed to get recurrence by ID", logger.Err(err))
+		writeError(w, r, err, "Failed to get recurrence by ID")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(rec); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary Delete Recurrence
+// @Description Delete a recurring task by its ID. Tasks already created from it are kept.
+// @Tags Recurrence
+// @Accept json
+// @Produce json
+// @Param recurrenceID path int true "Recurrence ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem "Recurrence not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /recurrence/{recurrenceID} [delete]
+func (h *Handler) recurrenceDelete(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.recurrenceDelete"
+	log := h.Logs.With(slog.String("operation", op))
+
+	id, err := parsePathID(r, "recurrenceID")
+	if err != nil {
+		log.Error("Invalid recurrence ID", logger.Err(err))
+		writeError(w, r, err, "Invalid recurrence ID")
+		return
+	}
+
+	if err := h.services.Recurrence.Delete(r.Context(), id); err != nil {
+		log.Error("Failed to delete recurrence", logger.Err(err))
+		writeError(w, r, err, "Failed to delete recurrence")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
@@ -1,15 +1,113 @@
 package handler
 
 import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/entities"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
 )
 
-type ErrorResponse struct {
-	Message string `json:"message"`
+// problemContentType тип содержимого ответа об ошибке по RFC 7807.
+const problemContentType = "application/problem+json"
+
+// totalCountHeader заголовок ответа со страницей списка, общее число записей по фильтру.
+const totalCountHeader = "X-Total-Count"
+
+// Problem ответ об ошибке в формате RFC 7807.
+// Type определяет категорию ошибки, Title - её краткое описание, Detail - подробности конкретного случая,
+// Instance - путь запроса, Errors - ошибки отдельных полей запроса.
+type Problem struct {
+	Type      string              `json:"type"`
+	Title     string              `json:"title"`
+	Status    int                 `json:"status"`
+	Detail    string              `json:"detail,omitempty"`
+	Instance  string              `json:"instance,omitempty"`
+	RequestID string              `json:"request_id,omitempty"`
+	Errors    []domain.FieldError `json:"errors,omitempty"`
+}
+
+// writePage отвечает страницей списка, общее число записей передаётся в заголовке X-Total-Count.
+func writePage[T any](w http.ResponseWriter, page entities.Page[T]) error {
+	w.Header().Set(totalCountHeader, strconv.Itoa(page.Total))
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(page)
+}
+
+// writeErrorResponse отвечает ошибкой с кодом statusCode и подробностями message.
+func writeErrorResponse(w http.ResponseWriter, r *http.Request, statusCode int, message string) {
+	writeProblem(w, r, Problem{Status: statusCode, Detail: message})
+}
+
+// writeError отвечает кодом, соответствующим категории ошибки из пакета domain.
+// Для ошибок без категории клиент получает только message, подробности остаются в логе.
+func writeError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	problem := Problem{Status: errorStatus(err), Detail: message}
+	if problem.Status != http.StatusInternalServerError {
+		problem.Detail = errorDetail(err)
+	}
+
+	var validation *domain.ValidationError
+	if errors.As(err, &validation) {
+		problem.Errors = validation.Fields
+	}
+
+	writeProblem(w, r, problem)
+}
+
+// writeProblem дополняет ответ типом, заголовком, путём и ID запроса и записывает его.
+func writeProblem(w http.ResponseWriter, r *http.Request, problem Problem) {
+	problem.Type = problemType(problem.Status)
+	problem.Title = http.StatusText(problem.Status)
+	problem.Instance = r.URL.Path
+	problem.RequestID = middleware.GetReqID(r.Context())
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
+
+// errorStatus возвращает HTTP код для категории ошибки.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, domain.ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrUnauthorized):
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
+
+// problemType возвращает идентификатор типа ошибки для кода ответа.
+// Для кодов без отдельного типа используется about:blank, как рекомендует RFC 7807.
+func problemType(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "/problems/validation"
+	case http.StatusUnauthorized:
+		return "/problems/unauthorized"
+	case http.StatusForbidden:
+		return "/problems/forbidden"
+	case http.StatusNotFound:
+		return "/problems/not-found"
+	case http.StatusConflict:
+		return "/problems/conflict"
+	}
+	return "about:blank"
 }
 
-func writeErrorResponse(w http.ResponseWriter, statusCode int, message string) {
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(ErrorResponse{Message: message})
+// errorDetail возвращает текст ошибки без имени операции хранилища.
+func errorDetail(err error) string {
+	detail, _, _ := strings.Cut(err.Error(), ", operation: ")
+	return detail
 }
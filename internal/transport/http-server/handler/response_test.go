@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/internal/service"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"forbidden", service.ErrForbidden, http.StatusForbidden},
+		{"wrapped forbidden", fmt.Errorf("%w: cannot act for people ID 2", service.ErrForbidden), http.StatusForbidden},
+		{"unauthorized", service.ErrUnauthorized, http.StatusUnauthorized},
+		{"not found", domain.ErrNoRecordsFound, http.StatusNotFound},
+		{"conflict", service.ErrPeriodLocked, http.StatusConflict},
+		{"validation", domain.ErrInputData, http.StatusBadRequest},
+		{"field error", domain.NewFieldError("title", "is required"), http.StatusBadRequest},
+		{"uncategorized", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorStatus(tt.err); got != tt.want {
+				t.Fatalf("errorStatus(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteErrorProblem(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantType   string
+		wantDetail string
+		wantErrors []domain.FieldError
+	}{
+		{
+			name: "validation error",
+			err: fmt.Errorf("people: %w", &domain.ValidationError{Fields: []domain.FieldError{
+				{Field: "surname", Message: "is required"},
+				{Field: "address", Message: "is required"},
+			}}),
+			wantStatus: http.StatusBadRequest,
+			wantType:   "/problems/validation",
+			wantDetail: "people: surname is required; address is required",
+			wantErrors: []domain.FieldError{
+				{Field: "surname", Message: "is required"},
+				{Field: "address", Message: "is required"},
+			},
+		},
+		{
+			name:       "input data without fields",
+			err:        domain.ErrInputData,
+			wantStatus: http.StatusBadRequest,
+			wantType:   "/problems/validation",
+			wantDetail: "incorrect input data",
+		},
+		{
+			name:       "forbidden",
+			err:        fmt.Errorf("%w: cannot act for people ID 2", service.ErrForbidden),
+			wantStatus: http.StatusForbidden,
+			wantType:   "/problems/forbidden",
+		},
+		{
+			name:       "uncategorized",
+			err:        errors.New("connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantDetail: "Failed to create people",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/people", nil)
+
+			writeError(w, r, tt.err, "Failed to create people")
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Content-Type"); got != problemContentType {
+				t.Fatalf("Content-Type = %q, want %q", got, problemContentType)
+			}
+
+			var problem Problem
+			if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+				t.Fatalf("decode problem: %v", err)
+			}
+			if problem.Status != tt.wantStatus || problem.Instance != "/people" {
+				t.Fatalf("problem = %+v", problem)
+			}
+			if tt.wantType != "" && problem.Type != tt.wantType {
+				t.Fatalf("problem type = %q, want %q", problem.Type, tt.wantType)
+			}
+			if tt.wantDetail != "" && problem.Detail != tt.wantDetail {
+				t.Fatalf("problem detail = %q, want %q", problem.Detail, tt.wantDetail)
+			}
+			if len(problem.Errors) != len(tt.wantErrors) {
+				t.Fatalf("problem errors = %+v, want %+v", problem.Errors, tt.wantErrors)
+			}
+			for i := range tt.wantErrors {
+				if problem.Errors[i] != tt.wantErrors[i] {
+					t.Fatalf("problem errors = %+v, want %+v", problem.Errors, tt.wantErrors)
+				}
+			}
+		})
+	}
+}
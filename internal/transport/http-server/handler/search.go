@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"TaskSync/internal/domain"
+	"TaskSync/pkg/logger"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+// Handler methods for Search
+
+// @Summary Search
+// @Description Full-text search over people names and task titles and descriptions.
+// @Description Every word of the query must match the beginning of a word, case is ignored.
+// @Description Results are ordered by relevance, matches in task title rank higher than in description.
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Param q query string true "Search query"
+// @Param limit query int false "Maximum number of results, 20 by default, at most 100"
+// @Success 200 {array} entities.SearchResult
+// @Failure 400 {object} Problem "Invalid search parameters"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /search [get]
+func (h *Handler) search(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.search"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var limit int
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil {
+			err = domain.NewFieldError("limit", "must be an integer")
+			log.Error("Invalid search parameters", logger.Err(err))
+			writeError(w, r, err, "Invalid search parameters")
+			return
+		}
+	}
+
+	results, err := h.services.Search.Search(r.Context(), r.URL.Query().Get("q"), limit)
+	if err != nil {
+		log.Error("Failed to search", logger.Err(err))
+		writeError(w, r, err, "Failed to search")
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/pkg/logger"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+// Handler methods for Tag
+
+// @Summary Create Tag
+// @Description Create a new task tag, e.g. bugfix or feature. Tag name must be unique.
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Param tag body entities.Tag true "Tag to create"
+// @Success 201 {integer} int "ID of the created tag"
+// @Failure 400 {object} Problem "Invalid request payload"
+// @Failure 409 {object} Problem "Tag already exists"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /tag [post]
+func (h *Handler) tagCreate(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.tagCreate"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var tag entities.Tag
+	if err := decodeJSON(r, &tag); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	id, err := h.services.Tag.Create(r.Context(), tag)
+	if err != nil {
+		log.Error("Failed to create tag", logger.Err(err))
+		writeError(w, r, err, "Failed to create tag")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(id); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary List Tags
+// @Description Get all tags ordered by name
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Success 200 {array} entities.Tag
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /tag [get]
+func (h *Handler) tagList(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.tagList"
+	log := h.Logs.With(slog.String("operation", op))
+
+	tags, err := h.services.Tag.List(r.Context())
+	if err != nil {
+		log.Error("Failed to list tags", logger.Err(err))
+		writeError(w, r, err, "Failed to list tags")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(tags); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary Get Tag by ID
+// @Description Get a tag by its ID
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Param tagID path int true "Tag ID"
+// @Success 200 {object} entities.Tag
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem "Tag not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /tag/{tagID} [get]
+func (h *Handler) tagGetByID(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.tagGetByID"
+	log := h.Logs.With(slog.String("operation", op))
+
+	id, err := parsePathID(r, "tagID")
+	if err != nil {
+		log.Error("Invalid tag ID", logger.Err(err))
+		writeError(w, r, err, "Invalid tag ID")
+		return
+	}
+
+	tag, err := h.services.Tag.GetByID(r.Context(), id)
+	if err != nil {
+		log.Error("Failed to get tag by ID", logger.Err(err))
+		writeError(w, r, err, "Failed to get tag by ID")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(tag); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary Update Tag
+// @Description Rename an existing tag, the new name applies to all tasks with this tag
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Param tag body entities.Tag true "Tag to update"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem "Tag not found"
+// @Failure 409 {object} Problem "Tag already exists"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /tag [put]
+func (h *Handler) tagUpdate(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.tagUpdate"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var tag entities.Tag
+	if err := decodeJSON(r, &tag); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	if err := h.services.Tag.Update(r.Context(), tag); err != nil {
+		log.Error("Failed to update tag", logger.Err(err))
+		writeError(w, r, err, "Failed to update tag")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+// @Summary Delete Tag
+// @Description Delete a tag by its ID. The tag is removed from all tasks.
+// @Tags Tag
+// @Accept json
+// @Produce json
+// @Param tagID path int true "Tag ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem "Tag not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /tag/{tagID} [delete]
+func (h *Handler) tagDelete(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.tagDelete"
+	log := h.Logs.With(slog.String("operation", op))
+
+	id, err := parsePathID(r, "tagID")
+	if err != nil {
+		log.Error("Invalid tag ID", logger.Err(err))
+		writeError(w, r, err, "Invalid tag ID")
+		return
+	}
+
+	if err := h.services.Tag.Delete(r.Context(), id); err != nil {
+		log.Error("Failed to delete tag", logger.Err(err))
+		writeError(w, r, err, "Failed to delete tag")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
@@ -6,74 +6,86 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
-	"strconv"
-
-	"github.com/go-chi/chi/v5"
+	"time"
 )
 
 // Handler methods for Task
 
 // @Summary Create Task
-// @Description Create a new task. FORMAT TIME - RFC 3339 "2024-08-01T08:00:00Z".
+// @Description Create a new task in the initial status of the workflow. The optional estimate is a duration such as "4h30m". FORMAT TIME - RFC 3339 "2024-08-01T08:00:00Z".
 // @Tags Task
 // @Accept json
 // @Produce json
 // @Param task body entities.Task true "Task to create"
 // @Success 200 {integer} int "Task ID"
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} Problem "Unknown project, parent task or people, or invalid estimate"
+// @Failure 403 {object} Problem "Time entry of another person"
+// @Failure 409 {object} Problem "The time entry overlaps existing entries or is in an approved week"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /task [post]
 func (h *Handler) taskCreate(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.taskCreate"
 	log := h.Logs.With(slog.String("operation", op))
 
 	var task entities.Task
-	if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
+	if err := decodeJSON(r, &task); err != nil {
 		log.Error("Failed to decode request body", logger.Err(err))
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		writeError(w, r, err, "Invalid request payload")
 		return
 	}
 
 	id, err := h.services.Task.Create(r.Context(), task)
 	if err != nil {
 		log.Error("Failed to create task", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to create task")
+		writeError(w, r, err, "Failed to create task")
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(id); err != nil {
 		log.Error("Failed to encode response", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to encode response")
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
 	}
 }
 
 // @Summary Get Task by ID
-// @Description Get a task by its ID
+// @Description Get a task by its ID. With subtree=true the task includes all its subtasks and a rollup of time spent in completed sessions, own and with all descendants.
 // @Tags Task
 // @Accept json
 // @Produce json
 // @Param taskID path int true "Task ID"
+// @Param subtree query bool false "Include subtasks and rolled-up time"
 // @Success 200 {object} entities.Task
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /task/{taskID} [get]
 func (h *Handler) taskGetByID(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.taskGetByID"
 	log := h.Logs.With(slog.String("operation", op))
 
-	taskID := chi.URLParam(r, "taskID")
-	id, err := strconv.Atoi(taskID)
+	id, err := parsePathID(r, "taskID")
 	if err != nil {
 		log.Error("Invalid task ID", logger.Err(err))
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid task ID")
+		writeError(w, r, err, "Invalid task ID")
+		return
+	}
+
+	subtree, err := parseQueryBool(r, "subtree")
+	if err != nil {
+		log.Error("Invalid subtree parameter", logger.Err(err))
+		writeError(w, r, err, "Invalid subtree parameter")
 		return
 	}
 
-	task, err := h.services.Task.GetByID(r.Context(), id)
+	task, err := h.services.Task.GetByID(r.Context(), id, subtree)
 	if err != nil {
 		log.Error("Failed to get task by ID", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to get task by ID")
+		writeError(w, r, err, "Failed to get task by ID")
 		return
 	}
 
@@ -81,29 +93,105 @@ func (h *Handler) taskGetByID(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(task)
 }
 
+// taskPage страница задач в ответе, описывает entities.Page[entities.Task] для swagger.
+type taskPage struct {
+	Items      []entities.Task `json:"items"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
 // @Summary List Tasks
-// @Description Get list of all tasks
+// @Description Get a page of tasks, optionally filtered by project, tag and parent task. Sort fields: id, title, status, project_id.
 // @Tags Task
 // @Accept json
 // @Produce json
-// @Success 200 {array} entities.Task
-// @Failure 500 {object} ErrorResponse
+// @Param project_id query int false "Project ID"
+// @Param tag query string false "Tag name, lists only tasks with this tag"
+// @Param parent_id query int false "Parent task ID, lists only its direct subtasks"
+// @Param limit query int false "Page size, 50 by default, at most 500"
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param sort query string false "Comma separated sort fields, a leading minus sorts descending, e.g. surname,-id"
+// @Success 200 {object} taskPage
+// @Header 200 {integer} X-Total-Count "Total number of tasks matching the filter"
+// @Failure 400 {object} Problem "Invalid page parameters"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /task [get]
 func (h *Handler) taskList(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.taskList"
 	log := h.Logs.With(slog.String("operation", op))
 
-	tasks, err := h.services.Task.List(r.Context())
+	filter := entities.TaskFilter{
+		ProjectID: parseQueryInt(r.URL.Query().Get("project_id")),
+		Tag:       r.URL.Query().Get("tag"),
+		ParentID:  parseQueryInt(r.URL.Query().Get("parent_id")),
+	}
+
+	query, err := parsePageQuery(r)
+	if err != nil {
+		log.Error("Invalid page parameters", logger.Err(err))
+		writeError(w, r, err, "Invalid page parameters")
+		return
+	}
+
+	tasks, err := h.services.Task.List(r.Context(), filter, query)
 	if err != nil {
 		log.Error("Failed to list tasks", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to list tasks")
+		writeError(w, r, err, "Failed to list tasks")
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	if err := json.NewEncoder(w).Encode(tasks); err != nil {
+	if err := writePage(w, tasks); err != nil {
 		log.Error("Failed to encode response", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to encode response")
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary List Subtasks
+// @Description Get a page of direct subtasks of a task. Sort fields: id, title, status, project_id.
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param taskID path int true "Task ID"
+// @Param limit query int false "Page size, 50 by default, at most 500"
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param sort query string false "Comma separated sort fields, a leading minus sorts descending, e.g. title,-id"
+// @Success 200 {object} taskPage
+// @Header 200 {integer} X-Total-Count "Total number of direct subtasks"
+// @Failure 400 {object} Problem "Invalid task ID or page parameters"
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /task/{taskID}/children [get]
+func (h *Handler) taskChildren(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.taskChildren"
+	log := h.Logs.With(slog.String("operation", op))
+
+	id, err := parsePathID(r, "taskID")
+	if err != nil {
+		log.Error("Invalid task ID", logger.Err(err))
+		writeError(w, r, err, "Invalid task ID")
+		return
+	}
+
+	query, err := parsePageQuery(r)
+	if err != nil {
+		log.Error("Invalid page parameters", logger.Err(err))
+		writeError(w, r, err, "Invalid page parameters")
+		return
+	}
+
+	tasks, err := h.services.Task.Children(r.Context(), id, query)
+	if err != nil {
+		log.Error("Failed to list subtasks", logger.Err(err))
+		writeError(w, r, err, "Failed to list subtasks")
+		return
+	}
+
+	if err := writePage(w, tasks); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
 	}
 }
 
@@ -120,30 +208,34 @@ type taskUpdate struct {
 // @Produce json
 // @Param task body taskUpdate true "Task to update"
 // @Success 200 {string} string "OK"
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} Problem
+// @Failure 403 {object} Problem "Not an assignee of the task"
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /task [put]
 func (h *Handler) taskUpdate(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.taskUpdate"
 	log := h.Logs.With(slog.String("operation", op))
 
 	var task taskUpdate
-	if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
+	if err := decodeJSON(r, &task); err != nil {
 		log.Error("Failed to decode request body", logger.Err(err))
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		writeError(w, r, err, "Invalid request payload")
 		return
 	}
 
 	if err := h.services.Task.Update(r.Context(), task.TaskID, task.Title, task.Description); err != nil {
 		log.Error("Failed to update task", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to update task")
+		writeError(w, r, err, "Failed to update task")
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 	if _, err := w.Write([]byte("OK")); err != nil {
 		log.Error("Failed to write response", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to write response")
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
 	}
 }
 
@@ -153,14 +245,20 @@ type PeopleAndTask struct {
 }
 
 // @Summary Update People in Task
-// @Description Update people associated with a task
+// @Description Make the person the only current assignee of a task. Other assignments are closed and kept in the history.
+// @Description Time entries the previous assignees already worked keep their person, only entries not yet started move to the new assignee.
 // @Tags Task
 // @Accept json
 // @Produce json
 // @Param task body PeopleAndTask true "People and task to update"
 // @Success 200 {string} string "OK"
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} Problem
+// @Failure 403 {object} Problem "Forbidden"
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 409 {object} Problem "The person already has a running timer or overlapping entries"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /task/update-people [put]
 func (h *Handler) taskUpdatePeople(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.taskUpdatePeople"
@@ -168,22 +266,220 @@ func (h *Handler) taskUpdatePeople(w http.ResponseWriter, r *http.Request) {
 
 	var values PeopleAndTask
 
-	if err := json.NewDecoder(r.Body).Decode(&values); err != nil {
+	if err := decodeJSON(r, &values); err != nil {
 		log.Error("Failed to decode request body", logger.Err(err))
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		writeError(w, r, err, "Invalid request payload")
 		return
 	}
 
 	if err := h.services.Task.UpdatePeople(r.Context(), values.PeopleID, values.TaskID); err != nil {
 		log.Error("Failed to update people in task", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to update people in task")
+		writeError(w, r, err, "Failed to update people in task")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+type ProjectAndTask struct {
+	ProjectID int `json:"project_id"`
+	TaskID    int `json:"task_id"`
+}
+
+// @Summary Update Project in Task
+// @Description Move a task to a project. Project ID 0 removes the task from its project.
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param task body ProjectAndTask true "Project and task to update"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem "Unknown project"
+// @Failure 403 {object} Problem "Not an assignee of the task"
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /task/update-project [put]
+func (h *Handler) taskUpdateProject(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.taskUpdateProject"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var values ProjectAndTask
+
+	if err := decodeJSON(r, &values); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	if err := h.services.Task.UpdateProject(r.Context(), values.ProjectID, values.TaskID); err != nil {
+		log.Error("Failed to update project in task", logger.Err(err))
+		writeError(w, r, err, "Failed to update project in task")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+type taskParent struct {
+	ParentID int `json:"parent_id"`
+}
+
+// @Summary Move Task
+// @Description Move a task under another task. Parent ID 0 makes the task a top-level task. Moving a task under itself or any of its subtasks is rejected.
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param taskID path int true "Task ID"
+// @Param parent body taskParent true "New parent task"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem "Invalid task ID or unknown parent task"
+// @Failure 403 {object} Problem "Not an assignee of the task"
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 409 {object} Problem "The move would create a cycle"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /task/{taskID}/parent [put]
+func (h *Handler) taskUpdateParent(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.taskUpdateParent"
+	log := h.Logs.With(slog.String("operation", op))
+
+	id, err := parsePathID(r, "taskID")
+	if err != nil {
+		log.Error("Invalid task ID", logger.Err(err))
+		writeError(w, r, err, "Invalid task ID")
+		return
+	}
+
+	var parent taskParent
+	if err := decodeJSON(r, &parent); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	if err := h.services.Task.UpdateParent(r.Context(), parent.ParentID, id); err != nil {
+		log.Error("Failed to move task", logger.Err(err))
+		writeError(w, r, err, "Failed to move task")
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 	if _, err := w.Write([]byte("OK")); err != nil {
 		log.Error("Failed to write response", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to write response")
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+type taskSchedule struct {
+	DueDate  time.Time `json:"due_date"`
+	Estimate string    `json:"estimate"`
+}
+
+// @Summary Update Task Schedule
+// @Description Set the due date and the estimate of a task. The estimate is a duration such as "4h30m". A missing due date or an empty estimate removes it. FORMAT TIME - RFC 3339 "2024-08-01T08:00:00Z".
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param taskID path int true "Task ID"
+// @Param schedule body taskSchedule true "Due date and estimate"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem "Invalid task ID or estimate"
+// @Failure 403 {object} Problem "Not an assignee of the task"
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /task/{taskID}/schedule [put]
+func (h *Handler) taskUpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.taskUpdateSchedule"
+	log := h.Logs.With(slog.String("operation", op))
+
+	id, err := parsePathID(r, "taskID")
+	if err != nil {
+		log.Error("Invalid task ID", logger.Err(err))
+		writeError(w, r, err, "Invalid task ID")
+		return
+	}
+
+	var schedule taskSchedule
+	if err := decodeJSON(r, &schedule); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	if err := h.services.Task.UpdateSchedule(r.Context(), id, schedule.DueDate, schedule.Estimate); err != nil {
+		log.Error("Failed to update task schedule", logger.Err(err))
+		writeError(w, r, err, "Failed to update task schedule")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+type taskTransition struct {
+	Status   entities.TaskStatus `json:"status"`
+	PeopleID int                 `json:"people_id"`
+}
+
+// @Summary Transition Task
+// @Description Move a task to another status. Moving to in_progress starts a timer for people_id (the authenticated person by default), moving to done closes all open time entries of the task.
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param taskID path int true "Task ID"
+// @Param transition body taskTransition true "Target status"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem "Invalid task ID or unknown status"
+// @Failure 403 {object} Problem "Not an assignee of the task, or open time entries of people the caller cannot act for"
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 409 {object} Problem "Transition is not allowed, the task is blocked by unfinished tasks or its open time entries are in an approved week"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /task/{taskID}/transition [post]
+func (h *Handler) taskTransition(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.taskTransition"
+	log := h.Logs.With(slog.String("operation", op))
+
+	id, err := parsePathID(r, "taskID")
+	if err != nil {
+		log.Error("Invalid task ID", logger.Err(err))
+		writeError(w, r, err, "Invalid task ID")
+		return
+	}
+
+	var transition taskTransition
+	if err := decodeJSON(r, &transition); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	if err := h.services.Task.Transition(r.Context(), id, transition.Status, transition.PeopleID); err != nil {
+		log.Error("Failed to transition task", logger.Err(err))
+		writeError(w, r, err, "Failed to transition task")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
 	}
 }
 
@@ -194,30 +490,387 @@ func (h *Handler) taskUpdatePeople(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param taskID path int true "Task ID"
 // @Success 200 {string} string "OK"
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} Problem
+// @Failure 403 {object} Problem "Only admins and managers can delete tasks"
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 409 {object} Problem "Time entries of the task are in an approved week"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /task/{taskID} [delete]
 func (h *Handler) taskDelete(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.taskDelete"
 	log := h.Logs.With(slog.String("operation", op))
 
-	taskID := chi.URLParam(r, "taskID")
-	id, err := strconv.Atoi(taskID)
+	id, err := parsePathID(r, "taskID")
 	if err != nil {
 		log.Error("Invalid task ID", logger.Err(err))
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid task ID")
+		writeError(w, r, err, "Invalid task ID")
 		return
 	}
 
 	if err := h.services.Task.Delete(r.Context(), id); err != nil {
 		log.Error("Failed to delete task", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to delete task")
+		writeError(w, r, err, "Failed to delete task")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+// @Summary Attach Tag
+// @Description Attach a tag to a task. Attaching a tag the task already has is not an error.
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param taskID path int true "Task ID"
+// @Param tagID path int true "Tag ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem "Invalid task or tag ID"
+// @Failure 404 {object} Problem "Task or tag not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /task/{taskID}/tags/{tagID} [post]
+func (h *Handler) taskAttachTag(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.taskAttachTag"
+	log := h.Logs.With(slog.String("operation", op))
+
+	taskID, tagID, err := parseTaskTagIDs(r)
+	if err != nil {
+		log.Error("Invalid task or tag ID", logger.Err(err))
+		writeError(w, r, err, "Invalid task or tag ID")
+		return
+	}
+
+	if err := h.services.Tag.Attach(r.Context(), taskID, tagID); err != nil {
+		log.Error("Failed to attach tag", logger.Err(err))
+		writeError(w, r, err, "Failed to attach tag")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+// @Summary Detach Tag
+// @Description Remove a tag from a task
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param taskID path int true "Task ID"
+// @Param tagID path int true "Tag ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem "Invalid task or tag ID"
+// @Failure 404 {object} Problem "Task has no such tag"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /task/{taskID}/tags/{tagID} [delete]
+func (h *Handler) taskDetachTag(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.taskDetachTag"
+	log := h.Logs.With(slog.String("operation", op))
+
+	taskID, tagID, err := parseTaskTagIDs(r)
+	if err != nil {
+		log.Error("Invalid task or tag ID", logger.Err(err))
+		writeError(w, r, err, "Invalid task or tag ID")
+		return
+	}
+
+	if err := h.services.Tag.Detach(r.Context(), taskID, tagID); err != nil {
+		log.Error("Failed to detach tag", logger.Err(err))
+		writeError(w, r, err, "Failed to detach tag")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+// parseTaskTagIDs читает ID задачи и метки из пути /task/{taskID}/tags/{tagID}.
+func parseTaskTagIDs(r *http.Request) (int, int, error) {
+	taskID, err := parsePathID(r, "taskID")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	tagID, err := parsePathID(r, "tagID")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return taskID, tagID, nil
+}
+
+// @Summary Add Dependency
+// @Description Declare that a task blocks another task. A blocked task cannot be started until all its blockers are done or cancelled. Adding an existing dependency is not an error, a dependency that would create a cycle is rejected.
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param taskID path int true "Blocking task ID"
+// @Param blockedID path int true "Blocked task ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem "Invalid task IDs or a task blocking itself"
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 409 {object} Problem "The dependency would create a cycle"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /task/{taskID}/blocks/{blockedID} [post]
+func (h *Handler) taskAddDependency(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.taskAddDependency"
+	log := h.Logs.With(slog.String("operation", op))
+
+	blockerID, blockedID, err := parseDependencyIDs(r)
+	if err != nil {
+		log.Error("Invalid task ID", logger.Err(err))
+		writeError(w, r, err, "Invalid task ID")
+		return
+	}
+
+	if err := h.services.Dependency.Add(r.Context(), blockerID, blockedID); err != nil {
+		log.Error("Failed to add dependency", logger.Err(err))
+		writeError(w, r, err, "Failed to add dependency")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+// @Summary Remove Dependency
+// @Description Remove a dependency between tasks
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param taskID path int true "Blocking task ID"
+// @Param blockedID path int true "Blocked task ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem "Invalid task IDs"
+// @Failure 404 {object} Problem "The task does not block the other task"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /task/{taskID}/blocks/{blockedID} [delete]
+func (h *Handler) taskRemoveDependency(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.taskRemoveDependency"
+	log := h.Logs.With(slog.String("operation", op))
+
+	blockerID, blockedID, err := parseDependencyIDs(r)
+	if err != nil {
+		log.Error("Invalid task ID", logger.Err(err))
+		writeError(w, r, err, "Invalid task ID")
+		return
+	}
+
+	if err := h.services.Dependency.Remove(r.Context(), blockerID, blockedID); err != nil {
+		log.Error("Failed to remove dependency", logger.Err(err))
+		writeError(w, r, err, "Failed to remove dependency")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+// parseDependencyIDs читает ID блокирующей и заблокированной задачи из пути /task/{taskID}/blocks/{blockedID}.
+func parseDependencyIDs(r *http.Request) (int, int, error) {
+	blockerID, err := parsePathID(r, "taskID")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	blockedID, err := parsePathID(r, "blockedID")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return blockerID, blockedID, nil
+}
+
+// @Summary List Task Assignees
+// @Description Get the assignment history of a task: current assignees have no unassigned_at
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param taskID path int true "Task ID"
+// @Success 200 {array} entities.TaskAssignment
+// @Failure 400 {object} Problem "Invalid task ID"
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /task/{taskID}/assignees [get]
+func (h *Handler) taskAssignees(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.taskAssignees"
+	log := h.Logs.With(slog.String("operation", op))
+
+	id, err := parsePathID(r, "taskID")
+	if err != nil {
+		log.Error("Invalid task ID", logger.Err(err))
+		writeError(w, r, err, "Invalid task ID")
+		return
+	}
+
+	history, err := h.services.Assignee.History(r.Context(), id)
+	if err != nil {
+		log.Error("Failed to get assignees", logger.Err(err))
+		writeError(w, r, err, "Failed to get assignees")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(history); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary Assign People to Task
+// @Description Add a person to the current assignees of a task. Assigning a current assignee again changes nothing.
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param taskID path int true "Task ID"
+// @Param peopleID path int true "People ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem "Invalid task or people ID"
+// @Failure 403 {object} Problem "Forbidden"
+// @Failure 404 {object} Problem "Task or people not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /task/{taskID}/assignees/{peopleID} [post]
+func (h *Handler) taskAssign(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.taskAssign"
+	log := h.Logs.With(slog.String("operation", op))
+
+	taskID, peopleID, err := parseAssigneeIDs(r)
+	if err != nil {
+		log.Error("Invalid ID", logger.Err(err))
+		writeError(w, r, err, "Invalid ID")
+		return
+	}
+
+	if err := h.services.Assignee.Assign(r.Context(), taskID, peopleID); err != nil {
+		log.Error("Failed to assign people", logger.Err(err))
+		writeError(w, r, err, "Failed to assign people")
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 	if _, err := w.Write([]byte("OK")); err != nil {
 		log.Error("Failed to write response", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to write response")
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
 	}
 }
+
+// @Summary Unassign People from Task
+// @Description Remove a person from the current assignees of a task. The assignment stays in the history, worked time is kept.
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param taskID path int true "Task ID"
+// @Param peopleID path int true "People ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem "Invalid task or people ID"
+// @Failure 403 {object} Problem "Forbidden"
+// @Failure 404 {object} Problem "The person is not assigned to the task"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /task/{taskID}/assignees/{peopleID} [delete]
+func (h *Handler) taskUnassign(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.taskUnassign"
+	log := h.Logs.With(slog.String("operation", op))
+
+	taskID, peopleID, err := parseAssigneeIDs(r)
+	if err != nil {
+		log.Error("Invalid ID", logger.Err(err))
+		writeError(w, r, err, "Invalid ID")
+		return
+	}
+
+	if err := h.services.Assignee.Unassign(r.Context(), taskID, peopleID); err != nil {
+		log.Error("Failed to unassign people", logger.Err(err))
+		writeError(w, r, err, "Failed to unassign people")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+// @Summary Task Time by Assignee
+// @Description Get completed time spent on a task per person: current assignees and everyone who worked on it, most time first.
+// @Description Non-admin callers only see people they may act for.
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param taskID path int true "Task ID"
+// @Success 200 {array} entities.AssigneeTimeSpent
+// @Failure 400 {object} Problem "Invalid task ID"
+// @Failure 404 {object} Problem "Task not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /task/{taskID}/time [get]
+func (h *Handler) taskTimeByAssignee(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.taskTimeByAssignee"
+	log := h.Logs.With(slog.String("operation", op))
+
+	id, err := parsePathID(r, "taskID")
+	if err != nil {
+		log.Error("Invalid task ID", logger.Err(err))
+		writeError(w, r, err, "Invalid task ID")
+		return
+	}
+
+	timeSpent, err := h.services.Assignee.TimeByAssignee(r.Context(), id)
+	if err != nil {
+		log.Error("Failed to get task time spent", logger.Err(err))
+		writeError(w, r, err, "Failed to get task time spent")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(timeSpent); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// parseAssigneeIDs читает ID задачи и пользователя из пути /task/{taskID}/assignees/{peopleID}.
+func parseAssigneeIDs(r *http.Request) (int, int, error) {
+	taskID, err := parsePathID(r, "taskID")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	peopleID, err := parsePathID(r, "peopleID")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return taskID, peopleID, nil
+}
@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"TaskSync/internal/entities"
+	"TaskSync/pkg/logger"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+// Handler methods for Template
+
+// @Summary Create Template
+// @Description Create a reusable task template: a title pattern, description, default project and assignee, estimate and checklist items.
+// @Description The title pattern may contain placeholders such as {date}, {week}, {month}, {year} or custom ones filled from vars when a task is created, e.g. "Release {version} checklist".
+// @Description Only admins and managers can manage templates.
+// @Tags Template
+// @Accept json
+// @Produce json
+// @Param template body entities.TaskTemplate true "Template to create"
+// @Success 201 {integer} int "ID of the created template"
+// @Failure 400 {object} Problem "Invalid request payload or unknown project or assignee"
+// @Failure 403 {object} Problem
+// @Failure 409 {object} Problem "Template already exists"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /template [post]
+func (h *Handler) templateCreate(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.templateCreate"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var tpl entities.TaskTemplate
+	if err := decodeJSON(r, &tpl); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	id, err := h.services.Template.Create(r.Context(), tpl)
+	if err != nil {
+		log.Error("Failed to create template", logger.Err(err))
+		writeError(w, r, err, "Failed to create template")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(id); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary List Templates
+// @Description Get all task templates ordered by name
+// @Tags Template
+// @Accept json
+// @Produce json
+// @Success 200 {array} entities.TaskTemplate
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /template [get]
+func (h *Handler) templateList(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.templateList"
+	log := h.Logs.With(slog.String("operation", op))
+
+	templates, err := h.services.Template.List(r.Context())
+	if err != nil {
+		log.Error("Failed to list templates", logger.Err(err))
+		writeError(w, r, err, "Failed to list templates")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(templates); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary Get Template by ID
+// @Description Get a task template by its ID
+// @Tags Template
+// @Accept json
+// @Produce json
+// @Param templateID path int true "Template ID"
+// @Success 200 {object} entities.TaskTemplate
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem "Template not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /template/{templateID} [get]
+func (h *Handler) templateGetByID(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.templateGetByID"
+	log := h.Logs.With(slog.String("operation", op))
+
+	id, err := parsePathID(r, "templateID")
+	if err != nil {
+		log.Error("Invalid template ID", logger.Err(err))
+		writeError(w, r, err, "Invalid template ID")
+		return
+	}
+
+	tpl, err := h.services.Template.GetByID(r.Context(), id)
+	if err != nil {
+		log.Error("Failed to get template by ID", logger.Err(err))
+		writeError(w, r, err, "Failed to get template by ID")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(tpl); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary Update Template
+// @Description Replace a task template, including its checklist. Tasks already created from the template are not changed.
+// @Tags Template
+// @Accept json
+// @Produce json
+// @Param template body entities.TaskTemplate true "Template to update"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem "Template not found"
+// @Failure 409 {object} Problem "Template already exists"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /template [put]
+func (h *Handler) templateUpdate(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.templateUpdate"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var tpl entities.TaskTemplate
+	if err := decodeJSON(r, &tpl); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	if err := h.services.Template.Update(r.Context(), tpl); err != nil {
+		log.Error("Failed to update template", logger.Err(err))
+		writeError(w, r, err, "Failed to update template")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+// @Summary Delete Template
+// @Description Delete a task template by its ID. Tasks already created from it are kept.
+// @Tags Template
+// @Accept json
+// @Produce json
+// @Param templateID path int true "Template ID"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 404 {object} Problem "Template not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /template/{templateID} [delete]
+func (h *Handler) templateDelete(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.templateDelete"
+	log := h.Logs.With(slog.String("operation", op))
+
+	id, err := parsePathID(r, "templateID")
+	if err != nil {
+		log.Error("Invalid template ID", logger.Err(err))
+		writeError(w, r, err, "Invalid template ID")
+		return
+	}
+
+	if err := h.services.Template.Delete(r.Context(), id); err != nil {
+		log.Error("Failed to delete template", logger.Err(err))
+		writeError(w, r, err, "Failed to delete template")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+// @Summary Create Task from Template
+// @Description Create a task from a template. The title placeholders {date}, {week} (ISO, e.g. 2026-W42), {month} and {year} take the current UTC date; vars fill custom placeholders and override the built-in ones.
+// @Description Non-zero project_id and assignee_id replace the template defaults, parent_id makes the task a subtask. The template checklist is copied to the task. The body may be omitted.
+// @Description Only admins and managers may create a task with an assignee, from the body or the template; managers only for themselves or their team.
+// @Tags Task
+// @Accept json
+// @Produce json
+// @Param templateID path int true "Template ID"
+// @Param params body entities.TemplateParams false "Overrides and placeholder values"
+// @Success 201 {integer} int "ID of the created task"
+// @Failure 400 {object} Problem "Invalid request payload, missing placeholder values or unknown project, parent or assignee"
+// @Failure 403 {object} Problem "Caller may not assign the task to this person"
+// @Failure 404 {object} Problem "Template not found"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /task/from-template/{templateID} [post]
+func (h *Handler) taskFromTemplate(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.taskFromTemplate"
+	log := h.Logs.With(slog.String("operation", op))
+
+	id, err := parsePathID(r, "templateID")
+	if err != nil {
+		log.Error("Invalid template ID", logger.Err(err))
+		writeError(w, r, err, "Invalid template ID")
+		return
+	}
+
+	// Без тела задача создаётся со значениями шаблона
+	var params entities.TemplateParams
+	if r.ContentLength != 0 {
+		if err := decodeJSON(r, &params); err != nil {
+			log.Error("Failed to decode request body", logger.Err(err))
+			writeError(w, r, err, "Invalid request payload")
+			return
+		}
+	}
+
+	taskID, err := h.services.Template.Instantiate(r.Context(), id, params)
+	if err != nil {
+		log.Error("Failed to create task from template", logger.Err(err))
+		writeError(w, r, err, "Failed to create task from template")
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(taskID); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
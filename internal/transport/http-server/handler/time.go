@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"TaskSync/internal/domain"
 	"TaskSync/pkg/logger"
 	"encoding/json"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"time"
@@ -11,115 +13,431 @@ import (
 // Handler methods for Time
 
 type timeTask struct {
-	TaskID int       `json:"task_id"`
-	Time   time.Time `json:"time"`
+	TaskID   int       `json:"task_id"`
+	PeopleID int       `json:"people_id"`
+	Time     time.Time `json:"time"`
 }
 
 // @Summary Start Time Entry
-// @Description Start recording time for a task
+// @Description Start a new work session of a person on a task. People id defaults to the authenticated person. FORMAT TIME - RFC 3339 "2024-08-01T08:00:00Z".
 // @Tags Time
 // @Accept json
 // @Produce json
 // @Param task body timeTask true "Task to start time entry for"
-// @Success 200 {string} string "OK"
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Success 200 {integer} int "Time entry ID"
+// @Failure 400 {object} Problem
+// @Failure 403 {object} Problem "Time of another person"
+// @Failure 409 {object} Problem "Another timer is already running, the task is blocked by unfinished tasks, the entry overlaps existing entries or is inside an approved timesheet week"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /time/start [post]
 func (h *Handler) timeStartTimeEntry(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.timeStartTimeEntry"
 	log := h.Logs.With(slog.String("operation", op))
 
 	var task timeTask
-	if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
+	if err := decodeJSON(r, &task); err != nil {
 		log.Error("Failed to decode request body", logger.Err(err))
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		writeError(w, r, err, "Invalid request payload")
 		return
 	}
 
-	if err := h.services.Time.StartTimeEntry(r.Context(), task.TaskID, task.Time); err != nil {
+	id, err := h.services.Time.StartTimeEntry(r.Context(), task.TaskID, task.PeopleID, task.Time)
+	if err != nil {
 		log.Error("Failed to start time entry", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to start time entry")
+		writeError(w, r, err, "Failed to start time entry")
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
-	if _, err := w.Write([]byte("OK")); err != nil {
-		log.Error("Failed to write response", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to write response")
+	if err := json.NewEncoder(w).Encode(id); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
 	}
 }
 
 // @Summary End Time Entry
-// @Description End recording time for a task. FORMAT TIME - RFC 3339 "2024-08-01T08:00:00Z".
+// @Description End the open work session of a person on a task. People id defaults to the authenticated person. FORMAT TIME - RFC 3339 "2024-08-01T08:00:00Z".
 // @Tags Time
 // @Accept json
 // @Produce json
 // @Param task body timeTask true "Task to end time entry for"
 // @Success 200 {string} string "OK"
-// @Failure 400 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} Problem
+// @Failure 403 {object} Problem "Time of another person"
+// @Failure 404 {object} Problem "No open time entry"
+// @Failure 409 {object} Problem "Time is inside an approved timesheet week"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /time/end [post]
 func (h *Handler) timeEndTimeEntry(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.timeEndTimeEntry"
 	log := h.Logs.With(slog.String("operation", op))
 
 	var task timeTask
-	if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
+	if err := decodeJSON(r, &task); err != nil {
 		log.Error("Failed to decode request body", logger.Err(err))
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		writeError(w, r, err, "Invalid request payload")
 		return
 	}
 
-	if err := h.services.Time.EndTimeEntry(r.Context(), task.TaskID, task.Time); err != nil {
+	if err := h.services.Time.EndTimeEntry(r.Context(), task.TaskID, task.PeopleID, task.Time); err != nil {
 		log.Error("Failed to end time entry", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to end time entry")
+		writeError(w, r, err, "Failed to end time entry")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+type timePeople struct {
+	PeopleID int       `json:"people_id"`
+	Time     time.Time `json:"time"`
+}
+
+// @Summary Pause Timer
+// @Description Pause the running timer of a person. People id defaults to the authenticated person. If time is omitted, the current time is used. FORMAT TIME - RFC 3339 "2024-08-01T08:00:00Z".
+// @Tags Time
+// @Accept json
+// @Produce json
+// @Param people body timePeople true "People to pause the timer for"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem "No running timer"
+// @Failure 409 {object} Problem "Time is inside an approved timesheet week"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /time/pause [post]
+func (h *Handler) timePause(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.timePause"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var people timePeople
+	if err := decodeJSON(r, &people); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	if err := h.services.Time.PauseTimeEntry(r.Context(), people.PeopleID, people.Time); err != nil {
+		log.Error("Failed to pause timer", logger.Err(err))
+		writeError(w, r, err, "Failed to pause timer")
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 	if _, err := w.Write([]byte("OK")); err != nil {
 		log.Error("Failed to write response", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to write response")
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+// @Summary Resume Timer
+// @Description Resume the paused timer of a person with a new segment of the same session. People id defaults to the authenticated person. If time is omitted, the current time is used. FORMAT TIME - RFC 3339 "2024-08-01T08:00:00Z".
+// @Tags Time
+// @Accept json
+// @Produce json
+// @Param people body timePeople true "People to resume the timer for"
+// @Success 200 {integer} int "Time entry ID of the new segment"
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem "No paused timer"
+// @Failure 409 {object} Problem "Timer is not paused, the entry overlaps existing entries or is inside an approved timesheet week"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /time/resume [post]
+func (h *Handler) timeResume(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.timeResume"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var people timePeople
+	if err := decodeJSON(r, &people); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	id, err := h.services.Time.ResumeTimeEntry(r.Context(), people.PeopleID, people.Time)
+	if err != nil {
+		log.Error("Failed to resume timer", logger.Err(err))
+		writeError(w, r, err, "Failed to resume timer")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(id); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary Active Timer
+// @Description Get the running or paused timer of a person with the elapsed time of the whole session
+// @Tags Time
+// @Accept json
+// @Produce json
+// @Param people_id query int false "People ID, the authenticated person by default"
+// @Success 200 {object} entities.ActiveTimer
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem "No active timer"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /time/active [get]
+func (h *Handler) timeActive(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.timeActive"
+	log := h.Logs.With(slog.String("operation", op))
+
+	peopleID, err := parseQueryID(r, "people_id")
+	if err != nil {
+		log.Error("Invalid people ID", logger.Err(err))
+		writeError(w, r, err, "Invalid people ID")
+		return
+	}
+
+	timer, err := h.services.Time.ActiveTimer(r.Context(), peopleID)
+	if err != nil {
+		log.Error("Failed to get active timer", logger.Err(err))
+		writeError(w, r, err, "Failed to get active timer")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(timer); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary List Time Entries
+// @Description Get work sessions of a task. Admins see all sessions, others only sessions of people they can act for.
+// @Tags Time
+// @Accept json
+// @Produce json
+// @Param task_id query int true "Task ID"
+// @Success 200 {array} entities.TimeEntry
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /time/entries [get]
+func (h *Handler) timeListEntries(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.timeListEntries"
+	log := h.Logs.With(slog.String("operation", op))
+
+	taskID := parseQueryInt(r.URL.Query().Get("task_id"))
+	if taskID <= 0 {
+		log.Error("Invalid task ID", slog.String("task_id", r.URL.Query().Get("task_id")))
+		writeError(w, r, domain.NewFieldError("task_id", "must be a positive integer"), "Invalid task ID")
+		return
+	}
+
+	entries, err := h.services.Time.ListTimeEntries(r.Context(), taskID)
+	if err != nil {
+		log.Error("Failed to list time entries", logger.Err(err))
+		writeError(w, r, err, "Failed to list time entries")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
 	}
 }
 
 type peopleTimeRange struct {
 	PeopleID  int       `json:"people_id"`
+	ProjectID int       `json:"project_id"`
 	StartTime time.Time `json:"start_time"`
 	EndTime   time.Time `json:"end_time"`
 }
 
 // @Summary Task Time Spent
-// @Description Get time spent on tasks by a person within a specific time range. FORMAT TIME - RFC 3339 "2024-08-01T08:00:00Z".
+// @Description Get time spent on tasks by a person within a specific time range, optionally only on tasks of a project. People id 0 means the authenticated person, for admins - all people. FORMAT TIME - RFC 3339 "2024-08-01T08:00:00Z".
+// @Description The report can be downloaded as CSV or XLSX with numeric hour columns and a header row. Per-person and grand totals go to a separate "Total hours" column, so summing "Hours" does not count them twice. Text cells starting with =, +, - or @ are prefixed with an apostrophe in CSV: pass format=csv|xlsx or the matching Accept header.
 // @Tags Time
 // @Accept json
-// @Produce json
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param task body peopleTimeRange true "People id and time range"
+// @Param format query string false "Report format, overrides the Accept header" Enums(json, csv, xlsx)
 // @Success 200 {array} entities.TaskTimeSpent
-// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} Problem "Unknown report format"
+// @Failure 500 {object} Problem
+// @Failure 403 {object} Problem "Forbidden"
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /time/spent [post]
 func (h *Handler) TasksTimeSpent(w http.ResponseWriter, r *http.Request) {
 	const op = "handler.timeGetTaskTimeSpent"
 	log := h.Logs.With(slog.String("operation", op))
 
+	format, ok := reportFormat(r)
+	if !ok {
+		writeErrorResponse(w, r, http.StatusBadRequest, "Unknown report format, expected json, csv or xlsx")
+		return
+	}
+
 	var inputValues peopleTimeRange
 
-	if err := json.NewDecoder(r.Body).Decode(&inputValues); err != nil {
+	if err := decodeJSON(r, &inputValues); err != nil {
 		log.Error("Failed to decode request body", logger.Err(err))
-		writeErrorResponse(w, http.StatusBadRequest, "Invalid request payload")
+		writeError(w, r, err, "Invalid request payload")
 		return
 	}
 
-	timeSpent, err := h.services.Time.TasksTimeSpent(r.Context(), inputValues.PeopleID, inputValues.StartTime, inputValues.EndTime)
+	timeSpent, err := h.services.Time.TasksTimeSpent(r.Context(), inputValues.PeopleID, inputValues.ProjectID, inputValues.StartTime, inputValues.EndTime)
 	if err != nil {
 		log.Error("Failed to get task time spent", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to get task time spent")
+		writeError(w, r, err, "Failed to get task time spent")
+		return
+	}
+
+	if format != formatJSON {
+		name := fmt.Sprintf("time-spent_%s_%s", inputValues.StartTime.Format(time.DateOnly), inputValues.EndTime.Format(time.DateOnly))
+		if err := writeReport(w, format, timeSpentReport(name, timeSpent)); err != nil {
+			log.Error("Failed to write report", logger.Err(err))
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(timeSpent); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary Project Time Spent
+// @Description Get time spent on each project within a specific time range. People id 0 means all people. FORMAT TIME - RFC 3339 "2024-08-01T08:00:00Z".
+// @Tags Time
+// @Accept json
+// @Produce json
+// @Param task body peopleTimeRange true "People id and time range, project id is ignored"
+// @Success 200 {array} entities.ProjectTimeSpent
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 403 {object} Problem "Forbidden"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /time/spent/projects [post]
+func (h *Handler) projectsTimeSpent(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.projectsTimeSpent"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var inputValues peopleTimeRange
+
+	if err := decodeJSON(r, &inputValues); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	timeSpent, err := h.services.Time.ProjectsTimeSpent(r.Context(), inputValues.PeopleID, inputValues.StartTime, inputValues.EndTime)
+	if err != nil {
+		log.Error("Failed to get project time spent", logger.Err(err))
+		writeError(w, r, err, "Failed to get project time spent")
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 	if err := json.NewEncoder(w).Encode(timeSpent); err != nil {
 		log.Error("Failed to encode response", logger.Err(err))
-		writeErrorResponse(w, http.StatusInternalServerError, "Failed to encode response")
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary Tag Time Spent
+// @Description Get time spent on tasks with each tag within a specific time range, optionally only on tasks of a project. Time of a task with several tags counts towards each of them, untagged tasks are not included. People id 0 means the authenticated person, for admins - all people. FORMAT TIME - RFC 3339 "2024-08-01T08:00:00Z".
+// @Tags Time
+// @Accept json
+// @Produce json
+// @Param task body peopleTimeRange true "People id, project id and time range"
+// @Success 200 {array} entities.TagTimeSpent
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
+// @Failure 403 {object} Problem "Forbidden"
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /time/spent/tags [post]
+func (h *Handler) tagsTimeSpent(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.tagsTimeSpent"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var inputValues peopleTimeRange
+
+	if err := decodeJSON(r, &inputValues); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	timeSpent, err := h.services.Time.TagsTimeSpent(r.Context(), inputValues.PeopleID, inputValues.ProjectID, inputValues.StartTime, inputValues.EndTime)
+	if err != nil {
+		log.Error("Failed to get tag time spent", logger.Err(err))
+		writeError(w, r, err, "Failed to get tag time spent")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(timeSpent); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+// @Summary Estimates Report
+// @Description Compare task estimates with time spent in completed sessions, per task, per person and per project.
+// @Description Includes tasks with an estimate or a due date. Overrun marks time spent above the estimate, overdue marks a past due date on a task that is neither done nor cancelled.
+// @Description Only admins get all tasks, other callers get the tasks they are assigned to or worked on by default.
+// @Description A report for one person lists only that person's time per task, the task total still includes everyone.
+// @Tags Time
+// @Accept json
+// @Produce json
+// @Param people_id query int false "People ID: only tasks the person is assigned to or worked on"
+// @Param project_id query int false "Project ID"
+// @Success 200 {object} entities.EstimateReport
+// @Failure 400 {object} Problem
+// @Failure 403 {object} Problem "Forbidden"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /time/estimates [get]
+func (h *Handler) timeEstimates(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.timeEstimates"
+	log := h.Logs.With(slog.String("operation", op))
+
+	peopleID, err := parseQueryID(r, "people_id")
+	if err != nil {
+		log.Error("Invalid people ID", logger.Err(err))
+		writeError(w, r, err, "Invalid people ID")
+		return
+	}
+
+	projectID, err := parseQueryID(r, "project_id")
+	if err != nil {
+		log.Error("Invalid project ID", logger.Err(err))
+		writeError(w, r, err, "Invalid project ID")
+		return
+	}
+
+	report, err := h.services.Time.Estimates(r.Context(), peopleID, projectID)
+	if err != nil {
+		log.Error("Failed to get estimates report", logger.Err(err))
+		writeError(w, r, err, "Failed to get estimates report")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
 	}
 }
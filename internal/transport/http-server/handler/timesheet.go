@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"TaskSync/pkg/logger"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+// Handler methods for Timesheet
+
+// @Summary Get Timesheet
+// @Description Get a weekly timesheet: hours per task per day, day totals and the approval state. People id defaults to the authenticated person, week defaults to the current ISO week.
+// @Tags Timesheet
+// @Accept json
+// @Produce json
+// @Param people_id query int false "People ID"
+// @Param week query string false "ISO week" example(2026-W42)
+// @Success 200 {object} entities.Timesheet
+// @Failure 400 {object} Problem "Invalid week"
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /timesheet [get]
+func (h *Handler) timesheetGet(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.timesheetGet"
+	log := h.Logs.With(slog.String("operation", op))
+
+	peopleID, err := parseQueryID(r, "people_id")
+	if err != nil {
+		log.Error("Invalid people ID", logger.Err(err))
+		writeError(w, r, err, "Invalid people ID")
+		return
+	}
+
+	sheet, err := h.services.Timesheet.Get(r.Context(), peopleID, r.URL.Query().Get("week"))
+	if err != nil {
+		log.Error("Failed to get timesheet", logger.Err(err))
+		writeError(w, r, err, "Failed to get timesheet")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(sheet); err != nil {
+		log.Error("Failed to encode response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to encode response")
+	}
+}
+
+type timesheetWeek struct {
+	PeopleID int    `json:"people_id"`
+	Week     string `json:"week" example:"2026-W42"`
+	Comment  string `json:"comment"`
+}
+
+// @Summary Submit Timesheet
+// @Description Submit a weekly timesheet for approval. Draft and rejected timesheets can be submitted. People id defaults to the authenticated person.
+// @Tags Timesheet
+// @Accept json
+// @Produce json
+// @Param timesheet body timesheetWeek true "People and week, comment is ignored"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 409 {object} Problem "Timesheet is already submitted or approved, the week is not over or a timer is running in it"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /timesheet/submit [post]
+func (h *Handler) timesheetSubmit(w http.ResponseWriter, r *http.Request) {
+	const op = "handler.timesheetSubmit"
+	log := h.Logs.With(slog.String("operation", op))
+
+	var req timesheetWeek
+	if err := decodeJSON(r, &req); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	if err := h.services.Timesheet.Submit(r.Context(), req.PeopleID, req.Week); err != nil {
+		log.Error("Failed to submit timesheet", logger.Err(err))
+		writeError(w, r, err, "Failed to submit timesheet")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
+
+// @Summary Approve Timesheet
+// @Description Approve a submitted timesheet. Available to the person's manager and admins. Time entries inside an approved week can no longer be changed.
+// @Tags Timesheet
+// @Accept json
+// @Produce json
+// @Param timesheet body timesheetWeek true "People, week and optional comment"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 409 {object} Problem "Timesheet is not submitted, the week is not over or a timer is running in it"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /timesheet/approve [post]
+func (h *Handler) timesheetApprove(w http.ResponseWriter, r *http.Request) {
+	h.timesheetReview(w, r, "handler.timesheetApprove", h.services.Timesheet.Approve)
+}
+
+// @Summary Reject Timesheet
+// @Description Reject a submitted timesheet with a comment. Available to the person's manager and admins. The person can fix the time and submit the week again.
+// @Tags Timesheet
+// @Accept json
+// @Produce json
+// @Param timesheet body timesheetWeek true "People, week and comment"
+// @Success 200 {string} string "OK"
+// @Failure 400 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 409 {object} Problem "Timesheet is not submitted"
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /timesheet/reject [post]
+func (h *Handler) timesheetReject(w http.ResponseWriter, r *http.Request) {
+	h.timesheetReview(w, r, "handler.timesheetReject", h.services.Timesheet.Reject)
+}
+
+// timesheetReview общая обработка согласования и отклонения табеля.
+func (h *Handler) timesheetReview(w http.ResponseWriter, r *http.Request, op string,
+	review func(ctx context.Context, peopleID int, week, comment string) error) {
+	log := h.Logs.With(slog.String("operation", op))
+
+	var req timesheetWeek
+	if err := decodeJSON(r, &req); err != nil {
+		log.Error("Failed to decode request body", logger.Err(err))
+		writeError(w, r, err, "Invalid request payload")
+		return
+	}
+
+	if err := review(r.Context(), req.PeopleID, req.Week, req.Comment); err != nil {
+		log.Error("Failed to review timesheet", logger.Err(err))
+		writeError(w, r, err, "Failed to review timesheet")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write([]byte("OK")); err != nil {
+		log.Error("Failed to write response", logger.Err(err))
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Failed to write response")
+	}
+}
@@ -0,0 +1,6 @@
+-- Несколько сессий работы над одной задачей.
+-- Открытой считается сессия с start_time и без end_time,
+-- у пользователя может быть только одна открытая сессия по задаче.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_open_session
+    ON time_entries (task_id, people_id)
+    WHERE start_time IS NOT NULL AND end_time IS NULL;
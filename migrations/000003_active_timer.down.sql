@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_time_entries_session_id;
+
+ALTER TABLE time_entries
+    DROP COLUMN IF EXISTS paused,
+    DROP COLUMN IF EXISTS session_id;
+
+DROP INDEX IF EXISTS idx_time_entries_running;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_open_session
+    ON time_entries (task_id, people_id)
+    WHERE start_time IS NOT NULL AND end_time IS NULL;
@@ -0,0 +1,13 @@
+-- Запущенный таймер у пользователя может быть только один, независимо от задачи.
+DROP INDEX IF EXISTS idx_time_entries_open_session;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_running
+    ON time_entries (people_id)
+    WHERE start_time IS NOT NULL AND end_time IS NULL;
+
+-- Отрезки одной сессии, разделённые паузами.
+-- session_id ссылается на первый отрезок сессии, у первого отрезка он NULL.
+ALTER TABLE time_entries
+    ADD COLUMN IF NOT EXISTS session_id INTEGER REFERENCES time_entries(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS paused BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_time_entries_session_id ON time_entries (session_id);
@@ -0,0 +1,2 @@
+ALTER TABLE time_entries DROP CONSTRAINT IF EXISTS excl_time_entries_overlap;
+ALTER TABLE time_entries DROP COLUMN IF EXISTS overlaps;
@@ -0,0 +1,26 @@
+-- Контроль пересечения интервалов времени одного пользователя.
+-- Записи с флагом overlaps разрешены политикой "allow-but-flag" и в проверке не участвуют.
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+ALTER TABLE time_entries ADD COLUMN IF NOT EXISTS overlaps BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Уже существующие пересечения помечаются флагом, иначе ограничение не создать
+UPDATE time_entries te
+SET overlaps = TRUE
+WHERE te.start_time IS NOT NULL
+    AND EXISTS (
+        SELECT 1
+        FROM time_entries o
+        WHERE o.id <> te.id
+            AND o.people_id = te.people_id
+            AND o.start_time IS NOT NULL
+            AND tsrange(o.start_time, o.end_time) && tsrange(te.start_time, te.end_time)
+    );
+
+-- Открытая сессия (end_time IS NULL) считается интервалом без верхней границы
+ALTER TABLE time_entries ADD CONSTRAINT excl_time_entries_overlap
+    EXCLUDE USING gist (
+        people_id WITH =,
+        tsrange(start_time, end_time) WITH &&
+    )
+    WHERE (start_time IS NOT NULL AND NOT overlaps);
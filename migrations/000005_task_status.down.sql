@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tasks_status;
+ALTER TABLE tasks DROP COLUMN IF EXISTS status;
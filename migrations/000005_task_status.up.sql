@@ -0,0 +1,4 @@
+-- Статус задачи, допустимые переходы проверяются в сервисе
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'todo';
+
+CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks (status);
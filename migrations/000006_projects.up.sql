@@ -0,0 +1,12 @@
+-- Проекты, объединяющие задачи
+CREATE TABLE IF NOT EXISTS projects (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    CONSTRAINT unique_project_name UNIQUE (name)
+);
+
+-- При удалении проекта задачи остаются без проекта
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS project_id INTEGER REFERENCES projects(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks (project_id);
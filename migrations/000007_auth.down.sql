@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS auth_sessions;
+ALTER TABLE people_info DROP COLUMN IF EXISTS password_hash;
@@ -0,0 +1,16 @@
+-- Пароль пользователя, хранится только bcrypt хеш
+ALTER TABLE people_info ADD COLUMN IF NOT EXISTS password_hash TEXT;
+
+-- Сессии входа: refresh token хранится в виде SHA-256 хеша,
+-- отозванная сессия делает недействительными все её токены
+CREATE TABLE IF NOT EXISTS auth_sessions (
+    id VARCHAR(64) PRIMARY KEY,
+    people_id INTEGER NOT NULL,
+    refresh_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (people_id) REFERENCES people_info(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_sessions_people_id ON auth_sessions (people_id);
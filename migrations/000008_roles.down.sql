@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_people_info_manager_id;
+ALTER TABLE people_info DROP CONSTRAINT IF EXISTS chk_people_role;
+ALTER TABLE people_info
+    DROP COLUMN IF EXISTS manager_id,
+    DROP COLUMN IF EXISTS role;
@@ -0,0 +1,8 @@
+-- Роли пользователей и команды менеджеров
+ALTER TABLE people_info
+    ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'member',
+    ADD COLUMN IF NOT EXISTS manager_id INTEGER REFERENCES people_info(id) ON DELETE SET NULL;
+
+ALTER TABLE people_info ADD CONSTRAINT chk_people_role CHECK (role IN ('admin', 'manager', 'member'));
+
+CREATE INDEX IF NOT EXISTS idx_people_info_manager_id ON people_info (manager_id);
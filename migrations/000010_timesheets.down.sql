@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_time_entries_people_start;
+DROP TABLE IF EXISTS timesheets;
@@ -0,0 +1,19 @@
+-- Недельные табели: отправка пользователем и согласование менеджером.
+-- Записи времени внутри согласованной недели не изменяются.
+CREATE TABLE IF NOT EXISTS timesheets (
+    id SERIAL PRIMARY KEY,
+    people_id INTEGER NOT NULL,
+    week_start DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    comment TEXT NOT NULL DEFAULT '',
+    submitted_at TIMESTAMP,
+    reviewed_at TIMESTAMP,
+    reviewed_by INTEGER,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (people_id) REFERENCES people_info(id) ON DELETE CASCADE,
+    FOREIGN KEY (reviewed_by) REFERENCES people_info(id) ON DELETE SET NULL,
+    CONSTRAINT unique_timesheet_week UNIQUE (people_id, week_start),
+    CONSTRAINT chk_timesheet_status CHECK (status IN ('draft', 'submitted', 'approved', 'rejected'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_time_entries_people_start ON time_entries (people_id, start_time);
@@ -0,0 +1,23 @@
+ALTER TABLE people_info
+    DROP CONSTRAINT IF EXISTS chk_passport_number,
+    DROP CONSTRAINT IF EXISTS chk_passport_series;
+
+CREATE OR REPLACE FUNCTION check_passport_length()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.passport_series IS NOT NULL AND (NEW.passport_series < 1000 OR NEW.passport_series > 9999) THEN
+        RAISE EXCEPTION 'passport_series must be a 4-digit number';
+    END IF;
+
+    IF NEW.passport_number IS NOT NULL AND (NEW.passport_number < 100000 OR NEW.passport_number > 999999) THEN
+        RAISE EXCEPTION 'passport_number must be a 6-digit number';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER check_passport_length_trigger
+BEFORE INSERT OR UPDATE ON people_info
+FOR EACH ROW
+EXECUTE FUNCTION check_passport_length();
@@ -0,0 +1,7 @@
+-- Проверка паспортных данных выполняется в сервисе, в схеме остаются ограничения CHECK, как в SQLite
+DROP TRIGGER IF EXISTS check_passport_length_trigger ON people_info;
+DROP FUNCTION IF EXISTS check_passport_length();
+
+ALTER TABLE people_info
+    ADD CONSTRAINT chk_passport_series CHECK (passport_series BETWEEN 1000 AND 9999),
+    ADD CONSTRAINT chk_passport_number CHECK (passport_number BETWEEN 100000 AND 999999);
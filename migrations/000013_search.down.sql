@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tasks_search;
+DROP INDEX IF EXISTS idx_people_info_search;
+ALTER TABLE tasks DROP COLUMN IF EXISTS search;
+ALTER TABLE people_info DROP COLUMN IF EXISTS search;
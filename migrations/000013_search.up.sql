@@ -0,0 +1,13 @@
+-- Полнотекстовый поиск по ФИО пользователей и заголовку и описанию задач.
+-- Конфигурация simple не приводит слова к основе, поэтому подходит и для ФИО, и для смешанного русского и английского текста.
+ALTER TABLE people_info ADD COLUMN IF NOT EXISTS search tsvector
+    GENERATED ALWAYS AS (to_tsvector('simple', surname || ' ' || name || ' ' || COALESCE(patronymic, ''))) STORED;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS search tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', title), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_people_info_search ON people_info USING GIN (search);
+CREATE INDEX IF NOT EXISTS idx_tasks_search ON tasks USING GIN (search);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_tags_tag_id;
+DROP TABLE IF EXISTS task_tags;
+DROP TABLE IF EXISTS tags;
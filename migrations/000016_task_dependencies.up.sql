@@ -0,0 +1,10 @@
+-- Зависимости между задачами: blocker_id блокирует blocked_id, пока не будет выполнена.
+-- Зависимость удаляется вместе с любой из задач.
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    blocker_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    blocked_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CONSTRAINT check_task_dependency_self CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_dependencies_blocked_id ON task_dependencies (blocked_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_assignees_people_id;
+DROP INDEX IF EXISTS idx_task_assignees_current;
+DROP TABLE IF EXISTS task_assignees;
@@ -0,0 +1,6 @@
+-- Срок выполнения и оценка задачи. Оценка хранится в секундах, NULL - задача без оценки или без срока.
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS due_date TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS estimate_seconds BIGINT CONSTRAINT chk_tasks_estimate CHECK (estimate_seconds > 0);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_due_date ON tasks (due_date);
@@ -0,0 +1,31 @@
+-- Повторяющиеся задачи: шаблон задачи и правило повторения RRULE.
+-- next_run - следующее повторение, NULL у исчерпанного правила, last_run - последнее созданное повторение.
+CREATE TABLE IF NOT EXISTS recurrences (
+    id SERIAL PRIMARY KEY,
+    title VARCHAR(100) NOT NULL,
+    description TEXT,
+    project_id INTEGER REFERENCES projects(id) ON DELETE SET NULL,
+    estimate_seconds BIGINT CONSTRAINT chk_recurrences_estimate CHECK (estimate_seconds > 0),
+    rule VARCHAR(255) NOT NULL,
+    start_at TIMESTAMP NOT NULL,
+    next_run TIMESTAMP,
+    last_run TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurrences_next_run ON recurrences (next_run);
+
+-- Исполнители, назначаемые на каждую созданную задачу
+CREATE TABLE IF NOT EXISTS recurrence_assignees (
+    recurrence_id INTEGER NOT NULL REFERENCES recurrences(id) ON DELETE CASCADE,
+    people_id INTEGER NOT NULL REFERENCES people_info(id) ON DELETE CASCADE,
+    PRIMARY KEY (recurrence_id, people_id)
+);
+
+-- Созданные повторения. Первичный ключ не даёт создать задачу одного повторения дважды,
+-- в том числе после перезапуска или при нескольких запущенных экземплярах.
+CREATE TABLE IF NOT EXISTS recurrence_runs (
+    recurrence_id INTEGER NOT NULL REFERENCES recurrences(id) ON DELETE CASCADE,
+    occurrence_at TIMESTAMP NOT NULL,
+    task_id INTEGER REFERENCES tasks(id) ON DELETE SET NULL,
+    PRIMARY KEY (recurrence_id, occurrence_at)
+);
@@ -0,0 +1,30 @@
+-- Чек-листы задач: пункты отмечаются независимо друг от друга, done_at - время отметки, NULL - пункт не выполнен.
+CREATE TABLE IF NOT EXISTS task_checklist_items (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    done_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_checklist_items_task_id ON task_checklist_items (task_id, position);
+
+-- Шаблоны задач. title_pattern - заголовок с подстановками вида {date}, assignee_id - исполнитель по умолчанию.
+CREATE TABLE IF NOT EXISTS task_templates (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    title_pattern VARCHAR(100) NOT NULL,
+    description TEXT,
+    project_id INTEGER REFERENCES projects(id) ON DELETE SET NULL,
+    assignee_id INTEGER REFERENCES people_info(id) ON DELETE SET NULL,
+    estimate_seconds BIGINT CONSTRAINT chk_task_templates_estimate CHECK (estimate_seconds > 0),
+    CONSTRAINT unique_task_template_name UNIQUE (name)
+);
+
+-- Пункты чек-листа шаблона, копируются в каждую созданную по шаблону задачу
+CREATE TABLE IF NOT EXISTS task_template_items (
+    template_id INTEGER NOT NULL REFERENCES task_templates(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    PRIMARY KEY (template_id, position)
+);
@@ -0,0 +1,10 @@
+// Package migrations содержит SQL миграции схемы БД.
+package migrations
+
+import "embed"
+
+// SQLite миграции SQLite, встроены в исполняемый файл,
+// чтобы офлайн установка не зависела от рабочего каталога.
+//
+//go:embed sqlite/*.sql
+var SQLite embed.FS
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS timesheets;
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS auth_sessions;
+DROP TRIGGER IF EXISTS excl_time_entries_overlap_update;
+DROP TRIGGER IF EXISTS excl_time_entries_overlap_insert;
+DROP TABLE IF EXISTS time_entries;
+DROP TABLE IF EXISTS tasks;
+DROP TABLE IF EXISTS projects;
+DROP TABLE IF EXISTS people_info;
@@ -0,0 +1,160 @@
+-- Схема SQLite для однопользовательских и офлайн установок.
+-- Повторяет итоговую схему PostgreSQL: триггеры plpgsql заменены ограничениями CHECK,
+-- ограничение EXCLUDE на пересечение интервалов - триггерами, INTERVAL считается в коде.
+-- Время хранится текстом в UTC, формат "2006-01-02 15:04:05.999999999-07:00".
+
+-- Пользователи
+CREATE TABLE IF NOT EXISTS people_info (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    passport_series INTEGER NOT NULL,
+    passport_number INTEGER NOT NULL,
+    surname VARCHAR(50) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    patronymic VARCHAR(50),
+    address TEXT NOT NULL,
+    password_hash TEXT,
+    role VARCHAR(20) NOT NULL DEFAULT 'member',
+    manager_id INTEGER,
+    FOREIGN KEY (manager_id) REFERENCES people_info(id) ON DELETE SET NULL,
+    CONSTRAINT unique_passport UNIQUE (passport_series, passport_number),
+    CONSTRAINT chk_passport_series CHECK (passport_series BETWEEN 1000 AND 9999),
+    CONSTRAINT chk_passport_number CHECK (passport_number BETWEEN 100000 AND 999999),
+    CONSTRAINT chk_people_role CHECK (role IN ('admin', 'manager', 'member'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_people_info_manager_id ON people_info (manager_id);
+
+-- Проекты, объединяющие задачи
+CREATE TABLE IF NOT EXISTS projects (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    CONSTRAINT unique_project_name UNIQUE (name)
+);
+
+-- Задания, при удалении проекта задачи остаются без проекта
+CREATE TABLE IF NOT EXISTS tasks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title VARCHAR(100) NOT NULL,
+    description TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'todo',
+    project_id INTEGER,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks (status);
+CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks (project_id);
+
+-- Контроль времени.
+-- session_id ссылается на первый отрезок сессии, у первого отрезка он NULL.
+-- Записи с флагом overlaps разрешены политикой "allow-but-flag" и в проверке пересечений не участвуют.
+CREATE TABLE IF NOT EXISTS time_entries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    people_id INTEGER,
+    task_id INTEGER NOT NULL,
+    start_time TIMESTAMP,
+    end_time TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    session_id INTEGER,
+    paused BOOLEAN NOT NULL DEFAULT FALSE,
+    overlaps BOOLEAN NOT NULL DEFAULT FALSE,
+    FOREIGN KEY (people_id) REFERENCES people_info(id) ON DELETE SET NULL,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (session_id) REFERENCES time_entries(id) ON DELETE CASCADE,
+    CONSTRAINT chk_time CHECK (end_time >= start_time)
+);
+
+CREATE INDEX IF NOT EXISTS idx_time_entries_people_id ON time_entries (people_id);
+CREATE INDEX IF NOT EXISTS idx_time_entries_task_id ON time_entries (task_id);
+CREATE INDEX IF NOT EXISTS idx_time_entries_session_id ON time_entries (session_id);
+CREATE INDEX IF NOT EXISTS idx_time_entries_people_start ON time_entries (people_id, start_time);
+
+-- Запущенный таймер у пользователя может быть только один, независимо от задачи.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_running
+    ON time_entries (people_id)
+    WHERE start_time IS NOT NULL AND end_time IS NULL;
+
+-- Интервалы одного пользователя не пересекаются, открытая сессия - интервал без верхней границы.
+-- Два запущенных таймера отклоняются индексом idx_time_entries_running.
+CREATE TRIGGER IF NOT EXISTS excl_time_entries_overlap_insert
+BEFORE INSERT ON time_entries
+WHEN NEW.people_id IS NOT NULL AND NEW.start_time IS NOT NULL AND NOT NEW.overlaps
+    AND (NEW.end_time IS NULL OR NEW.end_time > NEW.start_time)
+BEGIN
+    SELECT RAISE(ABORT, 'excl_time_entries_overlap')
+    WHERE EXISTS (
+        SELECT 1
+        FROM time_entries o
+        WHERE o.people_id = NEW.people_id
+            AND o.start_time IS NOT NULL
+            AND NOT o.overlaps
+            AND NOT (o.end_time IS NULL AND NEW.end_time IS NULL)
+            AND (o.end_time IS NULL OR (o.end_time > o.start_time AND o.end_time > NEW.start_time))
+            AND (NEW.end_time IS NULL OR o.start_time < NEW.end_time)
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS excl_time_entries_overlap_update
+BEFORE UPDATE OF people_id, start_time, end_time, overlaps ON time_entries
+WHEN NEW.people_id IS NOT NULL AND NEW.start_time IS NOT NULL AND NOT NEW.overlaps
+    AND (NEW.end_time IS NULL OR NEW.end_time > NEW.start_time)
+BEGIN
+    SELECT RAISE(ABORT, 'excl_time_entries_overlap')
+    WHERE EXISTS (
+        SELECT 1
+        FROM time_entries o
+        WHERE o.id <> NEW.id
+            AND o.people_id = NEW.people_id
+            AND o.start_time IS NOT NULL
+            AND NOT o.overlaps
+            AND NOT (o.end_time IS NULL AND NEW.end_time IS NULL)
+            AND (o.end_time IS NULL OR (o.end_time > o.start_time AND o.end_time > NEW.start_time))
+            AND (NEW.end_time IS NULL OR o.start_time < NEW.end_time)
+    );
+END;
+
+-- Сессии входа: refresh token хранится в виде SHA-256 хеша
+CREATE TABLE IF NOT EXISTS auth_sessions (
+    id VARCHAR(64) PRIMARY KEY,
+    people_id INTEGER NOT NULL,
+    refresh_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (people_id) REFERENCES people_info(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_sessions_people_id ON auth_sessions (people_id);
+
+-- API ключи интеграций, области доступа хранятся строкой через запятую
+CREATE TABLE IF NOT EXISTS api_keys (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    people_id INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    prefix VARCHAR(32) NOT NULL UNIQUE,
+    key_hash VARCHAR(64) NOT NULL,
+    scopes TEXT NOT NULL DEFAULT '',
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (people_id) REFERENCES people_info(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_people_id ON api_keys (people_id);
+
+-- Недельные табели
+CREATE TABLE IF NOT EXISTS timesheets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    people_id INTEGER NOT NULL,
+    week_start DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    comment TEXT NOT NULL DEFAULT '',
+    submitted_at TIMESTAMP,
+    reviewed_at TIMESTAMP,
+    reviewed_by INTEGER,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (people_id) REFERENCES people_info(id) ON DELETE CASCADE,
+    FOREIGN KEY (reviewed_by) REFERENCES people_info(id) ON DELETE SET NULL,
+    CONSTRAINT unique_timesheet_week UNIQUE (people_id, week_start),
+    CONSTRAINT chk_timesheet_status CHECK (status IN ('draft', 'submitted', 'approved', 'rejected'))
+);
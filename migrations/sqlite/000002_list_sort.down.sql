@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_title_id;
+DROP INDEX IF EXISTS idx_people_info_name_id;
+DROP INDEX IF EXISTS idx_people_info_surname_id;
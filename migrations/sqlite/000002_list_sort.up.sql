@@ -0,0 +1,4 @@
+-- Индексы для пагинации по курсору: сортировка по полю с дополнением по id
+CREATE INDEX IF NOT EXISTS idx_people_info_surname_id ON people_info (surname, id);
+CREATE INDEX IF NOT EXISTS idx_people_info_name_id ON people_info (name, id);
+CREATE INDEX IF NOT EXISTS idx_tasks_title_id ON tasks (title, id);
@@ -0,0 +1,5 @@
+-- Иерархия задач. При удалении родителя подзадачи становятся задачами верхнего уровня,
+-- их записи времени сохраняются.
+ALTER TABLE tasks ADD COLUMN parent_id INTEGER REFERENCES tasks(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks (parent_id);
@@ -0,0 +1,24 @@
+-- Исполнители задач и история назначений.
+-- Текущее назначение имеет пустой unassigned_at, снятое назначение остаётся в истории.
+-- Записи времени хранят пользователя, который фактически выполнял работу, и при переназначении не меняются.
+CREATE TABLE IF NOT EXISTS task_assignees (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_id INTEGER NOT NULL,
+    people_id INTEGER NOT NULL,
+    assigned_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    unassigned_at TIMESTAMP,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    FOREIGN KEY (people_id) REFERENCES people_info(id) ON DELETE CASCADE,
+    CONSTRAINT chk_task_assignee_period CHECK (unassigned_at IS NULL OR unassigned_at >= assigned_at)
+);
+
+-- Пользователь назначен на задачу не более одного раза одновременно
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_assignees_current ON task_assignees (task_id, people_id) WHERE unassigned_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_task_assignees_people_id ON task_assignees (people_id);
+
+-- Прежний единственный исполнитель - пользователь из записей времени задачи
+INSERT INTO task_assignees (task_id, people_id, assigned_at)
+SELECT task_id, people_id, MIN(COALESCE(start_time, created_at, CURRENT_TIMESTAMP))
+FROM time_entries
+WHERE people_id IS NOT NULL
+GROUP BY task_id, people_id;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_due_date;
+ALTER TABLE tasks DROP COLUMN estimate_seconds;
+ALTER TABLE tasks DROP COLUMN due_date;
@@ -0,0 +1,5 @@
+-- Срок выполнения и оценка задачи. Оценка хранится в секундах, NULL - задача без оценки или без срока.
+ALTER TABLE tasks ADD COLUMN due_date TIMESTAMP;
+ALTER TABLE tasks ADD COLUMN estimate_seconds INTEGER CONSTRAINT chk_tasks_estimate CHECK (estimate_seconds > 0);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_due_date ON tasks (due_date);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS recurrence_runs;
+DROP TABLE IF EXISTS recurrence_assignees;
+DROP INDEX IF EXISTS idx_recurrences_next_run;
+DROP TABLE IF EXISTS recurrences;
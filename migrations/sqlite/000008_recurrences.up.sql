@@ -0,0 +1,36 @@
+-- Повторяющиеся задачи: шаблон задачи и правило повторения RRULE.
+-- next_run - следующее повторение, NULL у исчерпанного правила, last_run - последнее созданное повторение.
+CREATE TABLE IF NOT EXISTS recurrences (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title VARCHAR(100) NOT NULL,
+    description TEXT,
+    project_id INTEGER,
+    estimate_seconds INTEGER CONSTRAINT chk_recurrences_estimate CHECK (estimate_seconds > 0),
+    rule VARCHAR(255) NOT NULL,
+    start_at TIMESTAMP NOT NULL,
+    next_run TIMESTAMP,
+    last_run TIMESTAMP,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurrences_next_run ON recurrences (next_run);
+
+-- Исполнители, назначаемые на каждую созданную задачу
+CREATE TABLE IF NOT EXISTS recurrence_assignees (
+    recurrence_id INTEGER NOT NULL,
+    people_id INTEGER NOT NULL,
+    PRIMARY KEY (recurrence_id, people_id),
+    FOREIGN KEY (recurrence_id) REFERENCES recurrences(id) ON DELETE CASCADE,
+    FOREIGN KEY (people_id) REFERENCES people_info(id) ON DELETE CASCADE
+);
+
+-- Созданные повторения. Первичный ключ не даёт создать задачу одного повторения дважды,
+-- в том числе после перезапуска.
+CREATE TABLE IF NOT EXISTS recurrence_runs (
+    recurrence_id INTEGER NOT NULL,
+    occurrence_at TIMESTAMP NOT NULL,
+    task_id INTEGER,
+    PRIMARY KEY (recurrence_id, occurrence_at),
+    FOREIGN KEY (recurrence_id) REFERENCES recurrences(id) ON DELETE CASCADE,
+    FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE SET NULL
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS task_template_items;
+DROP TABLE IF EXISTS task_templates;
+DROP INDEX IF EXISTS idx_task_checklist_items_task_id;
+DROP TABLE IF EXISTS task_checklist_items;
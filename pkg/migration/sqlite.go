@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	schema "TaskSync/migrations"
+	"database/sql"
+	"fmt"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// RunSQLiteMigrations применяет миграции SQLite, они лежат отдельно от миграций PostgreSQL
+// и встроены в исполняемый файл.
+func RunSQLiteMigrations(db *sql.DB) error {
+	const operation = "migrations.RunSQLiteMigrations"
+	source, err := iofs.New(schema.SQLite, "sqlite")
+	if err != nil {
+		return fmt.Errorf("%s - Failed to open migrations: %w", operation, err)
+	}
+
+	driver, err := sqlite.WithInstance(db, &sqlite.Config{})
+	if err != nil {
+		return fmt.Errorf("%s - Failed to create driver: %w", operation, err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, "sqlite", driver)
+	if err != nil {
+		return fmt.Errorf("%s - Failed to create migrate instance: %w", operation, err)
+	}
+
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		return fmt.Errorf("%s - Failed to run migrate up: %w", operation, err)
+	}
+	return nil
+}
@@ -0,0 +1,395 @@
+// Package rrule разбирает подмножество правил повторения RFC 5545 (RRULE) и вычисляет даты повторений.
+//
+// Поддерживаются FREQ=DAILY, WEEKLY и MONTHLY, INTERVAL, BYDAY, UNTIL и COUNT, например
+// "FREQ=WEEKLY;BYDAY=MO,TH" или "FREQ=MONTHLY;BYDAY=-1FR;COUNT=12".
+// Повторения считаются от начала DTSTART и наследуют его время суток,
+// сам DTSTART входит в повторения, только если подходит под правило.
+// Неделя начинается с понедельника.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRule правило не разобрано или использует неподдерживаемые части RRULE.
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+// Frequency частота повторения.
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// WeekdayNum день недели из BYDAY.
+// N - номер дня в месяце для MONTHLY: 1 - первый, -1 - последний, 0 - каждый такой день.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// Rule разобранное правило повторения.
+// Нулевой Until и нулевой Count означают бесконечное повторение.
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []WeekdayNum
+	Until    time.Time
+	Count    int
+}
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Форматы UNTIL: момент в UTC, локальное время трактуется как UTC, дата - до конца дня.
+const (
+	untilUTC   = "20060102T150405Z"
+	untilLocal = "20060102T150405"
+	untilDate  = "20060102"
+)
+
+// Parse разбирает правило, префикс "RRULE:" и регистр букв не важны.
+func Parse(s string) (Rule, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.TrimPrefix(s, "RRULE:")
+	if s == "" {
+		return Rule{}, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	r := Rule{Interval: 1}
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return Rule{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		if seen[key] {
+			return Rule{}, fmt.Errorf("%w: duplicate %s", ErrInvalidRule, key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			r.Freq, err = parseFreq(value)
+		case "INTERVAL":
+			r.Interval, err = parsePositive(key, value)
+		case "COUNT":
+			r.Count, err = parsePositive(key, value)
+		case "UNTIL":
+			r.Until, err = parseUntil(value)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		default:
+			err = fmt.Errorf("%w: unsupported part %s", ErrInvalidRule, key)
+		}
+		if err != nil {
+			return Rule{}, err
+		}
+	}
+
+	if r.Freq == "" {
+		return Rule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return Rule{}, fmt.Errorf("%w: UNTIL and COUNT cannot be combined", ErrInvalidRule)
+	}
+	for _, day := range r.ByDay {
+		if day.N != 0 && r.Freq != Monthly {
+			return Rule{}, fmt.Errorf("%w: numbered BYDAY is allowed only with FREQ=MONTHLY", ErrInvalidRule)
+		}
+	}
+
+	return r, nil
+}
+
+func parseFreq(value string) (Frequency, error) {
+	switch f := Frequency(value); f {
+	case Daily, Weekly, Monthly:
+		return f, nil
+	}
+	return "", fmt.Errorf("%w: unsupported FREQ %s", ErrInvalidRule, value)
+}
+
+func parsePositive(key, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%w: %s must be a positive integer", ErrInvalidRule, key)
+	}
+	return n, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse(untilUTC, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(untilLocal, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(untilDate, value); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: UNTIL must be a date or a UTC time, e.g. 20240131T090000Z", ErrInvalidRule)
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("%w: invalid BYDAY %q", ErrInvalidRule, item)
+		}
+
+		weekday, ok := weekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid BYDAY %q", ErrInvalidRule, item)
+		}
+
+		day := WeekdayNum{Weekday: weekday}
+		if prefix := item[:len(item)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("%w: invalid BYDAY %q", ErrInvalidRule, item)
+			}
+			day.N = n
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// String возвращает правило в каноническом виде RRULE без префикса.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = weekdayName(day.Weekday)
+			if day.N != 0 {
+				days[i] = strconv.Itoa(day.N) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilUTC))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	return strings.Join(parts, ";")
+}
+
+func weekdayName(weekday time.Weekday) string {
+	for name, w := range weekdays {
+		if w == weekday {
+			return name
+		}
+	}
+	return ""
+}
+
+// Next возвращает первое повторение позже after, false - повторений больше нет.
+func (r Rule) Next(dtstart, after time.Time) (time.Time, bool) {
+	var next time.Time
+	r.each(dtstart, after, func(t time.Time) bool {
+		if t.After(after) {
+			next = t
+			return false
+		}
+		return true
+	})
+	return next, !next.IsZero()
+}
+
+// Between возвращает повторения в промежутке от from до to включительно по порядку.
+func (r Rule) Between(dtstart, from, to time.Time) []time.Time {
+	var occurrences []time.Time
+	r.each(dtstart, from, func(t time.Time) bool {
+		if t.After(to) {
+			return false
+		}
+		if !t.Before(from) {
+			occurrences = append(occurrences, t)
+		}
+		return true
+	})
+	return occurrences
+}
+
+// maxEmptyPeriods ограничивает перебор правил, под которые не подходит ни один день,
+// например FREQ=DAILY;INTERVAL=7;BYDAY=TU при DTSTART в понедельник.
+const maxEmptyPeriods = 1000
+
+// each перебирает повторения по порядку, пока fn возвращает true.
+// Для правил без COUNT периоды, целиком лежащие раньше from, пропускаются без перебора.
+func (r Rule) each(dtstart, from time.Time, fn func(time.Time) bool) {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	count, empty := 0, 0
+	for period := r.skip(dtstart, from, interval); ; period++ {
+		candidates := r.period(dtstart, period*interval)
+		if len(candidates) == 0 {
+			if empty++; empty > maxEmptyPeriods {
+				return
+			}
+			continue
+		}
+		empty = 0
+
+		for _, t := range candidates {
+			if t.Before(dtstart) {
+				continue
+			}
+			if !r.Until.IsZero() && t.After(r.Until) {
+				return
+			}
+			count++
+			if !fn(t) {
+				return
+			}
+			if r.Count > 0 && count >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+// skip возвращает число периодов, которые можно пропустить до from.
+// С COUNT пропускать нельзя: повторения нумеруются от DTSTART.
+func (r Rule) skip(dtstart, from time.Time, interval int) int {
+	if r.Count > 0 || !from.After(dtstart) {
+		return 0
+	}
+
+	var units int
+	switch r.Freq {
+	case Daily:
+		units = int(from.Sub(dtstart) / (24 * time.Hour))
+	case Weekly:
+		units = int(from.Sub(weekStart(dtstart)) / (7 * 24 * time.Hour))
+	case Monthly:
+		units = (from.Year()-dtstart.Year())*12 + int(from.Month()-dtstart.Month())
+	}
+
+	// Один период про запас: границы периодов не совпадают с временем суток DTSTART
+	if skip := units/interval - 1; skip > 0 {
+		return skip
+	}
+	return 0
+}
+
+// period возвращает повторения-кандидаты периода, сдвинутого от DTSTART на offset дней, недель или месяцев.
+func (r Rule) period(dtstart time.Time, offset int) []time.Time {
+	switch r.Freq {
+	case Daily:
+		day := at(dtstart, dtstart.AddDate(0, 0, offset))
+		if len(r.ByDay) > 0 && !r.hasWeekday(day.Weekday()) {
+			return nil
+		}
+		return []time.Time{day}
+
+	case Weekly:
+		start := weekStart(dtstart).AddDate(0, 0, 7*offset)
+		if len(r.ByDay) == 0 {
+			return []time.Time{at(dtstart, start.AddDate(0, 0, mondayIndex(dtstart.Weekday())))}
+		}
+		var days []time.Time
+		for i := 0; i < 7; i++ {
+			day := start.AddDate(0, 0, i)
+			if r.hasWeekday(day.Weekday()) {
+				days = append(days, at(dtstart, day))
+			}
+		}
+		return days
+
+	case Monthly:
+		first := time.Date(dtstart.Year(), dtstart.Month()+time.Month(offset), 1, 0, 0, 0, 0, dtstart.Location())
+		last := first.AddDate(0, 1, -1).Day()
+		if len(r.ByDay) == 0 {
+			if dtstart.Day() > last {
+				return nil
+			}
+			return []time.Time{at(dtstart, first.AddDate(0, 0, dtstart.Day()-1))}
+		}
+		return r.monthDays(dtstart, first, last)
+	}
+
+	return nil
+}
+
+// monthDays возвращает дни месяца, подходящие под BYDAY, по порядку и без повторов.
+func (r Rule) monthDays(dtstart, first time.Time, last int) []time.Time {
+	matched := make(map[int]bool)
+	for _, spec := range r.ByDay {
+		var days []int
+		for d := 1; d <= last; d++ {
+			if first.AddDate(0, 0, d-1).Weekday() == spec.Weekday {
+				days = append(days, d)
+			}
+		}
+
+		switch {
+		case spec.N == 0:
+			for _, d := range days {
+				matched[d] = true
+			}
+		case spec.N > 0 && spec.N <= len(days):
+			matched[days[spec.N-1]] = true
+		case spec.N < 0 && -spec.N <= len(days):
+			matched[days[len(days)+spec.N]] = true
+		}
+	}
+
+	result := make([]time.Time, 0, len(matched))
+	for d := range matched {
+		result = append(result, at(dtstart, first.AddDate(0, 0, d-1)))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+	return result
+}
+
+func (r Rule) hasWeekday(weekday time.Weekday) bool {
+	for _, day := range r.ByDay {
+		if day.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// at возвращает день day со временем суток dtstart.
+func at(dtstart, day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), dtstart.Hour(), dtstart.Minute(), dtstart.Second(), 0, dtstart.Location())
+}
+
+// weekStart возвращает полночь понедельника недели t.
+func weekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return day.AddDate(0, 0, -mondayIndex(t.Weekday()))
+}
+
+// mondayIndex номер дня недели, начиная с понедельника.
+func mondayIndex(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
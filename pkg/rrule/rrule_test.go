@@ -0,0 +1,252 @@
+package rrule_test
+
+import (
+	"TaskSync/pkg/rrule"
+	"errors"
+	"testing"
+	"time"
+)
+
+// monday начало повторений в проверках: понедельник, 9:00 UTC.
+var monday = time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+// date возвращает момент в UTC, формат "2006-01-02 15:04".
+func date(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		t.Fatalf("parse %q: %v", s, err)
+	}
+	return d
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		from    string
+		to      string
+		want    []string
+	}{
+		{
+			name: "daily",
+			rule: "FREQ=DAILY",
+			from: "2026-01-05 00:00", to: "2026-01-08 12:00",
+			want: []string{"2026-01-05 09:00", "2026-01-06 09:00", "2026-01-07 09:00", "2026-01-08 09:00"},
+		},
+		{
+			name: "daily interval",
+			rule: "FREQ=DAILY;INTERVAL=2",
+			from: "2026-01-05 00:00", to: "2026-01-10 00:00",
+			want: []string{"2026-01-05 09:00", "2026-01-07 09:00", "2026-01-09 09:00"},
+		},
+		{
+			name: "weekly",
+			rule: "FREQ=WEEKLY",
+			from: "2026-01-05 00:00", to: "2026-01-20 00:00",
+			want: []string{"2026-01-05 09:00", "2026-01-12 09:00", "2026-01-19 09:00"},
+		},
+		{
+			name: "weekly by days",
+			rule: "FREQ=WEEKLY;BYDAY=MO,TH",
+			from: "2026-01-05 00:00", to: "2026-01-16 00:00",
+			want: []string{"2026-01-05 09:00", "2026-01-08 09:00", "2026-01-12 09:00", "2026-01-15 09:00"},
+		},
+		{
+			name: "weekly interval without dtstart day",
+			rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=WE",
+			from: "2026-01-01 00:00", to: "2026-02-05 00:00",
+			want: []string{"2026-01-07 09:00", "2026-01-21 09:00", "2026-02-04 09:00"},
+		},
+		{
+			name: "monthly",
+			rule: "FREQ=MONTHLY",
+			from: "2026-01-01 00:00", to: "2026-03-31 00:00",
+			want: []string{"2026-01-05 09:00", "2026-02-05 09:00", "2026-03-05 09:00"},
+		},
+		{
+			name: "monthly last friday",
+			rule: "FREQ=MONTHLY;BYDAY=-1FR",
+			from: "2026-01-01 00:00", to: "2026-03-31 00:00",
+			want: []string{"2026-01-30 09:00", "2026-02-27 09:00", "2026-03-27 09:00"},
+		},
+		{
+			name: "monthly second tuesday and first monday",
+			rule: "FREQ=MONTHLY;BYDAY=2TU,1MO",
+			from: "2026-01-01 00:00", to: "2026-02-28 00:00",
+			want: []string{"2026-01-05 09:00", "2026-01-13 09:00", "2026-02-02 09:00", "2026-02-10 09:00"},
+		},
+		{
+			name:    "monthly skips months without day 31",
+			rule:    "FREQ=MONTHLY",
+			dtstart: time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC),
+			from:    "2026-01-01 00:00", to: "2026-06-30 00:00",
+			want: []string{"2026-01-31 09:00", "2026-03-31 09:00", "2026-05-31 09:00"},
+		},
+		{
+			name: "until date includes the whole day",
+			rule: "FREQ=DAILY;UNTIL=20260107",
+			from: "2026-01-01 00:00", to: "2026-12-31 00:00",
+			want: []string{"2026-01-05 09:00", "2026-01-06 09:00", "2026-01-07 09:00"},
+		},
+		{
+			name: "until time is inclusive",
+			rule: "FREQ=DAILY;UNTIL=20260107T090000Z",
+			from: "2026-01-01 00:00", to: "2026-12-31 00:00",
+			want: []string{"2026-01-05 09:00", "2026-01-06 09:00", "2026-01-07 09:00"},
+		},
+		{
+			name: "until before occurrence time",
+			rule: "FREQ=DAILY;UNTIL=20260107T085959Z",
+			from: "2026-01-01 00:00", to: "2026-12-31 00:00",
+			want: []string{"2026-01-05 09:00", "2026-01-06 09:00"},
+		},
+		{
+			name: "count",
+			rule: "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=3",
+			from: "2026-01-01 00:00", to: "2026-12-31 00:00",
+			want: []string{"2026-01-05 09:00", "2026-01-07 09:00", "2026-01-09 09:00"},
+		},
+		{
+			name: "count is numbered from dtstart",
+			rule: "FREQ=DAILY;COUNT=3",
+			from: "2026-01-06 00:00", to: "2026-12-31 00:00",
+			want: []string{"2026-01-06 09:00", "2026-01-07 09:00"},
+		},
+		{
+			name: "catch-up after downtime",
+			rule: "FREQ=DAILY",
+			from: "2026-03-02 09:00", to: "2026-03-04 08:00",
+			want: []string{"2026-03-02 09:00", "2026-03-03 09:00"},
+		},
+		{
+			name: "catch-up a year later",
+			rule: "FREQ=WEEKLY;BYDAY=FR",
+			from: "2027-03-01 00:00", to: "2027-03-15 00:00",
+			want: []string{"2027-03-05 09:00", "2027-03-12 09:00"},
+		},
+		{
+			name: "catch-up of last friday a year later",
+			rule: "FREQ=MONTHLY;BYDAY=-1FR",
+			from: "2027-03-01 00:00", to: "2027-04-30 23:59",
+			want: []string{"2027-03-26 09:00", "2027-04-30 09:00"},
+		},
+		{
+			name: "nothing missed",
+			rule: "FREQ=DAILY",
+			from: "2026-03-02 09:01", to: "2026-03-03 08:59",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := rrule.Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.rule, err)
+			}
+
+			dtstart := tt.dtstart
+			if dtstart.IsZero() {
+				dtstart = monday
+			}
+
+			got := rule.Between(dtstart, date(t, tt.from), date(t, tt.to))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Between = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if want := date(t, tt.want[i]); !got[i].Equal(want) {
+					t.Fatalf("Between[%d] = %v, want %v", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   string
+		after  string
+		want   string
+		wantOK bool
+	}{
+		{name: "before dtstart", rule: "FREQ=DAILY", after: "2026-01-01 00:00", want: "2026-01-05 09:00", wantOK: true},
+		{name: "at occurrence", rule: "FREQ=DAILY", after: "2026-01-05 09:00", want: "2026-01-06 09:00", wantOK: true},
+		{name: "weekly by day", rule: "FREQ=WEEKLY;BYDAY=TU", after: "2026-01-06 09:00", want: "2026-01-13 09:00", wantOK: true},
+		{name: "last friday", rule: "FREQ=MONTHLY;BYDAY=-1FR", after: "2026-01-30 10:00", want: "2026-02-27 09:00", wantOK: true},
+		{name: "count exhausted", rule: "FREQ=DAILY;COUNT=2", after: "2026-01-06 09:00"},
+		{name: "until passed", rule: "FREQ=WEEKLY;UNTIL=20260115", after: "2026-01-12 09:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := rrule.Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.rule, err)
+			}
+
+			got, ok := rule.Next(monday, date(t, tt.after))
+			if ok != tt.wantOK {
+				t.Fatalf("Next = %v, %t, want ok %t", got, ok, tt.wantOK)
+			}
+			if ok && !got.Equal(date(t, tt.want)) {
+				t.Fatalf("Next = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		rule string
+		want string
+	}{
+		{rule: "FREQ=DAILY", want: "FREQ=DAILY"},
+		{rule: "rrule:freq=monthly;byday=-1fr;count=12", want: "FREQ=MONTHLY;BYDAY=-1FR;COUNT=12"},
+		{rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", want: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH"},
+		{rule: "FREQ=WEEKLY;INTERVAL=1", want: "FREQ=WEEKLY"},
+		{rule: "FREQ=DAILY;UNTIL=20260131T090000Z", want: "FREQ=DAILY;UNTIL=20260131T090000Z"},
+		{rule: "FREQ=DAILY;UNTIL=20260131", want: "FREQ=DAILY;UNTIL=20260131T235959Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := rrule.Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if got := rule.String(); got != tt.want {
+				t.Fatalf("String = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	rules := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=YEARLY",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=-1",
+		"FREQ=DAILY;COUNT=2;UNTIL=20260131",
+		"FREQ=DAILY;UNTIL=2026-01-31",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=MONTHLY;BYDAY=XX",
+		"FREQ=DAILY;BYMONTH=1",
+		"FREQ=DAILY;",
+	}
+
+	for _, s := range rules {
+		t.Run(s, func(t *testing.T) {
+			if _, err := rrule.Parse(s); !errors.Is(err, rrule.ErrInvalidRule) {
+				t.Fatalf("Parse(%q) error = %v, want ErrInvalidRule", s, err)
+			}
+		})
+	}
+}